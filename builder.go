@@ -0,0 +1,533 @@
+package rules
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"net/netip"
+	"reflect"
+	"regexp"
+	"strconv"
+
+	"github.com/alex4386/rules/parser"
+	"github.com/blang/semver"
+)
+
+// Rule is a rule built with And, Or, Not and the comparisons of Value. It
+// renders to canonical rule text and compiles the same as that text would, so
+// values never have to be quoted or escaped by hand. A rule built from a value
+// that can't be written in a rule reports the error when it is rendered or
+// compiled.
+type Rule struct {
+	node map[string]interface{}
+	err  error
+}
+
+// Text returns the rule in the canonical form of parser.Format
+func (r Rule) Text() (string, error) {
+	data, err := r.MarshalJSON()
+	if err != nil {
+		return "", err
+	}
+	return parser.RuleFromAST(data)
+}
+
+// String returns the rule text, or an empty string for an invalid rule
+func (r Rule) String() string {
+	text, _ := r.Text()
+	return text
+}
+
+// Evaluator compiles the rule
+func (r Rule) Evaluator(opts ...parser.EvaluatorOption) (*parser.Evaluator, error) {
+	text, err := r.Text()
+	if err != nil {
+		return nil, err
+	}
+	return parser.NewEvaluator(text, opts...)
+}
+
+// MarshalJSON returns the rule as the JSON AST of parser.EvaluatorFromAST
+func (r Rule) MarshalJSON() ([]byte, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	root := map[string]interface{}{"version": parser.ASTVersion}
+	for k, v := range r.node {
+		root[k] = v
+	}
+	return json.Marshal(root)
+}
+
+func invalid(err error) Rule {
+	return Rule{err: err}
+}
+
+func logical(op string, rules []Rule) Rule {
+	if len(rules) == 0 {
+		return invalid(fmt.Errorf("%s of no rules", op))
+	}
+	args := make([]interface{}, len(rules))
+	for i, r := range rules {
+		if r.err != nil {
+			return r
+		}
+		args[i] = r.node
+	}
+	return Rule{node: map[string]interface{}{"op": op, "args": args}}
+}
+
+// And is true if all the rules are
+func And(rules ...Rule) Rule {
+	return logical("and", rules)
+}
+
+// Or is true if any of the rules is
+func Or(rules ...Rule) Rule {
+	return logical("or", rules)
+}
+
+// Xor is true if an odd number of the rules are
+func Xor(rules ...Rule) Rule {
+	return logical("xor", rules)
+}
+
+func Not(r Rule) Rule {
+	return logical("not", []Rule{r})
+}
+
+func quantifier(op string, list Value, r Rule) Rule {
+	if list.err != nil {
+		return invalid(list.err)
+	}
+	if list.path == nil {
+		return invalid(fmt.Errorf("%s needs an attribute path", op))
+	}
+	if r.err != nil {
+		return r
+	}
+	return Rule{node: map[string]interface{}{"op": op, "path": list.path, "args": []interface{}{r.node}}}
+}
+
+// Any is true if r is true for an element of the list, see parser.QuantifierExpr
+func Any(list Value, r Rule) Rule {
+	return quantifier("any", list, r)
+}
+
+// All is true if r is true for every element of the list
+func All(list Value, r Rule) Rule {
+	return quantifier("all", list, r)
+}
+
+// None is true if r is true for no element of the list
+func None(list Value, r Rule) Rule {
+	return quantifier("none", list, r)
+}
+
+// Counter is the number of elements of a list a rule is true for, see Count
+type Counter struct {
+	quantified Rule
+}
+
+// Count counts the elements of the list r is true for, to compare it with a
+// number: Count(Path("answers"), Path("type").Eq("A")).Gt(2)
+func Count(list Value, r Rule) Counter {
+	return Counter{quantified: quantifier("count", list, r)}
+}
+
+func (c Counter) compare(cmp string, n interface{}) Rule {
+	if c.quantified.err != nil {
+		return c.quantified
+	}
+	node := map[string]interface{}{}
+	for k, v := range c.quantified.node {
+		node[k] = v
+	}
+	node["cmp"] = cmp
+	if err := setRight(node, n); err != nil {
+		return invalid(err)
+	}
+	return Rule{node: node}
+}
+
+func (c Counter) Eq(n interface{}) Rule { return c.compare("eq", n) }
+func (c Counter) Ne(n interface{}) Rule { return c.compare("ne", n) }
+func (c Counter) Gt(n interface{}) Rule { return c.compare("gt", n) }
+func (c Counter) Lt(n interface{}) Rule { return c.compare("lt", n) }
+func (c Counter) Ge(n interface{}) Rule { return c.compare("ge", n) }
+func (c Counter) Le(n interface{}) Rule { return c.compare("le", n) }
+
+// Value is an operand of a comparison other than a literal: an attribute
+// path, arithmetic or a function call. The right operand of a comparison is
+// either a Value or a literal: nil, a bool, a number, a string, a net.IP, a
+// *net.IPNet, a netip.Addr, a netip.Prefix, a semver.Version, a
+// *regexp.Regexp, a slice of numbers or strings, or one of IP, CIDR, Version
+// and Regex.
+type Value struct {
+	// path is set for attribute paths, which are written inline
+	path []interface{}
+	node map[string]interface{}
+	err  error
+}
+
+// Path is the attribute at the end of the names, `x.a` for Path("x", "a")
+func Path(names ...string) Value {
+	if len(names) == 0 {
+		return Value{err: fmt.Errorf("empty path")}
+	}
+	path := make([]interface{}, len(names))
+	for i, name := range names {
+		path[i] = name
+	}
+	return Value{path: path}
+}
+
+func (v Value) segment(seg interface{}) Value {
+	if v.err != nil || v.path == nil {
+		return Value{err: v.errOr(fmt.Errorf("only attribute paths have elements"))}
+	}
+	path := append(append([]interface{}{}, v.path...), seg)
+	return Value{path: path}
+}
+
+func (v Value) errOr(err error) error {
+	if v.err != nil {
+		return v.err
+	}
+	return err
+}
+
+// Field is the attribute name of v, which can be any string, `v["a.b"]`
+func (v Value) Field(name string) Value {
+	return v.segment(name)
+}
+
+// Index is an element of the list v, negative indexes count from the end
+func (v Value) Index(i int) Value {
+	return v.segment(i)
+}
+
+// Each is every element of the list v, `v[*]`
+func (v Value) Each() Value {
+	return v.segment(nil)
+}
+
+func (v Value) valueNode() (map[string]interface{}, error) {
+	if v.err != nil {
+		return nil, v.err
+	}
+	if v.path != nil {
+		return map[string]interface{}{"path": v.path}, nil
+	}
+	return v.node, nil
+}
+
+func operandNode(x interface{}) (map[string]interface{}, error) {
+	if v, ok := x.(Value); ok {
+		return v.valueNode()
+	}
+	return literal(x)
+}
+
+func (v Value) arith(op string, x interface{}) Value {
+	left, err := v.valueNode()
+	if err != nil {
+		return Value{err: err}
+	}
+	right, err := operandNode(x)
+	if err != nil {
+		return Value{err: err}
+	}
+	return Value{node: map[string]interface{}{"arith": op, "args": []interface{}{left, right}}}
+}
+
+func (v Value) Add(x interface{}) Value { return v.arith("+", x) }
+func (v Value) Sub(x interface{}) Value { return v.arith("-", x) }
+func (v Value) Mul(x interface{}) Value { return v.arith("*", x) }
+func (v Value) Div(x interface{}) Value { return v.arith("/", x) }
+func (v Value) Mod(x interface{}) Value { return v.arith("%", x) }
+
+// Call is a call of a built-in function, or one of the parser.Env the rule is
+// compiled with
+func Call(name string, args ...interface{}) Value {
+	nodes := make([]interface{}, len(args))
+	for i, arg := range args {
+		node, err := operandNode(arg)
+		if err != nil {
+			return Value{err: err}
+		}
+		nodes[i] = node
+	}
+	return Value{node: map[string]interface{}{"call": name, "args": nodes}}
+}
+
+func (v Value) compare(cmp string, not bool, right interface{}) Rule {
+	if v.err != nil {
+		return invalid(v.err)
+	}
+	node := map[string]interface{}{"cmp": cmp}
+	if not {
+		node["not"] = true
+	}
+	if v.path != nil {
+		node["path"] = v.path
+	} else {
+		node["left"] = v.node
+	}
+	if err := setRight(node, right); err != nil {
+		return invalid(err)
+	}
+	return Rule{node: node}
+}
+
+// setRight puts a literal inline in the node and a Value in "right"
+func setRight(node map[string]interface{}, right interface{}) error {
+	if v, ok := right.(Value); ok {
+		val, err := v.valueNode()
+		node["right"] = val
+		return err
+	}
+	lit, err := literal(right)
+	for k, v := range lit {
+		node[k] = v
+	}
+	return err
+}
+
+func (v Value) Eq(x interface{}) Rule    { return v.compare("eq", false, x) }
+func (v Value) Ne(x interface{}) Rule    { return v.compare("ne", false, x) }
+func (v Value) Gt(x interface{}) Rule    { return v.compare("gt", false, x) }
+func (v Value) Lt(x interface{}) Rule    { return v.compare("lt", false, x) }
+func (v Value) Ge(x interface{}) Rule    { return v.compare("ge", false, x) }
+func (v Value) Le(x interface{}) Rule    { return v.compare("le", false, x) }
+func (v Value) Co(x interface{}) Rule    { return v.compare("co", false, x) }
+func (v Value) Sw(x interface{}) Rule    { return v.compare("sw", false, x) }
+func (v Value) Ew(x interface{}) Rule    { return v.compare("ew", false, x) }
+func (v Value) NotCo(x interface{}) Rule { return v.compare("co", true, x) }
+func (v Value) NotSw(x interface{}) Rule { return v.compare("sw", true, x) }
+func (v Value) NotEw(x interface{}) Rule { return v.compare("ew", true, x) }
+
+// Mt matches v with a regular expression, a Regex, a *regexp.Regexp or a
+// Value holding one
+func (v Value) Mt(re interface{}) Rule    { return v.compare("mt", false, re) }
+func (v Value) NotMt(re interface{}) Rule { return v.compare("mt", true, re) }
+
+// Is is true if the IP address v is of the class, such as "private" or
+// "bogon"
+func (v Value) Is(class string) Rule {
+	return v.compare("is", false, Literal{value: class, typ: "ip_class"})
+}
+
+func (v Value) IsNot(class string) Rule {
+	return v.compare("is", true, Literal{value: class, typ: "ip_class"})
+}
+
+// Pr is true if the attribute path v is present
+func (v Value) Pr() Rule {
+	if v.err != nil || v.path == nil {
+		return invalid(v.errOr(fmt.Errorf("pr needs an attribute path")))
+	}
+	return Rule{node: map[string]interface{}{"cmp": "pr", "path": v.path}}
+}
+
+// In is true if v is one of the values, which are numbers or strings, or is
+// in one of the IP addresses and CIDRs. A single slice is taken as the list
+// of values.
+func (v Value) In(values ...interface{}) Rule {
+	return v.in(false, values)
+}
+
+func (v Value) NotIn(values ...interface{}) Rule {
+	return v.in(true, values)
+}
+
+func (v Value) in(not bool, values []interface{}) Rule {
+	if len(values) == 1 {
+		if list := reflect.ValueOf(values[0]); list.Kind() == reflect.Slice && !isIP(values[0]) {
+			values = make([]interface{}, list.Len())
+			for i := range values {
+				values[i] = list.Index(i).Interface()
+			}
+		}
+	}
+	if len(values) == 0 {
+		return invalid(fmt.Errorf("in of no values"))
+	}
+	if len(values) == 1 {
+		if _, ok := values[0].(Value); ok {
+			return v.compare("in", not, values[0])
+		}
+	}
+	if !isIP(values[0]) {
+		return v.compare("in", not, values)
+	}
+	if len(values) == 1 {
+		return v.compare("in", not, values[0])
+	}
+
+	entries := make([]string, len(values))
+	for i, val := range values {
+		entry, ok := ipEntry(val)
+		if !ok {
+			return invalid(fmt.Errorf("%v is not an IP address or CIDR", val))
+		}
+		entries[i] = entry
+	}
+	return v.compare("in", not, ipList(entries))
+}
+
+// ipList is a list of IP addresses and CIDRs
+type ipList []string
+
+// ipEntry is the text of an IP address or CIDR in an IP list
+func ipEntry(x interface{}) (string, bool) {
+	switch x := x.(type) {
+	case net.IP:
+		return x.String(), true
+	case *net.IPNet:
+		return x.String(), true
+	case net.IPNet:
+		return x.String(), true
+	case netip.Addr:
+		return x.String(), x.IsValid()
+	case netip.Prefix:
+		return x.String(), x.IsValid()
+	case Literal:
+		return x.value, x.typ == "ip" || x.typ == "cidr"
+	}
+	return "", false
+}
+
+func isIP(x interface{}) bool {
+	switch x.(type) {
+	case net.IP, *net.IPNet, net.IPNet, netip.Addr, netip.Prefix:
+		return true
+	}
+	return isLiteralType(x, "ip") || isLiteralType(x, "cidr")
+}
+
+func isLiteralType(x interface{}, typ string) bool {
+	lit, ok := x.(Literal)
+	return ok && lit.typ == typ
+}
+
+// Literal is a value that is written as a string in Go but is not a string in
+// rules
+type Literal struct {
+	value string
+	typ   string
+	flags string
+}
+
+// IP is an IP address such as "10.0.0.1"
+func IP(addr string) Literal {
+	return Literal{value: addr, typ: "ip"}
+}
+
+// CIDR is a CIDR range such as "10.0.0.0/8"
+func CIDR(cidr string) Literal {
+	return Literal{value: cidr, typ: "cidr"}
+}
+
+// IPRange is the range of IP addresses from first to last, both included,
+// such as IPRange("10.0.0.5", "10.0.0.99"). It can be used with In, alone or
+// with other addresses.
+func IPRange(first, last string) Literal {
+	return Literal{value: first + "-" + last, typ: "ip"}
+}
+
+// Version is a semantic version such as "1.2.3"
+func Version(version string) Literal {
+	return Literal{value: version, typ: "version"}
+}
+
+// Regex is a regular expression with the flags of the rule syntax, i and m
+func Regex(pattern, flags string) Literal {
+	return Literal{value: pattern, typ: "regex", flags: flags}
+}
+
+// literal returns the value, type and flags of a literal operand
+func literal(x interface{}) (map[string]interface{}, error) {
+	switch x := x.(type) {
+	case Literal:
+		node := map[string]interface{}{"value": x.value, "type": x.typ}
+		if x.flags != "" {
+			node["flags"] = x.flags
+		}
+		return node, nil
+	case net.IP:
+		return map[string]interface{}{"value": x.String(), "type": "ip"}, nil
+	case *net.IPNet:
+		return map[string]interface{}{"value": x.String(), "type": "cidr"}, nil
+	case net.IPNet:
+		return map[string]interface{}{"value": x.String(), "type": "cidr"}, nil
+	case netip.Addr:
+		return map[string]interface{}{"value": x.String(), "type": "ip"}, nil
+	case netip.Prefix:
+		return map[string]interface{}{"value": x.String(), "type": "cidr"}, nil
+	case ipList:
+		return map[string]interface{}{"value": []string(x), "type": "ip"}, nil
+	case semver.Version:
+		return map[string]interface{}{"value": x.String(), "type": "version"}, nil
+	case *regexp.Regexp:
+		return map[string]interface{}{"value": x.String(), "type": "regex"}, nil
+	}
+	val, err := jsonValue(x)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"value": val}, nil
+}
+
+// jsonValue is x as it is written in the JSON AST, keeping floats floats
+func jsonValue(x interface{}) (interface{}, error) {
+	if x == nil {
+		return nil, nil
+	}
+	switch val := reflect.ValueOf(x); val.Kind() {
+	case reflect.Bool, reflect.String:
+		return x, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return json.Number(strconv.FormatInt(val.Int(), 10)), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return json.Number(strconv.FormatUint(val.Uint(), 10)), nil
+	case reflect.Float32, reflect.Float64:
+		f := val.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, fmt.Errorf("%v can't be written in a rule", f)
+		}
+		text := strconv.FormatFloat(f, 'f', -1, 64)
+		if _, err := strconv.Atoi(text); err == nil {
+			text += ".0"
+		}
+		return json.Number(text), nil
+	case reflect.Slice:
+		list := make([]interface{}, val.Len())
+		floats := false
+		for i := range list {
+			elem := val.Index(i).Interface()
+			switch reflect.ValueOf(elem).Kind() {
+			case reflect.Float32, reflect.Float64:
+				floats = true
+			}
+			var err error
+			if list[i], err = jsonValue(elem); err != nil {
+				return nil, err
+			}
+		}
+		if floats {
+			// a list has numbers of one kind, an int in a list of floats
+			// becomes a float
+			for i, elem := range list {
+				if n, ok := elem.(json.Number); ok {
+					if _, err := strconv.Atoi(string(n)); err == nil {
+						list[i] = json.Number(string(n) + ".0")
+					}
+				}
+			}
+		}
+		return list, nil
+	}
+	return nil, fmt.Errorf("%T can't be written in a rule", x)
+}
@@ -0,0 +1,152 @@
+package rules
+
+import (
+	"net"
+	"net/netip"
+	"regexp"
+	"testing"
+
+	"github.com/alex4386/rules/parser"
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuilderText(t *testing.T) {
+	_, cidr, _ := net.ParseCIDR("192.168.0.0/16")
+	tests := []struct {
+		rule Rule
+		text string
+	}{
+		{Path("x", "a").Eq(1), `x.a eq 1`},
+		{Path("x").Ne(1.5), `x ne 1.5`},
+		{Path("x").Gt(2.0), `x gt 2.0`},
+		{Path("x").Lt(int64(-3)), `x lt -3`},
+		{Path("x").Ge(uint8(4)), `x ge 4`},
+		{Path("x").Le(Path("y")), `x le y`},
+		{Path("s").Eq(`say "hi" \ bye`), `s eq "say \"hi\" \\ bye"`},
+		{Path("s").Co("a"), `s co "a"`},
+		{Path("s").Sw("a"), `s sw "a"`},
+		{Path("s").Ew("a"), `s ew "a"`},
+		{Path("s").NotCo("a"), `s not co "a"`},
+		{Path("s").NotSw("a"), `s not sw "a"`},
+		{Path("s").NotEw("a"), `s not ew "a"`},
+		{Path("s").In("b", "a", "b"), `s in ["a", "b"]`},
+		{Path("n").In([]int{3, 1}), `n in [1, 3]`},
+		{Path("n").In(1, 2.5), `n in [1.0, 2.5]`},
+		{Path("n").NotIn(1), `n not in [1]`},
+		{Path("s").Mt(Regex("^a.*z$", "i")), `s mt /^a.*z$/i`},
+		{Path("s").NotMt(regexp.MustCompile(`^a`)), `s not mt /^a/`},
+		{Path("s").Mt(Path("pattern")), `s mt pattern`},
+		{Path("x").Pr(), `x pr`},
+		{Path("x").Eq(nil), `x eq null`},
+		{Path("x").Eq(true), `x eq true`},
+		{Path("v").Ge(Version("1.2.3")), `v ge 1.2.3`},
+		{Path("v").Lt(semver.MustParse("2.0.0")), `v lt 2.0.0`},
+		{Path("ip").Eq(net.ParseIP("10.0.0.1")), `ip eq 10.0.0.1`},
+		{Path("ip").Eq(IP("::1")), `ip eq ::1`},
+		{Path("ip").In(CIDR("10.0.0.0/8")), `ip in 10.0.0.0/8`},
+		{Path("ip").In(CIDR("10.0.0.0/8"), cidr, IP("172.16.0.1")), `ip in [10.0.0.0/8, 172.16.0.1, 192.168.0.0/16]`},
+		{Path("ip").In(IP("fd00::1"), IP("fd00::1"), CIDR("fd00::/8")), `ip in [fd00::/8, fd00::1]`},
+		{Path("ip").NotIn([]*net.IPNet{cidr}), `ip not in 192.168.0.0/16`},
+		{Path("ip").Eq(netip.MustParseAddr("::ffff:10.0.0.1")), `ip eq ::ffff:10.0.0.1`},
+		{Path("ip").In(netip.MustParsePrefix("10.0.0.0/8"), netip.MustParseAddr("fd00::1")), `ip in [10.0.0.0/8, fd00::1]`},
+		{Path("ip").In(IPRange("10.0.0.5", "10.0.0.99")), `ip in 10.0.0.5-10.0.0.99`},
+		{Path("ip").NotIn(IPRange("10.0.0.5", "10.0.0.99"), CIDR("192.168.0.0/16")), `ip not in [10.0.0.5-10.0.0.99, 192.168.0.0/16]`},
+		{Path("src").Is("private"), `src is private`},
+		{Path("src").IsNot("bogon"), `src is not bogon`},
+		{Path("ip").Ge(IP("10.0.0.0")), `ip ge 10.0.0.0`},
+		{Path("ip").Lt(netip.MustParseAddr("10.0.4.0")), `ip lt 10.0.4.0`},
+		{Path("ip").NotIn(IP("10.0.0.1"), CIDR("10.0.0.0/8")), `ip not in [10.0.0.0/8, 10.0.0.1]`},
+		{Path("hops").Index(-1).Field("ip").In(cidr), `hops[-1].ip in 192.168.0.0/16`},
+		{Path("labels").Field("app.kubernetes.io/name").Eq("web"), `labels["app.kubernetes.io/name"] eq "web"`},
+		{Path("items").Each().Field("sku").Co("a"), `items[*].sku co "a"`},
+		{Path("a").Add(Path("b")).Mul(2).Gt(Path("c").Sub(1)), `(a + b) * 2 gt c - 1`},
+		{Path("a").Div(2).Mod(3).Eq(1), `a / 2 % 3 eq 1`},
+		{Call("len", Path("name")).Gt(Call("max", 1, 2)), `len(name) gt max(1, 2)`},
+		{And(Path("x").Eq(1), Or(Path("y").Eq(2), Path("z").Eq(3))), `x eq 1 and (y eq 2 or z eq 3)`},
+		{Or(And(Path("x").Eq(1), Path("y").Eq(2)), Path("z").Eq(3)), `x eq 1 and y eq 2 or z eq 3`},
+		{Xor(Path("x").Pr(), Path("y").Pr()), `x pr xor y pr`},
+		{Not(And(Path("x").Pr(), Path("y").Pr())), `not (x pr and y pr)`},
+		{Any(Path("items"), Path("qty").Gt(1)), `any(items, qty gt 1)`},
+		{All(Path("items"), Path("sku").Pr()), `all(items, sku pr)`},
+		{None(Path("items"), Path("qty").Lt(0)), `none(items, qty lt 0)`},
+		{Count(Path("answers"), Path("type").Eq("A")).Gt(2), `count(answers, type eq "A") gt 2`},
+	}
+	for _, tt := range tests {
+		text, err := tt.rule.Text()
+		require.NoError(t, err, tt.text)
+		require.Equal(t, tt.text, text)
+		require.Equal(t, tt.text, tt.rule.String())
+	}
+}
+
+func TestBuilderCompiles(t *testing.T) {
+	rule := And(
+		Path("x", "a").Eq(1),
+		Path("ip").In(CIDR("10.0.0.0/8"), CIDR("192.168.0.0/16")),
+		Path("name").NotIn("root", "admin"),
+		Any(Path("items"), Path("qty").Ge(2)),
+	)
+	ev, err := rule.Evaluator()
+	require.NoError(t, err)
+
+	// the same compiled form as the text
+	fromText, err := parser.NewEvaluator(rule.String())
+	require.NoError(t, err)
+	require.Equal(t, fromText.Expr(), ev.Expr())
+
+	input := map[string]interface{}{
+		"x":     map[string]interface{}{"a": 1},
+		"ip":    "192.168.1.1",
+		"name":  "guest",
+		"items": []interface{}{map[string]interface{}{"qty": 3}},
+	}
+	match, err := ev.Process(input)
+	require.NoError(t, err)
+	require.True(t, match)
+
+	input["name"] = "root"
+	match, err = ev.Process(input)
+	require.NoError(t, err)
+	require.False(t, match)
+
+	data, err := rule.MarshalJSON()
+	require.NoError(t, err)
+	fromAST, err := parser.EvaluatorFromAST(data)
+	require.NoError(t, err)
+	require.Equal(t, fromText.Expr(), fromAST.Expr())
+}
+
+func TestBuilderErrors(t *testing.T) {
+	tests := []Rule{
+		Path().Eq(1),
+		Path("x").Eq(struct{}{}),
+		Path("x").Eq(map[string]interface{}{}),
+		Path("x").In(),
+		Path("x").In(1, "a"),
+		Path("x").In(IP("10.0.0.1"), "10.0.0.2"),
+		Path("x").In(IP("10.0.0.1"), CIDR("10.0.0.0/8] or [x pr")),
+		Path("x").Eq(IP("10.0.0.1 or y pr")),
+		Path("x").Mt(Regex("a/ or y mt /b", "")),
+		Path("x").Is("public"),
+		Path("x").Is("private or y pr"),
+		Path("and").Eq(1),
+		Call("len", Path("x")).Index(0).Eq(1),
+		Call("len", Path("x")).Pr(),
+		Any(Call("len", Path("x")), Path("y").Pr()),
+		And(),
+		And(Path("x").Pr(), Path("y").Eq(struct{}{})),
+		Not(Path().Pr()),
+	}
+	for _, rule := range tests {
+		_, err := rule.Text()
+		require.Error(t, err)
+		require.Equal(t, "", rule.String())
+		_, err = rule.Evaluator()
+		require.Error(t, err)
+	}
+
+	// a valid AST of an invalid rule, the function doesn't exist
+	_, err := Call("nope").Eq(1).Evaluator()
+	require.Error(t, err)
+}
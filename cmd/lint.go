@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/alex4386/rules/parser"
+)
+
+// lint reports the issues parser.Lint finds in rule files, one rule per line,
+// and returns the exit code
+func lint(files []string) int {
+	if len(files) == 0 {
+		files = []string{"rules.txt"}
+	}
+	status := 0
+	for _, file := range files {
+		found, err := lintFile(file)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 2
+		}
+		if found {
+			status = 1
+		}
+	}
+	return status
+}
+
+func lintFile(file string) (bool, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return false, fmt.Errorf("Error reading rules from file %v", err)
+	}
+	defer f.Close()
+
+	found := false
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		rule := scanner.Text()
+		if trimmed := strings.TrimSpace(rule); trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		issues, err := parser.Lint(rule)
+		var parseErr *parser.ParseError
+		if errors.As(err, &parseErr) {
+			for _, e := range parseErr.Errors {
+				fmt.Printf("%s:%d:%d: %s\n", file, line, e.Column+1, e.Msg)
+			}
+			found = true
+			continue
+		} else if err != nil {
+			return found, err
+		}
+		for _, issue := range issues {
+			fmt.Printf("%s:%d:%d: %s\n", file, line, issue.Span.Start+1, issue)
+			found = true
+		}
+	}
+	return found, scanner.Err()
+}
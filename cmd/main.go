@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
+	"os"
 
 	"github.com/alex4386/rules/parser"
 )
@@ -12,6 +13,10 @@ import (
 type obj map[string]interface{}
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "lint" {
+		os.Exit(lint(os.Args[2:]))
+	}
+
 	jsonFile, err := ioutil.ReadFile("test.json")
 	if err != nil {
 		log.Fatal(fmt.Errorf("Error reading obj from file %v", err))
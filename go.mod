@@ -5,8 +5,10 @@ go 1.21
 require (
 	github.com/antlr4-go/antlr/v4 v4.13.0
 	github.com/blang/semver v3.5.1+incompatible
+	github.com/fsnotify/fsnotify v1.7.0
 	github.com/stretchr/testify v1.3.0
 	github.com/wasilibs/go-re2 v1.5.2
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -15,4 +17,5 @@ require (
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/tetratelabs/wazero v1.7.0 // indirect
 	golang.org/x/exp v0.0.0-20230515195305-f3d0a9c9a5cc // indirect
+	golang.org/x/sys v0.13.0 // indirect
 )
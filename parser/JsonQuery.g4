@@ -1,20 +1,47 @@
 grammar JsonQuery;
 
+root
+   : query EOF
+   ;
+
 query
-   : NOT? SP? '(' SP? query SP? ')'                                                       #parenExp
-   | query SP LOGICAL_OPERATOR SP query                                                   #logicalExp
+   : SP? '(' SP? query SP? ')'                                                            #parenExp
+   | NOT SP? query                                                                        #notExp
+   | query SP op=AND SP query                                                             #logicalExp
+   | query SP op=XOR SP query                                                             #logicalExp
+   | query SP op=OR SP query                                                              #logicalExp
+   | ATTRNAME '(' SP? attrPath SP? COMMA SP? query SP? ')'                                #quantifierExp
+   | ATTRNAME '(' SP? attrPath SP? COMMA SP? query SP? ')' SP op=( EQ | NE | GT | LT | GE | LE ) SP value  #countExp
    | attrPath SP 'pr'                                                                     #presentExp
-   | attrPath SP op=( EQ | NE | IN ) SP ipValue                                           #ipCompareExp
-   | attrPath SP op=( EQ | NE | GT | LT | GE | LE | CO | SW | EW | IN ) SP value          #compareExp
-   | attrPath SP op=MT SP regexValue                                                      #regexExp
+   | arith SP (NOT SP)? op=( EQ | NE | GT | LT | GE | LE | IN ) SP ( ipValue | listIPs )  #ipCompareExp
+   | arith SP (NOT SP)? op=( EQ | NE | GT | LT | GE | LE | CO | SW | EW | IN ) SP arith     #compareExp
+   | arith SP (NOT SP)? op=MT SP regexValue                                               #regexExp
+   | arith SP op=IS SP (NOT SP)? ipClass                                                  #ipClassExp
+   ;
+
+arith
+   : '(' SP? arith SP? ')'                                                                #parenArith
+   | ATTRNAME '(' SP? ')'                                                                 #callArith
+   | ATTRNAME '(' SP? arith (SP? COMMA SP? arith)* SP? ')'                                #callArith
+   | arith SP op=( STAR | SLASH | PERCENT ) SP arith                                      #mulArith
+   | arith SP op=( PLUS | MINUS ) SP arith                                                #addArith
+   | value                                                                                #valueArith
    ;
 
 NOT
-   : 'not' | 'NOT'
+   : 'not' | 'NOT' | '!'
    ;
 
-LOGICAL_OPERATOR
-   : 'and' | 'or'
+AND
+   : 'and' | 'AND' | '&&'
+   ;
+
+XOR
+   : 'xor' | 'XOR'
+   ;
+
+OR
+   : 'or' | 'OR' | '||'
    ;
 
 BOOLEAN
@@ -26,6 +53,7 @@ NULL
    ;
 
 IN:  'IN' | 'in';
+IS:  'IS' | 'is';
 EQ : 'eq' | 'EQ' | 'equals' | 'EQUALS' | '==';
 NE : 'ne' | 'NE' | 'noteq' | 'NOTEQ' | '!=';
 GT : 'gt' | 'GT' | '>';
@@ -37,12 +65,18 @@ SW : 'sw' | 'SW' | 'startsWith';
 EW : 'ew' | 'EW' | 'endsWith';
 MT : 'mt' | 'MT' | 'matches' | 'MATCHES' | '~=';
 
+PLUS : '+';
+MINUS : '-';
+STAR : '*';
+SLASH : '/';
+PERCENT : '%';
+
 attrPath
-   : ATTRNAME subAttr?
+   : ATTRNAME subAttr*
    ;
 
 valueAttrPath
-   : ATTRNAME valueSubAttr?
+   : ATTRNAME subAttr*
    ;
 
 JSON_SEP
@@ -50,11 +84,10 @@ JSON_SEP
     ;
 
 subAttr
-   : JSON_SEP attrPath
-   ;
-
-valueSubAttr
-   : JSON_SEP valueAttrPath
+   : JSON_SEP ATTRNAME                                                                    #fieldAttr
+   | '[' '-'? INT ']'                                                                     #indexAttr
+   | '[' '*' ']'                                                                          #wildcardAttr
+   | '[' STRING ']'                                                                       #keyAttr
    ;
 
 ATTRNAME
@@ -98,8 +131,9 @@ regexValue
     | valueAttrPath
     ;
 
+// only after a match operator, so that `a / b / c` is a division
 REGEX
-   : ('/' (REGEX_ESC | ~ [/\\]*) '/' REGEX_FLAGS? REGEX_FLAGS? REGEX_FLAGS?)
+   : ('/' {l.regexAllowed()}? (REGEX_ESC | ~ [/\\]*) '/' REGEX_FLAGS? REGEX_FLAGS? REGEX_FLAGS?)
    ;
 
 fragment REGEX_ESC
@@ -111,9 +145,14 @@ fragment REGEX_FLAGS
    : [igm]
    ;
 
+ipClass
+    : ATTRNAME
+    ;
+
 ipValue
     : IP_ADDRESS
     | IP_CIDR
+    | IP_RANGE
     ;
 
 listIPs
@@ -134,6 +173,11 @@ IP_CIDR
     |   IPv6 '/' INT
     ;
 
+// both ends of a range are checked to be of the same family by the visitor
+IP_RANGE
+    :   (IPv4 | IPv6) '-' (IPv4 | IPv6)
+    ;
+
 fragment IPv4
     :   OCTET '.' OCTET '.' OCTET '.' OCTET
     ;
@@ -150,6 +194,11 @@ fragment IPv6
     :   (HEX_QUARTET ':') (HEX_QUARTET ':') (HEX_QUARTET ':') (HEX_QUARTET ':') (HEX_QUARTET ':')  HEX_QUARTET (':' HEX_QUARTET)?  // Basic IPv6 (six quartets + one or two more)
     |   '::' (HEX_QUARTET ':')* HEX_QUARTET                    // Leading ::
     |   (HEX_QUARTET ':')* ':' (HEX_QUARTET ':')* HEX_QUARTET // Embedded ::
+    |   (HEX_QUARTET ':')+ ':'                                 // Trailing ::
+    |   '::'
+    |   (HEX_QUARTET ':') (HEX_QUARTET ':') (HEX_QUARTET ':') (HEX_QUARTET ':') (HEX_QUARTET ':') (HEX_QUARTET ':') IPv4  // Six quartets + IPv4
+    |   '::' (HEX_QUARTET ':')* IPv4                           // Leading :: + IPv4, e.g. ::ffff:1.2.3.4
+    |   (HEX_QUARTET ':')+ ':' (HEX_QUARTET ':')* IPv4         // Embedded :: + IPv4
     ;
 
 fragment HEX_QUARTET
@@ -206,11 +255,14 @@ EXP
    : [Ee] [+\-]? INT
    ;
 
-NEWLINE
-   : '\n' ;
+fragment NEWLINE
+   : '\r'? '\n'
+   ;
 
 COMMA
    : ',' ' '*;
+
+// any run of white space, so that rules can be split over indented lines
 SP
-   : ' ' NEWLINE*
+   : (' ' | '\t' | NEWLINE)+
    ;
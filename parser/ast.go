@@ -0,0 +1,942 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+)
+
+// Expr is a node of a compiled rule that evaluates to a boolean. The nodes are
+// built once by NewEvaluator and never modified afterwards, so a compiled rule
+// can be evaluated from many goroutines at the same time.
+type Expr interface {
+	eval(s *evalState) Truth
+}
+
+// Value is a node that produces an operand for a comparison: a path, a
+// literal, arithmetic or a function call. String returns it as written in a
+// rule.
+type Value interface {
+	fmt.Stringer
+	value(s *evalState) (Operand, error)
+}
+
+// Span is where a node is written in its rule, as offsets in characters
+type Span struct {
+	Start int
+	End   int
+}
+
+type LogicalOp int
+
+const (
+	LogicalAnd LogicalOp = iota
+	LogicalOr
+	LogicalXor
+)
+
+func (op LogicalOp) String() string {
+	switch op {
+	case LogicalAnd:
+		return "and"
+	case LogicalOr:
+		return "or"
+	case LogicalXor:
+		return "xor"
+	}
+	return fmt.Sprintf("LogicalOp(%d)", int(op))
+}
+
+type CompareOp int
+
+const (
+	CompareEQ CompareOp = iota
+	CompareNE
+	CompareGT
+	CompareLT
+	CompareGE
+	CompareLE
+	CompareCO
+	CompareSW
+	CompareEW
+	CompareIN
+	CompareMT
+	CompareIS
+)
+
+var compareOpNames = [...]string{
+	CompareEQ: "eq",
+	CompareNE: "ne",
+	CompareGT: "gt",
+	CompareLT: "lt",
+	CompareGE: "ge",
+	CompareLE: "le",
+	CompareCO: "co",
+	CompareSW: "sw",
+	CompareEW: "ew",
+	CompareIN: "in",
+	CompareMT: "mt",
+	CompareIS: "is",
+}
+
+func (op CompareOp) String() string {
+	if op >= 0 && int(op) < len(compareOpNames) {
+		return compareOpNames[op]
+	}
+	return fmt.Sprintf("CompareOp(%d)", int(op))
+}
+
+// Negatable reports whether the operation can be written in its negated infix
+// form, e.g. `x not in [1, 2]` or `x is not private`
+func (op CompareOp) Negatable() bool {
+	switch op {
+	case CompareIN, CompareCO, CompareSW, CompareEW, CompareMT, CompareIS:
+		return true
+	}
+	return false
+}
+
+func (op CompareOp) apply(o Operation) func(Operand, Operand) (bool, error) {
+	switch op {
+	case CompareEQ:
+		return o.EQ
+	case CompareNE:
+		return o.NE
+	case CompareGT:
+		return o.GT
+	case CompareLT:
+		return o.LT
+	case CompareGE:
+		return o.GE
+	case CompareLE:
+		return o.LE
+	case CompareCO:
+		return o.CO
+	case CompareSW:
+		return o.SW
+	case CompareEW:
+		return o.EW
+	case CompareIN:
+		return o.IN
+	case CompareMT:
+		return o.MT
+	case CompareIS:
+		// only addresses have classes
+		if c, ok := o.(interface {
+			IS(left Operand, right Operand) (bool, error)
+		}); ok {
+			return c.IS
+		}
+		return func(Operand, Operand) (bool, error) { return false, ErrInvalidOperation }
+	}
+	return nil
+}
+
+type LogicalExpr struct {
+	Span  Span
+	Op    LogicalOp
+	Left  Expr
+	Right Expr
+}
+
+func (e *LogicalExpr) eval(s *evalState) Truth {
+	left := s.eval(e.Left)
+	switch e.Op {
+	case LogicalOr:
+		if left == True {
+			return left
+		}
+		return left.or(s.eval(e.Right))
+	case LogicalXor:
+		// both sides always have to be looked at
+		return left.xor(s.eval(e.Right))
+	}
+	// means it is and
+	if left == False {
+		return left
+	}
+	return left.and(s.eval(e.Right))
+}
+
+type NotExpr struct {
+	Span Span
+	Expr Expr
+}
+
+func (e *LogicalExpr) span() Span {
+	return e.Span
+}
+
+func (e *NotExpr) eval(s *evalState) Truth {
+	return s.eval(e.Expr).not()
+}
+
+func (e *NotExpr) span() Span {
+	return e.Span
+}
+
+type PresentExpr struct {
+	Span Span
+	Path *Path
+}
+
+func (e *PresentExpr) eval(s *evalState) Truth {
+	return truth(e.Path.resolve(s) != nil)
+}
+
+func (e *PresentExpr) span() Span {
+	return e.Span
+}
+
+// CompareExpr compares two values. Not is set for the negated
+// infix forms such as `x not in [1, 2]`, which mean the same as
+// `not (x in [1, 2])`.
+type CompareExpr struct {
+	Span  Span
+	Op    CompareOp
+	Not   bool
+	Left  Value
+	Right Value
+}
+
+// operation picks the Operation the same way the rule visitor always did: a
+// literal decides by its own kind, a variable by the type of the left operand.
+// Types with an Operation of their own come first though, see Operable.
+func (e *CompareExpr) operation(left, right Operand) Operation {
+	if op := customOperation(left); op != nil {
+		return op
+	}
+	if op := customOperation(right); op != nil {
+		return op
+	}
+	if lit, ok := e.Right.(*Literal); ok {
+		return numericOperation(lit.op, left, right)
+	}
+	if e.Op == CompareMT {
+		return &RegexOperation{}
+	}
+	// the addresses decide, whatever the type of the address
+	if isAddrSet(right) {
+		return &IPCompareOperation{}
+	}
+	return numericOperation(GetCurrentOperationByRight(left), left, right)
+}
+
+func (e *CompareExpr) eval(s *evalState) Truth {
+	ret := e.compare(s)
+	if e.Not {
+		return ret.not()
+	}
+	return ret
+}
+
+func (e *CompareExpr) compare(s *evalState) Truth {
+	left, err := e.Left.value(s)
+	if err != nil {
+		s.setDebug(e, err, left, nil)
+		return False
+	}
+	right, err := e.Right.value(s)
+	if err != nil {
+		s.setDebug(e, err, left, right)
+		return False
+	}
+	if s.tracer != nil {
+		s.tracer.operands(left, right)
+	}
+	// `x eq null` is how a rule asks for a missing attribute, which is not unknown
+	if (s.unknown || s.strict) && (left == nil || right == nil) && !isNullLiteral(e.Left) && !isNullLiteral(e.Right) {
+		s.setDebug(e, ErrEvalOperandMissing, left, right)
+		return s.missing(nil)
+	}
+	if e.Op == CompareCO {
+		if list, ok := listElems(left); ok {
+			return truth(e.containsElem(s, list, right))
+		}
+	}
+
+	currentOp := e.operation(left, right)
+	if s.tracer != nil {
+		s.tracer.operation(currentOp)
+	}
+	if currentOp == nil {
+		s.setErr(newNestedError(ErrInvalidOperation, "No operation for datatype").Set(ErrVals{
+			"attr_path":           e.Left.String(),
+			"object_path_operand": left,
+		}))
+		return False
+	}
+
+	ret, err := e.Op.apply(currentOp)(left, right)
+	if err != nil {
+		if err == ErrInvalidOperation {
+			// in case of invalid operation lets rather
+			// be conservative and return false because the rule doesn't even make
+			// sense. It can be argued that it would be false positive if we were
+			// to return true
+			s.setErr(err)
+		}
+		s.setDebug(e, err, left, right)
+		return False
+	}
+	return truth(ret)
+}
+
+func isNullLiteral(v Value) bool {
+	lit, ok := v.(*Literal)
+	return ok && lit.Kind == LiteralNull
+}
+
+// containsElem is `co` on a list, e.g. the result of a wildcard path: true
+// when any element equals the right operand. The right operand decides how
+// elements are compared since the elements may be of mixed types.
+func (e *CompareExpr) containsElem(s *evalState, list []interface{}, right Operand) bool {
+	op := e.operation(right, right)
+	if s.tracer != nil {
+		s.tracer.operation(op)
+	}
+	for _, elem := range list {
+		elemOp := op
+		if custom := customOperation(elem); custom != nil {
+			elemOp = custom
+		}
+		if elemOp == nil {
+			continue
+		}
+		ok, err := elemOp.EQ(elem, right)
+		if err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+// listElems returns the elements of a list operand. Byte slices such as
+// net.IP are values in their own right and are not treated as lists.
+func listElems(v Operand) ([]interface{}, bool) {
+	if list, ok := v.([]interface{}); ok {
+		return list, true
+	}
+	if v == nil {
+		return nil, false
+	}
+	val := reflect.ValueOf(v)
+	if (val.Kind() != reflect.Slice && val.Kind() != reflect.Array) || val.Type().Elem().Kind() == reflect.Uint8 {
+		return nil, false
+	}
+	list := make([]interface{}, val.Len())
+	for i := range list {
+		list[i] = val.Index(i).Interface()
+	}
+	return list, true
+}
+
+func (e *CompareExpr) debugError(err error, left, right Operand) error {
+	return compareDebugError(e.Op, e.Left.String(), err, left, right)
+}
+
+func (e *CompareExpr) span() Span {
+	return e.Span
+}
+
+// attrPath is the side of the comparison at fault, the right one only if the
+// left one is there and the right one isn't
+func (e *CompareExpr) attrPath(left, right Operand) string {
+	if left != nil && right == nil {
+		return e.Right.String()
+	}
+	return e.Left.String()
+}
+
+func compareDebugError(op CompareOp, attrPath string, err error, left, right Operand) error {
+	switch err {
+	case ErrInvalidOperation:
+		return newNestedError(err, "Not a valid operation for datatypes").Set(ErrVals{
+			"operation":           op.String(),
+			"object_path_operand": left,
+			"rule_operand":        right,
+		})
+	case ErrEvalOperandMissing:
+		return newNestedError(err, "Eval operand missing in input object").Set(ErrVals{
+			"attr_path":           attrPath,
+			"object_path_operand": left,
+			"rule_operand":        right,
+		})
+	}
+	switch err.(type) {
+	case *ErrInvalidOperand:
+		return newNestedError(err, "operands are not the right value type").Set(ErrVals{
+			"attr_path":           attrPath,
+			"object_path_operand": left,
+			"rule_operand":        right,
+		})
+	}
+	return newNestedError(err, "unknown error").Set(ErrVals{
+		"attr_path":           attrPath,
+		"object_path_operand": left,
+		"rule_operand":        right,
+	})
+}
+
+type Quantifier int
+
+const (
+	QuantifierAny Quantifier = iota
+	QuantifierAll
+	QuantifierNone
+)
+
+func (q Quantifier) String() string {
+	switch q {
+	case QuantifierAny:
+		return "any"
+	case QuantifierAll:
+		return "all"
+	case QuantifierNone:
+		return "none"
+	}
+	return fmt.Sprintf("Quantifier(%d)", int(q))
+}
+
+// QuantifierExpr is `any(list, query)`, `all(list, query)` or
+// `none(list, query)`. The query is evaluated with every element of the list
+// as its root. A missing attribute or one that is not a list never matches,
+// an empty list matches all and none but not any.
+type QuantifierExpr struct {
+	Span       Span
+	Quantifier Quantifier
+	Path       *Path
+	Expr       Expr
+}
+
+func (e *QuantifierExpr) eval(s *evalState) Truth {
+	val := e.Path.resolve(s)
+	list, ok := listElems(val)
+	if !ok {
+		s.setDebug(e, listError(val), val, nil)
+		return s.missing(val)
+	}
+
+	unknown := false
+	for _, elem := range list {
+		matched := s.evalOn(elem, e.Expr)
+		switch {
+		case e.Quantifier == QuantifierAny && matched == True:
+			return True
+		case e.Quantifier == QuantifierAll && matched == False:
+			return False
+		case e.Quantifier == QuantifierNone && matched == True:
+			return False
+		}
+		unknown = unknown || matched == Unknown
+	}
+	if unknown {
+		return Unknown
+	}
+	return truth(e.Quantifier != QuantifierAny)
+}
+
+func (e *QuantifierExpr) debugError(err error, left, right Operand) error {
+	return compareDebugError(CompareEQ, e.Path.String(), err, left, right)
+}
+
+func (e *QuantifierExpr) span() Span {
+	return e.Span
+}
+
+func (e *QuantifierExpr) attrPath(left, right Operand) string {
+	return e.Path.String()
+}
+
+// CountExpr is `count(list, query)` compared with a number, the count being
+// the number of elements of the list the query matches
+type CountExpr struct {
+	Span  Span
+	Op    CompareOp
+	Path  *Path
+	Expr  Expr
+	Right Value
+}
+
+func (e *CountExpr) eval(s *evalState) Truth {
+	val := e.Path.resolve(s)
+	right, err := e.Right.value(s)
+	if err != nil {
+		s.setDebug(e, err, nil, right)
+		return False
+	}
+	list, ok := listElems(val)
+	if !ok {
+		s.setDebug(e, listError(val), val, right)
+		return s.missing(val)
+	}
+	if right == nil && s.unknown {
+		s.setDebug(e, ErrEvalOperandMissing, nil, right)
+		return Unknown
+	}
+
+	count := 0
+	for _, elem := range list {
+		switch s.evalOn(elem, e.Expr) {
+		case True:
+			count++
+		case Unknown:
+			// the count could be anything up to the number of elements
+			return Unknown
+		}
+	}
+
+	var op Operation = &IntOperation{}
+	if lit, ok := e.Right.(*Literal); ok {
+		op = lit.op
+	}
+	op = numericOperation(op, count, right)
+	if s.tracer != nil {
+		s.tracer.operands(count, right)
+		s.tracer.operation(op)
+	}
+	ret, err := e.Op.apply(op)(count, right)
+	if err != nil {
+		if err == ErrInvalidOperation {
+			s.setErr(err)
+		}
+		s.setDebug(e, err, count, right)
+		return False
+	}
+	return truth(ret)
+}
+
+func (e *CountExpr) debugError(err error, left, right Operand) error {
+	return compareDebugError(e.Op, "count("+e.Path.String()+")", err, left, right)
+}
+
+func (e *CountExpr) span() Span {
+	return e.Span
+}
+
+func (e *CountExpr) attrPath(left, right Operand) string {
+	if left != nil && right == nil {
+		return e.Right.String()
+	}
+	return e.Path.String()
+}
+
+func listError(val Operand) error {
+	if val == nil {
+		return ErrEvalOperandMissing
+	}
+	return newErrInvalidOperand(val, []interface{}{})
+}
+
+type SegmentKind int
+
+const (
+	// SegmentField is a map key or struct field, `.name` or `["name"]`
+	SegmentField SegmentKind = iota
+	// SegmentIndex is a list element, `[0]` or `[-1]` for the last one
+	SegmentIndex
+	// SegmentWildcard is every element of a list, `[*]`
+	SegmentWildcard
+)
+
+type PathSegment struct {
+	Kind  SegmentKind
+	Name  string
+	Index int
+}
+
+// Path is an attribute path such as `x.a[0].b`, resolved against the input
+// item. A path with a wildcard resolves to a []interface{} holding the value
+// of the rest of the path for every element, or nil if there is none.
+type Path struct {
+	Segments []PathSegment
+}
+
+// NewPath returns a path made of plain field names
+func NewPath(names ...string) *Path {
+	path := &Path{}
+	for _, name := range names {
+		path.Segments = append(path.Segments, PathSegment{Kind: SegmentField, Name: name})
+	}
+	return path
+}
+
+func (p *Path) value(s *evalState) (Operand, error) {
+	return p.resolve(s), nil
+}
+
+func (p *Path) resolve(s *evalState) Operand {
+	return resolvePath(s.item, p.Segments)
+}
+
+func resolvePath(item interface{}, segments []PathSegment) interface{} {
+	for i, seg := range segments {
+		if item == nil {
+			return nil
+		}
+		switch seg.Kind {
+		case SegmentField:
+			item = GetSubAttr(item, seg.Name)
+		case SegmentIndex:
+			item = GetIndex(item, seg.Index)
+		case SegmentWildcard:
+			return resolveWildcard(item, segments[i+1:])
+		}
+	}
+	return item
+}
+
+func resolveWildcard(item interface{}, rest []PathSegment) interface{} {
+	nested := false
+	for _, seg := range rest {
+		nested = nested || seg.Kind == SegmentWildcard
+	}
+
+	var out []interface{}
+	collect := func(elem interface{}) {
+		val := resolvePath(elem, rest)
+		if val == nil {
+			return
+		}
+		if nested {
+			out = append(out, val.([]interface{})...)
+			return
+		}
+		out = append(out, val)
+	}
+
+	if list, ok := item.([]interface{}); ok {
+		for _, elem := range list {
+			collect(elem)
+		}
+	} else {
+		val := reflect.ValueOf(item)
+		if val.Kind() != reflect.Slice && val.Kind() != reflect.Array {
+			return nil
+		}
+		for i := 0; i < val.Len(); i++ {
+			collect(val.Index(i).Interface())
+		}
+	}
+
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+func (p *Path) String() string {
+	var sb strings.Builder
+	for i, seg := range p.Segments {
+		switch seg.Kind {
+		case SegmentField:
+			if isAttrName(seg.Name) {
+				if i > 0 {
+					sb.WriteByte('.')
+				}
+				sb.WriteString(seg.Name)
+			} else {
+				sb.WriteByte('[')
+				sb.WriteString(quoteString(seg.Name))
+				sb.WriteByte(']')
+			}
+		case SegmentIndex:
+			sb.WriteString("[" + strconv.Itoa(seg.Index) + "]")
+		case SegmentWildcard:
+			sb.WriteString("[*]")
+		}
+	}
+	return sb.String()
+}
+
+// isAttrName reports whether name can be written without quotes, i.e. it
+// matches the ATTRNAME token and is not a keyword
+func isAttrName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, c := range name {
+		alpha := (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+		if i == 0 && !alpha {
+			return false
+		}
+		if !alpha && !(c >= '0' && c <= '9') && c != '-' && c != '_' {
+			return false
+		}
+	}
+	lex := NewJsonQueryLexer(antlr.NewInputStream(name))
+	lex.RemoveErrorListeners()
+	return lex.NextToken().GetTokenType() == JsonQueryLexerATTRNAME
+}
+
+// quoteString renders s as a STRING token
+func quoteString(s string) string {
+	var sb strings.Builder
+	enc := json.NewEncoder(&sb)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+type LiteralKind int
+
+const (
+	LiteralNull LiteralKind = iota
+	LiteralBool
+	LiteralInt
+	LiteralFloat
+	LiteralString
+	LiteralVersion
+	LiteralRegex
+	LiteralIP
+	LiteralCIDR
+	LiteralIntList
+	LiteralFloatList
+	LiteralStringList
+	LiteralIPList
+	LiteralIPRange
+	LiteralIPClass
+)
+
+// Literal is a constant operand of a rule. Val holds the parsed value, e.g. a
+// compiled regex for LiteralRegex, a netip.Prefix for LiteralCIDR, an *IPList
+// for LiteralIPList, an IPRange for LiteralIPRange or an IPClass for
+// LiteralIPClass, and Text the
+// literal as it was written in the rule.
+type Literal struct {
+	Kind LiteralKind
+	Val  Operand
+	Text string
+
+	op Operation
+}
+
+func newLiteral(kind LiteralKind, val Operand, text string) *Literal {
+	return &Literal{
+		Kind: kind,
+		Val:  val,
+		Text: text,
+		op:   operationForLiteral(kind),
+	}
+}
+
+func operationForLiteral(kind LiteralKind) Operation {
+	switch kind {
+	case LiteralBool:
+		return &BoolOperation{}
+	case LiteralInt, LiteralIntList:
+		return &IntOperation{}
+	case LiteralFloat, LiteralFloatList:
+		return &FloatOperation{}
+	case LiteralString, LiteralStringList:
+		return &StringOperation{}
+	case LiteralVersion:
+		return &VersionOperation{}
+	case LiteralRegex:
+		return &RegexOperation{}
+	case LiteralIP, LiteralCIDR, LiteralIPList, LiteralIPRange, LiteralIPClass:
+		return &IPCompareOperation{}
+	}
+	return &NullOperation{}
+}
+
+func (l *Literal) value(s *evalState) (Operand, error) {
+	return l.Val, nil
+}
+
+func (l *Literal) String() string {
+	return l.Text
+}
+
+type ArithOp int
+
+const (
+	ArithAdd ArithOp = iota
+	ArithSub
+	ArithMul
+	ArithDiv
+	ArithMod
+)
+
+var arithOpNames = [...]string{
+	ArithAdd: "+",
+	ArithSub: "-",
+	ArithMul: "*",
+	ArithDiv: "/",
+	ArithMod: "%",
+}
+
+func (op ArithOp) String() string {
+	if op >= 0 && int(op) < len(arithOpNames) {
+		return arithOpNames[op]
+	}
+	return fmt.Sprintf("ArithOp(%d)", int(op))
+}
+
+func (op ArithOp) precedence() int {
+	if op == ArithAdd || op == ArithSub {
+		return 0
+	}
+	return 1
+}
+
+// ArithExpr is arithmetic on two numbers. Integers stay integers except for
+// division, which is always a float64 as is anything involving a float. A missing operand
+// makes the result missing too.
+type ArithExpr struct {
+	Op    ArithOp
+	Left  Value
+	Right Value
+}
+
+func (e *ArithExpr) value(s *evalState) (Operand, error) {
+	left, err := e.Left.value(s)
+	if err != nil || left == nil {
+		return nil, err
+	}
+	right, err := e.Right.value(s)
+	if err != nil || right == nil {
+		return nil, err
+	}
+	return arith(e.Op, left, right)
+}
+
+// String adds the parentheses needed to keep the order of evaluation, the
+// operators being left associative
+func (e *ArithExpr) String() string {
+	left, right := e.Left.String(), e.Right.String()
+	if l, ok := e.Left.(*ArithExpr); ok && l.Op.precedence() < e.Op.precedence() {
+		left = "(" + left + ")"
+	}
+	if r, ok := e.Right.(*ArithExpr); ok && r.Op.precedence() <= e.Op.precedence() {
+		right = "(" + right + ")"
+	}
+	return left + " " + e.Op.String() + " " + right
+}
+
+// CallExpr is a function call such as `len(x)`
+type CallExpr struct {
+	Name string
+	Args []Value
+
+	fn *Function
+}
+
+func (e *CallExpr) value(s *evalState) (Operand, error) {
+	args := make([]Operand, len(e.Args))
+	for i, arg := range e.Args {
+		val, err := arg.value(s)
+		if err != nil || val == nil {
+			return nil, err
+		}
+		if args[i], err = e.fn.paramType(i).convert(val); err != nil {
+			return nil, err
+		}
+	}
+	return e.fn.Call(args)
+}
+
+func (e *CallExpr) String() string {
+	args := make([]string, len(e.Args))
+	for i, arg := range e.Args {
+		args[i] = arg.String()
+	}
+	return e.Name + "(" + strings.Join(args, ", ") + ")"
+}
+
+type evalState struct {
+	item interface{}
+
+	err error
+
+	// the last comparison that failed, only turned into a debug error once
+	// the evaluation is done since most callers never look at it
+	debugExpr  debugNode
+	debugCause error
+	debugLeft  Operand
+	debugRight Operand
+
+	doPanic bool
+	unknown bool
+	strict  bool
+	rule    []rune
+
+	tracer *tracer
+}
+
+// eval evaluates a child node, recording it when tracing
+func (s *evalState) eval(e Expr) Truth {
+	if s.tracer == nil {
+		return e.eval(s)
+	}
+	t := s.tracer.enter(e, s.rule)
+	ret := e.eval(s)
+	s.tracer.leave(t, ret, s.rule)
+	return ret
+}
+
+// missing is the result of an expression on a missing or invalid value: Unknown
+// if the value is missing and the rule was compiled WithUnknown
+func (s *evalState) missing(val Operand) Truth {
+	if val == nil && s.unknown {
+		return Unknown
+	}
+	return False
+}
+
+// debugNode is a node that can explain why it did not match
+type debugNode interface {
+	debugError(err error, left, right Operand) error
+	span() Span
+	// attrPath is the attribute or value the error is about
+	attrPath(left, right Operand) string
+}
+
+func (s *evalState) setDebug(e debugNode, err error, left, right Operand) {
+	if s.doPanic {
+		panic(e.debugError(err, left, right))
+	}
+	if s.strict && s.err == nil && isStrictError(err) {
+		span := e.span()
+		s.err = &EvalError{
+			Path:     e.attrPath(left, right),
+			Fragment: string(s.rule[span.Start:span.End]),
+			Span:     span,
+			Err:      err,
+		}
+	}
+	s.debugExpr = e
+	s.debugCause = err
+	s.debugLeft = left
+	s.debugRight = right
+}
+
+func (s *evalState) debugErr() error {
+	if s.debugCause == nil {
+		return nil
+	}
+	return s.debugExpr.debugError(s.debugCause, s.debugLeft, s.debugRight)
+}
+
+// evalOn evaluates expr with item as the root of every path in it
+func (s *evalState) evalOn(item interface{}, expr Expr) Truth {
+	root := s.item
+	s.item = item
+	if s.tracer != nil {
+		s.tracer.element = item
+	}
+	matched := s.eval(expr)
+	s.item = root
+	return matched
+}
+
+func (s *evalState) setErr(err error) {
+	if s.doPanic {
+		panic(err)
+	}
+	s.err = err
+}
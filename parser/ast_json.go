@@ -0,0 +1,579 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ASTVersion is the version of the JSON AST written by Evaluator.MarshalJSON
+// and the latest one EvaluatorFromAST reads
+const ASTVersion = 1
+
+// astNode is a query in the JSON AST:
+//
+//	{"op": "and", "args": [...]}                  and, or, xor, not
+//	{"op": "any", "path": [...], "args": [query]}  any, all, none
+//	{"op": "count", "path": [...], "args": [query], "cmp": "ge", "value": 2}
+//	{"cmp": "eq", "path": ["x", "a"], "value": 1}
+//	{"cmp": "pr", "path": ["x"]}
+//
+// A comparison has the attribute path or "left" on the left, and the literal
+// "value" or "right" on the right. Path segments are field names, indexes,
+// and null for [*].
+type astNode struct {
+	Version int    `json:"version,omitempty"`
+	Op      string `json:"op,omitempty"`
+	Cmp     string `json:"cmp,omitempty"`
+	Not     bool   `json:"not,omitempty"`
+
+	Path  []interface{}   `json:"path,omitempty"`
+	Left  *astValue       `json:"left,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+	Type  string          `json:"type,omitempty"`
+	Flags string          `json:"flags,omitempty"`
+	Right *astValue       `json:"right,omitempty"`
+	Args  []*astNode      `json:"args,omitempty"`
+}
+
+// astValue is an operand in the JSON AST, one of:
+//
+//	{"path": ["x", 0, null]}
+//	{"value": 1}, {"value": "10.0.0.0/8", "type": "cidr"}, {"value": "^a", "type": "regex", "flags": "i"}
+//	{"value": ["10.0.0.0/8", "::1"], "type": "ip"}
+//	{"arith": "+", "args": [value, value]}
+//	{"call": "len", "args": [value...]}
+type astValue struct {
+	Path  []interface{}   `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+	Type  string          `json:"type,omitempty"`
+	Flags string          `json:"flags,omitempty"`
+	Arith string          `json:"arith,omitempty"`
+	Call  string          `json:"call,omitempty"`
+	Args  []*astValue     `json:"args,omitempty"`
+}
+
+// MarshalJSON returns the rule as a JSON AST, see EvaluatorFromAST
+func (e *Evaluator) MarshalJSON() ([]byte, error) {
+	node := exprNode(e.expr)
+	node.Version = ASTVersion
+	return json.Marshal(node)
+}
+
+func exprNode(e Expr) *astNode {
+	switch e := e.(type) {
+	case *LogicalExpr:
+		node := &astNode{Op: e.Op.String()}
+		for _, term := range chain(e.Op, e, nil) {
+			node.Args = append(node.Args, exprNode(term))
+		}
+		return node
+	case *NotExpr:
+		return &astNode{Op: "not", Args: []*astNode{exprNode(e.Expr)}}
+	case *PresentExpr:
+		return &astNode{Cmp: "pr", Path: pathSegments(e.Path)}
+	case *CompareExpr:
+		node := &astNode{Cmp: e.Op.String(), Not: e.Not}
+		if path, ok := e.Left.(*Path); ok {
+			node.Path = pathSegments(path)
+		} else {
+			node.Left = valueNode(e.Left)
+		}
+		setRight(node, e.Right)
+		return node
+	case *QuantifierExpr:
+		return &astNode{
+			Op:   e.Quantifier.String(),
+			Path: pathSegments(e.Path),
+			Args: []*astNode{exprNode(e.Expr)},
+		}
+	case *CountExpr:
+		node := &astNode{
+			Op:   "count",
+			Cmp:  e.Op.String(),
+			Path: pathSegments(e.Path),
+			Args: []*astNode{exprNode(e.Expr)},
+		}
+		setRight(node, e.Right)
+		return node
+	}
+	return &astNode{}
+}
+
+// setRight puts a literal inline in the node and anything else in "right"
+func setRight(node *astNode, right Value) {
+	if _, ok := right.(*Literal); ok {
+		val := valueNode(right)
+		node.Value, node.Type, node.Flags = val.Value, val.Type, val.Flags
+		return
+	}
+	node.Right = valueNode(right)
+}
+
+var literalASTTypes = map[LiteralKind]string{
+	LiteralVersion: "version",
+	LiteralIP:      "ip",
+	LiteralIPList:  "ip",
+	LiteralIPRange: "ip",
+	LiteralIPClass: "ip_class",
+	LiteralCIDR:    "cidr",
+	LiteralRegex:   "regex",
+}
+
+func valueNode(v Value) *astValue {
+	switch v := v.(type) {
+	case *Path:
+		return &astValue{Path: pathSegments(v)}
+	case *Literal:
+		l := canonicalLiteral(v)
+		node := &astValue{Type: literalASTTypes[l.Kind]}
+		switch l.Kind {
+		case LiteralVersion, LiteralIP, LiteralCIDR, LiteralIPRange, LiteralIPClass:
+			node.Value = json.RawMessage(quoteString(l.Text))
+		case LiteralIPList:
+			entries := strings.Split(strings.Trim(l.Text, "[]"), ", ")
+			for i, entry := range entries {
+				entries[i] = quoteString(entry)
+			}
+			node.Value = json.RawMessage("[" + strings.Join(entries, ", ") + "]")
+		case LiteralRegex:
+			regex, flags := splitRegex(l.Text)
+			node.Value, node.Flags = json.RawMessage(quoteString(regex)), flags
+		default:
+			// numbers, strings and lists are written the same in rules and JSON
+			node.Value = json.RawMessage(l.Text)
+		}
+		return node
+	case *ArithExpr:
+		return &astValue{Arith: v.Op.String(), Args: []*astValue{valueNode(v.Left), valueNode(v.Right)}}
+	case *CallExpr:
+		node := &astValue{Call: v.Name}
+		for _, arg := range v.Args {
+			node.Args = append(node.Args, valueNode(arg))
+		}
+		return node
+	}
+	return &astValue{}
+}
+
+func pathSegments(p *Path) []interface{} {
+	segments := make([]interface{}, len(p.Segments))
+	for i, seg := range p.Segments {
+		switch seg.Kind {
+		case SegmentField:
+			segments[i] = seg.Name
+		case SegmentIndex:
+			segments[i] = seg.Index
+		}
+	}
+	return segments
+}
+
+// EvaluatorFromAST compiles a rule from the JSON AST written by
+// Evaluator.MarshalJSON, as if it was the rule RuleFromAST returns
+func EvaluatorFromAST(data []byte, opts ...EvaluatorOption) (*Evaluator, error) {
+	rule, err := RuleFromAST(data)
+	if err != nil {
+		return nil, err
+	}
+	return NewEvaluator(rule, opts...)
+}
+
+// RuleFromAST returns the rule of a JSON AST in the canonical form of Format
+func RuleFromAST(data []byte) (string, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	dec.DisallowUnknownFields()
+	var node astNode
+	if err := dec.Decode(&node); err != nil {
+		return "", fmt.Errorf("invalid AST: %v", err)
+	}
+	if node.Version > ASTVersion {
+		return "", fmt.Errorf("invalid AST: unsupported version %d", node.Version)
+	}
+	expr, err := node.expr("")
+	if err != nil {
+		return "", fmt.Errorf("invalid AST: %v", err)
+	}
+	return FormatExpr(expr), nil
+}
+
+var logicalOps = map[string]LogicalOp{
+	"and": LogicalAnd,
+	"or":  LogicalOr,
+	"xor": LogicalXor,
+}
+
+func compareOp(name string) (CompareOp, bool) {
+	for op, opName := range compareOpNames {
+		if opName == name {
+			return CompareOp(op), true
+		}
+	}
+	return 0, false
+}
+
+// at is where a node is in the AST, for errors
+func at(where, field string) string {
+	if where == "" {
+		return field
+	}
+	return where + "." + field
+}
+
+func (n *astNode) expr(where string) (Expr, error) {
+	if n == nil {
+		return nil, fmt.Errorf("%s: missing query", where)
+	}
+	if op, ok := logicalOps[n.Op]; ok {
+		if len(n.Args) == 0 {
+			return nil, fmt.Errorf("%s: %s without args", at(where, "op"), n.Op)
+		}
+		var expr Expr
+		for i, arg := range n.Args {
+			term, err := arg.expr(at(where, fmt.Sprintf("args[%d]", i)))
+			if err != nil {
+				return nil, err
+			}
+			if expr == nil {
+				expr = term
+			} else {
+				expr = &LogicalExpr{Op: op, Left: expr, Right: term}
+			}
+		}
+		return expr, nil
+	}
+
+	switch n.Op {
+	case "":
+		return n.compare(where)
+	case "not":
+		inner, err := n.single(where)
+		if err != nil {
+			return nil, err
+		}
+		return &NotExpr{Expr: inner}, nil
+	case "count":
+		path, err := n.path(where)
+		if err != nil {
+			return nil, err
+		}
+		inner, err := n.single(where)
+		if err != nil {
+			return nil, err
+		}
+		op, ok := compareOp(n.Cmp)
+		if !ok || op > CompareLE {
+			return nil, fmt.Errorf("%s: invalid count comparison %q", at(where, "cmp"), n.Cmp)
+		}
+		right, err := n.right(where)
+		if err != nil {
+			return nil, err
+		}
+		return &CountExpr{Op: op, Path: path, Expr: inner, Right: right}, nil
+	}
+	quantifier, ok := quantifiers[n.Op]
+	if !ok {
+		return nil, fmt.Errorf("%s: unknown operator %q", at(where, "op"), n.Op)
+	}
+	path, err := n.path(where)
+	if err != nil {
+		return nil, err
+	}
+	inner, err := n.single(where)
+	if err != nil {
+		return nil, err
+	}
+	return &QuantifierExpr{Quantifier: quantifier, Path: path, Expr: inner}, nil
+}
+
+// single is the only query in args
+func (n *astNode) single(where string) (Expr, error) {
+	if len(n.Args) != 1 {
+		return nil, fmt.Errorf("%s: %s takes one query, not %d", at(where, "args"), n.Op, len(n.Args))
+	}
+	return n.Args[0].expr(at(where, "args[0]"))
+}
+
+func (n *astNode) path(where string) (*Path, error) {
+	return astPath(n.Path, at(where, "path"))
+}
+
+func (n *astNode) compare(where string) (Expr, error) {
+	if n.Cmp == "pr" {
+		path, err := n.path(where)
+		if err != nil {
+			return nil, err
+		}
+		return &PresentExpr{Path: path}, nil
+	}
+	op, ok := compareOp(n.Cmp)
+	if !ok {
+		return nil, fmt.Errorf("%s: unknown comparison %q", at(where, "cmp"), n.Cmp)
+	}
+	if n.Not && !op.Negatable() {
+		return nil, fmt.Errorf("%s: %s can't be negated", at(where, "not"), n.Cmp)
+	}
+
+	var left Value
+	var err error
+	switch {
+	case n.Left != nil && n.Path != nil:
+		return nil, fmt.Errorf("%s: both path and left", where)
+	case n.Left != nil:
+		left, err = n.Left.value(at(where, "left"))
+	default:
+		left, err = n.path(where)
+	}
+	if err != nil {
+		return nil, err
+	}
+	right, err := n.right(where)
+	if err != nil {
+		return nil, err
+	}
+	return &CompareExpr{Op: op, Not: n.Not, Left: left, Right: right}, nil
+}
+
+func (n *astNode) right(where string) (Value, error) {
+	switch {
+	case n.Right != nil && n.Value != nil:
+		return nil, fmt.Errorf("%s: both value and right", where)
+	case n.Right != nil:
+		return n.Right.value(at(where, "right"))
+	}
+	val := &astValue{Value: n.Value, Type: n.Type, Flags: n.Flags}
+	return val.literal(where)
+}
+
+func (v *astValue) value(where string) (Value, error) {
+	switch {
+	case v.Path != nil:
+		return astPath(v.Path, at(where, "path"))
+	case v.Arith != "":
+		op, ok := arithOp(v.Arith)
+		if !ok {
+			return nil, fmt.Errorf("%s: unknown operator %q", at(where, "arith"), v.Arith)
+		}
+		if len(v.Args) != 2 {
+			return nil, fmt.Errorf("%s: %s takes two values, not %d", at(where, "args"), v.Arith, len(v.Args))
+		}
+		left, err := v.Args[0].value(at(where, "args[0]"))
+		if err != nil {
+			return nil, err
+		}
+		right, err := v.Args[1].value(at(where, "args[1]"))
+		if err != nil {
+			return nil, err
+		}
+		return &ArithExpr{Op: op, Left: left, Right: right}, nil
+	case v.Call != "":
+		if !attrNamePattern.MatchString(v.Call) {
+			return nil, fmt.Errorf("%s: invalid function name %q", at(where, "call"), v.Call)
+		}
+		call := &CallExpr{Name: v.Call}
+		for i, arg := range v.Args {
+			val, err := arg.value(at(where, fmt.Sprintf("args[%d]", i)))
+			if err != nil {
+				return nil, err
+			}
+			call.Args = append(call.Args, val)
+		}
+		return call, nil
+	}
+	return v.literal(where)
+}
+
+func arithOp(name string) (ArithOp, bool) {
+	for op, opName := range arithOpNames {
+		if opName == name {
+			return ArithOp(op), true
+		}
+	}
+	return 0, false
+}
+
+var (
+	attrNamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]*$`)
+	versionPattern  = regexp.MustCompile(`^(0|[1-9][0-9]*)\.(0|[1-9][0-9]*)\.(0|[1-9][0-9]*)$`)
+	regexEscape     = regexp.MustCompile(`^\\[\\/bfnrtbBdDwWsS]$`)
+)
+
+// literal is the value of the node as a Literal that Format writes the way
+// the rule grammar reads it. Anything that can't be written as a single
+// token is rejected, so that values can't change the rule around them.
+func (v *astValue) literal(where string) (*Literal, error) {
+	where = at(where, "value")
+	if v.Value == nil {
+		return nil, fmt.Errorf("%s: missing value", where)
+	}
+	var val interface{}
+	dec := json.NewDecoder(bytes.NewReader(v.Value))
+	dec.UseNumber()
+	if err := dec.Decode(&val); err != nil {
+		return nil, fmt.Errorf("%s: %v", where, err)
+	}
+
+	if list, ok := val.([]interface{}); ok && v.Type == "ip" {
+		return ipListLiteral(list, where)
+	}
+	if v.Type != "" {
+		text, ok := val.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s: %s has to be a string", where, v.Type)
+		}
+		switch v.Type {
+		case "version":
+			if versionPattern.MatchString(text) {
+				return &Literal{Kind: LiteralVersion, Text: text}, nil
+			}
+		case "ip":
+			if net.ParseIP(text) != nil {
+				return &Literal{Kind: LiteralIP, Text: text}, nil
+			}
+			if _, err := ParseIPRange(text); err == nil {
+				return &Literal{Kind: LiteralIPRange, Text: text}, nil
+			}
+		case "ip_class":
+			if _, ok := ParseIPClass(text); ok {
+				return &Literal{Kind: LiteralIPClass, Text: text}, nil
+			}
+		case "cidr":
+			if _, _, err := net.ParseCIDR(text); err == nil {
+				return &Literal{Kind: LiteralCIDR, Text: text}, nil
+			}
+		case "regex":
+			if strings.Trim(v.Flags, "gim") != "" {
+				return nil, fmt.Errorf("%s: invalid regex flags %q", at(where, "flags"), v.Flags)
+			}
+			if !regexEscape.MatchString(text) && strings.ContainsAny(text, `/\`) {
+				return nil, fmt.Errorf("%s: regex %q can't be written in a rule", where, text)
+			}
+			// compiled the way the evaluator compiles it
+			if _, err := compileRegexFlags(text, v.Flags); err != nil {
+				return nil, fmt.Errorf("%s: invalid regex %q: %v", where, text, err)
+			}
+			return &Literal{Kind: LiteralRegex, Text: "/" + text + "/" + v.Flags}, nil
+		default:
+			return nil, fmt.Errorf("%s: unknown type %q", at(where, "type"), v.Type)
+		}
+		return nil, fmt.Errorf("%s: invalid %s %q", where, v.Type, text)
+	}
+
+	switch val := val.(type) {
+	case nil:
+		return &Literal{Kind: LiteralNull, Text: "null"}, nil
+	case bool:
+		return &Literal{Kind: LiteralBool, Text: strconv.FormatBool(val)}, nil
+	case json.Number:
+		return &Literal{Kind: LiteralInt, Text: val.String()}, nil
+	case string:
+		return &Literal{Kind: LiteralString, Text: quoteString(val)}, nil
+	case []interface{}:
+		return listLiteral(val, where)
+	}
+	return nil, fmt.Errorf("%s: objects can't be compared", where)
+}
+
+// listLiteral is a list of numbers or of strings. Ints in a list with floats
+// are written as floats, lists have numbers of one kind.
+func listLiteral(list []interface{}, where string) (*Literal, error) {
+	if len(list) == 0 {
+		return nil, fmt.Errorf("%s: empty list", where)
+	}
+	var numbers []json.Number
+	var strs []string
+	floats := false
+	for _, elem := range list {
+		switch elem := elem.(type) {
+		case json.Number:
+			numbers = append(numbers, elem)
+			floats = floats || strings.ContainsAny(elem.String(), ".eE")
+		case string:
+			strs = append(strs, elem)
+		default:
+			return nil, fmt.Errorf("%s: lists hold either numbers or strings", where)
+		}
+	}
+	if len(numbers) > 0 && len(strs) > 0 {
+		return nil, fmt.Errorf("%s: lists hold either numbers or strings", where)
+	}
+	if len(strs) > 0 {
+		elems := make([]string, len(strs))
+		for i, s := range strs {
+			elems[i] = quoteString(s)
+		}
+		return &Literal{Kind: LiteralStringList, Val: strs, Text: "[" + strings.Join(elems, ", ") + "]"}, nil
+	}
+
+	// the values let Format sort the list
+	elems := make([]string, len(numbers))
+	if floats {
+		vals := make([]float64, len(numbers))
+		for i, n := range numbers {
+			elems[i] = n.String()
+			if !strings.ContainsAny(elems[i], ".eE") {
+				elems[i] += ".0"
+			}
+			vals[i], _ = n.Float64()
+		}
+		return &Literal{Kind: LiteralFloatList, Val: vals, Text: "[" + strings.Join(elems, ", ") + "]"}, nil
+	}
+	vals := make([]int, len(numbers))
+	for i, n := range numbers {
+		elems[i] = n.String()
+		vals[i], _ = strconv.Atoi(elems[i])
+	}
+	return &Literal{Kind: LiteralIntList, Val: vals, Text: "[" + strings.Join(elems, ", ") + "]"}, nil
+}
+
+// ipListLiteral is a list of IP addresses and CIDRs
+func ipListLiteral(list []interface{}, where string) (*Literal, error) {
+	if len(list) == 0 {
+		return nil, fmt.Errorf("%s: empty list", where)
+	}
+	ranges := make([]IPRange, len(list))
+	for i, elem := range list {
+		text, _ := elem.(string)
+		r, err := parseIPEntry(text)
+		if err != nil {
+			return nil, fmt.Errorf("%s[%d]: invalid IP address, CIDR or range %v", where, i, elem)
+		}
+		ranges[i] = r
+	}
+	ipList := newIPList(ranges)
+	return &Literal{Kind: LiteralIPList, Val: ipList, Text: ipList.String()}, nil
+}
+
+func astPath(segments []interface{}, where string) (*Path, error) {
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("%s: missing path", where)
+	}
+	path := &Path{}
+	for i, seg := range segments {
+		switch seg := seg.(type) {
+		case string:
+			if i == 0 && !isAttrName(seg) {
+				return nil, fmt.Errorf("%s: a path can't start with %q", where, seg)
+			}
+			path.Segments = append(path.Segments, PathSegment{Kind: SegmentField, Name: seg})
+			continue
+		case json.Number:
+			if index, err := strconv.Atoi(seg.String()); err == nil && i > 0 {
+				path.Segments = append(path.Segments, PathSegment{Kind: SegmentIndex, Index: index})
+				continue
+			}
+		case nil:
+			if i > 0 {
+				path.Segments = append(path.Segments, PathSegment{Kind: SegmentWildcard})
+				continue
+			}
+		}
+		return nil, fmt.Errorf("%s[%d]: invalid segment %v", where, i, seg)
+	}
+	return path, nil
+}
@@ -0,0 +1,151 @@
+package parser
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	tests := []struct {
+		rule string
+		ast  string
+	}{
+		{`x.a eq 1`, `{"version":1,"cmp":"eq","path":["x","a"],"value":1}`},
+		{`x.a == 1 and y != "b"`, `{"version":1,"op":"and","args":[{"cmp":"eq","path":["x","a"],"value":1},{"cmp":"ne","path":["y"],"value":"b"}]}`},
+		{`x eq 1 and (y eq 2 and z eq 3)`, `{"version":1,"op":"and","args":[{"cmp":"eq","path":["x"],"value":1},{"cmp":"eq","path":["y"],"value":2},{"cmp":"eq","path":["z"],"value":3}]}`},
+		{`not (x pr or y eq null)`, `{"version":1,"op":"not","args":[{"op":"or","args":[{"cmp":"pr","path":["x"]},{"cmp":"eq","path":["y"],"value":null}]}]}`},
+		{`x not in [3, 1]`, `{"version":1,"cmp":"in","not":true,"path":["x"],"value":[1, 3]}`},
+		{`x in [1.5, 0.5]`, `{"version":1,"cmp":"in","path":["x"],"value":[0.5, 1.5]}`},
+		{`x in ["b", "a"]`, `{"version":1,"cmp":"in","path":["x"],"value":["a", "b"]}`},
+		{`ip in 10.0.0.0/8`, `{"version":1,"cmp":"in","path":["ip"],"value":"10.0.0.0/8","type":"cidr"}`},
+		{`ip eq 10.0.0.1`, `{"version":1,"cmp":"eq","path":["ip"],"value":"10.0.0.1","type":"ip"}`},
+		{`ip ge 10.0.0.1`, `{"version":1,"cmp":"ge","path":["ip"],"value":"10.0.0.1","type":"ip"}`},
+		{`ip is not bogon`, `{"version":1,"cmp":"is","not":true,"path":["ip"],"value":"bogon","type":"ip_class"}`},
+		{`ip in 10.0.0.5-10.0.0.99`, `{"version":1,"cmp":"in","path":["ip"],"value":"10.0.0.5-10.0.0.99","type":"ip"}`},
+		{`ip in [10.0.0.5-10.0.0.99, ::1]`, `{"version":1,"cmp":"in","path":["ip"],"value":["10.0.0.5-10.0.0.99","::1"],"type":"ip"}`},
+		{`ip not in [fd00::/8, 10.0.0.1, 10.0.0.0/8]`, `{"version":1,"cmp":"in","not":true,"path":["ip"],"value":["10.0.0.0/8","10.0.0.1","fd00::/8"],"type":"ip"}`},
+		{`v ge 1.2.3`, `{"version":1,"cmp":"ge","path":["v"],"value":"1.2.3","type":"version"}`},
+		{`name mt /^a.*/mi`, `{"version":1,"cmp":"mt","path":["name"],"value":"^a.*","type":"regex","flags":"im"}`},
+		{`name mt / a/`, `{"version":1,"cmp":"mt","path":["name"],"value":" a","type":"regex"}`},
+		{`items[0].tags[*] co "a" and labels["a.b"] pr`, `{"version":1,"op":"and","args":[{"cmp":"co","path":["items",0,"tags",null],"value":"a"},{"cmp":"pr","path":["labels","a.b"]}]}`},
+		{`x eq y.z`, `{"version":1,"cmp":"eq","path":["x"],"right":{"path":["y","z"]}}`},
+		{`len(name) + 1 gt x * 2`, `{"version":1,"cmp":"gt","left":{"arith":"+","args":[{"call":"len","args":[{"path":["name"]}]},{"value":1}]},"right":{"arith":"*","args":[{"path":["x"]},{"value":2}]}}`},
+		{`any(items, qty gt 1)`, `{"version":1,"op":"any","path":["items"],"args":[{"cmp":"gt","path":["qty"],"value":1}]}`},
+		{`count(items, qty gt 1) ge 2`, `{"version":1,"op":"count","cmp":"ge","path":["items"],"value":2,"args":[{"cmp":"gt","path":["qty"],"value":1}]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			ev, err := NewEvaluator(tt.rule)
+			if !assert.NoError(t, err) {
+				return
+			}
+			data, err := json.Marshal(ev)
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.ast, string(data))
+
+			// the AST compiles back to the canonical form of the rule
+			formatted, err := Format(tt.rule)
+			assert.NoError(t, err)
+			rule, err := RuleFromAST(data)
+			assert.NoError(t, err)
+			assert.Equal(t, formatted, rule)
+
+			back, err := EvaluatorFromAST(data)
+			if assert.NoError(t, err) {
+				again, err := json.Marshal(back)
+				assert.NoError(t, err)
+				assert.JSONEq(t, tt.ast, string(again))
+			}
+		})
+	}
+}
+
+func TestEvaluatorFromAST(t *testing.T) {
+	ev, err := EvaluatorFromAST([]byte(`{
+		"op": "and",
+		"args": [
+			{"cmp": "eq", "path": ["x", "a"], "value": 1},
+			{"op": "or", "args": [
+				{"cmp": "in", "path": ["ip"], "value": "10.0.0.0/8", "type": "cidr"},
+				{"cmp": "sw", "path": ["name"], "value": "adm"}
+			]}
+		]
+	}`))
+	if !assert.NoError(t, err) {
+		return
+	}
+	match, err := ev.Process(obj{"x": obj{"a": 1}, "ip": "192.168.0.1", "name": "admin"})
+	assert.NoError(t, err)
+	assert.True(t, match)
+	match, err = ev.Process(obj{"x": obj{"a": 1}, "ip": "192.168.0.1", "name": "user"})
+	assert.NoError(t, err)
+	assert.False(t, match)
+
+	// lists are sorted and hold numbers of one kind
+	rule, err := RuleFromAST([]byte(`{"cmp": "in", "path": ["x"], "value": ["b", "a", "b"]}`))
+	assert.NoError(t, err)
+	assert.Equal(t, `x in ["a", "b"]`, rule)
+	rule, err = RuleFromAST([]byte(`{"cmp": "in", "path": ["x"], "value": [3, 1, 2.5]}`))
+	assert.NoError(t, err)
+	assert.Equal(t, `x in [1.0, 2.5, 3.0]`, rule)
+
+	// a single condition in and/or is just the condition
+	rule, err = RuleFromAST([]byte(`{"op": "or", "args": [{"cmp": "pr", "path": ["x"]}]}`))
+	assert.NoError(t, err)
+	assert.Equal(t, `x pr`, rule)
+}
+
+func TestRuleFromASTErrors(t *testing.T) {
+	tests := []struct {
+		ast string
+		err string
+	}{
+		{`{`, `invalid AST: unexpected EOF`},
+		{`{"version": 2, "cmp": "pr", "path": ["x"]}`, `invalid AST: unsupported version 2`},
+		{`{"cmp": "eq", "path": ["x"], "value": 1, "extra": 1}`, `invalid AST: json: unknown field "extra"`},
+		{`{"op": "and"}`, `invalid AST: op: and without args`},
+		{`{"op": "nand", "args": []}`, `invalid AST: op: unknown operator "nand"`},
+		{`{"op": "not", "args": [{"cmp": "pr", "path": ["x"]}, {"cmp": "pr", "path": ["y"]}]}`, `invalid AST: args: not takes one query, not 2`},
+		{`{"op": "and", "args": [{"cmp": "pr", "path": ["x"]}, {"cmp": "like", "path": ["y"], "value": 1}]}`, `invalid AST: args[1].cmp: unknown comparison "like"`},
+		{`{"cmp": "eq", "path": ["x"]}`, `invalid AST: value: missing value`},
+		{`{"cmp": "eq", "path": [], "value": 1}`, `invalid AST: path: missing path`},
+		{`{"cmp": "eq", "path": ["and"], "value": 1}`, `invalid AST: path: a path can't start with "and"`},
+		{`{"cmp": "eq", "path": ["x", 1.5], "value": 1}`, `invalid AST: path[1]: invalid segment 1.5`},
+		{`{"cmp": "eq", "not": true, "path": ["x"], "value": 1}`, `invalid AST: not: eq can't be negated`},
+		{`{"cmp": "eq", "path": ["x"], "value": {"a": 1}}`, `invalid AST: value: objects can't be compared`},
+		{`{"cmp": "in", "path": ["x"], "value": [1, "a"]}`, `invalid AST: value: lists hold either numbers or strings`},
+		{`{"cmp": "in", "path": ["x"], "value": []}`, `invalid AST: value: empty list`},
+		{`{"cmp": "in", "path": ["x"], "value": "10.0.0.0/8 or y pr", "type": "cidr"}`, `invalid AST: value: invalid cidr "10.0.0.0/8 or y pr"`},
+		{`{"cmp": "in", "path": ["x"], "value": ["10.0.0.0/8", "::1] or [y pr"], "type": "ip"}`, `invalid AST: value[1]: invalid IP address, CIDR or range ::1] or [y pr`},
+		{`{"cmp": "in", "path": ["x"], "value": ["10.0.0.0/8", 1], "type": "ip"}`, `invalid AST: value[1]: invalid IP address, CIDR or range 1`},
+		{`{"cmp": "in", "path": ["x"], "value": "10.0.0.9-10.0.0.1", "type": "ip"}`, `invalid AST: value: invalid ip "10.0.0.9-10.0.0.1"`},
+		{`{"cmp": "is", "path": ["x"], "value": "private or y pr", "type": "ip_class"}`, `invalid AST: value: invalid ip_class "private or y pr"`},
+		{`{"cmp": "in", "path": ["x"], "value": [], "type": "ip"}`, `invalid AST: value: empty list`},
+		{`{"cmp": "ge", "path": ["x"], "value": "1.2.3 or y pr", "type": "version"}`, `invalid AST: value: invalid version "1.2.3 or y pr"`},
+		{`{"cmp": "mt", "path": ["x"], "value": "a/ or y mt /b", "type": "regex"}`, `invalid AST: value: regex "a/ or y mt /b" can't be written in a rule`},
+		{`{"cmp": "mt", "path": ["x"], "value": "a", "type": "regex", "flags": "x"}`, `invalid AST: value.flags: invalid regex flags "x"`},
+		{`{"cmp": "eq", "path": ["x"], "value": 1, "type": "date"}`, `invalid AST: value: date has to be a string`},
+		{`{"cmp": "eq", "left": {"call": "len) or (x"}, "value": 1}`, `invalid AST: left.call: invalid function name "len) or (x"`},
+		{`{"cmp": "eq", "left": {"arith": "^", "args": []}, "value": 1}`, `invalid AST: left.arith: unknown operator "^"`},
+		{`{"op": "count", "cmp": "co", "path": ["x"], "value": 1, "args": [{"cmp": "pr", "path": ["y"]}]}`, `invalid AST: cmp: invalid count comparison "co"`},
+	}
+	for _, tt := range tests {
+		_, err := RuleFromAST([]byte(tt.ast))
+		if assert.Error(t, err, tt.ast) {
+			assert.Equal(t, tt.err, err.Error(), tt.ast)
+		}
+	}
+
+	// regexes are compiled by the regex engine of the evaluator
+	_, err := RuleFromAST([]byte(`{"cmp": "mt", "path": ["x"], "value": "a(b", "type": "regex"}`))
+	if assert.Error(t, err) {
+		assert.True(t, strings.HasPrefix(err.Error(), `invalid AST: value: invalid regex "a(b": `), err.Error())
+	}
+
+	// the AST is valid but the rule isn't
+	_, err = EvaluatorFromAST([]byte(`{"cmp": "eq", "left": {"call": "nope"}, "value": 1}`))
+	assert.Error(t, err)
+}
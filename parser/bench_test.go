@@ -42,6 +42,9 @@ func BenchmarkLogicalExpression(b *testing.B) {
 func BenchmarkLogicalExpressionBig(b *testing.B) {
 	rule := ""
 	for j := 0; j < 100; j++ {
+		if j > 0 {
+			rule += " or "
+		}
 		for i, condition := range conditions {
 			if i == len(conditions)-1 {
 				rule += fmt.Sprintf(" (((%s)))", condition)
@@ -70,6 +73,9 @@ func BenchmarkLogicalExpressionBig(b *testing.B) {
 func BenchmarkLogicalExpressionBigTreeCached(b *testing.B) {
 	rule := ""
 	for j := 0; j < 100; j++ {
+		if j > 0 {
+			rule += " or "
+		}
 		for i, condition := range conditions {
 			if i == len(conditions)-1 {
 				rule += fmt.Sprintf(" (((%s)))", condition)
@@ -120,3 +126,33 @@ func BenchmarkLogicalExpressionNested(b *testing.B) {
 		ev.Process(input)
 	}
 }
+
+func BenchmarkLogicalExpressionParallel(b *testing.B) {
+	rule := ""
+	for i, condition := range conditions {
+		if i == len(conditions)-1 {
+			rule += (" " + condition)
+			continue
+		}
+		rule += fmt.Sprintf("%s or ", condition)
+	}
+	input := map[string]interface{}{
+		"x":  123,
+		"y":  2345,
+		"z":  "asdada",
+		"a":  "1212121",
+		"b":  123131,
+		"aa": 123123.123131232113,
+		"jh": "1.2.3",
+	}
+	ev, err := NewEvaluator(rule)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			ev.Process(input)
+		}
+	})
+}
@@ -0,0 +1,288 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Issue is a problem found in a rule without evaluating it
+type Issue struct {
+	Span Span
+	// Text is the part of the rule the issue is about
+	Text    string
+	Message string
+	// Suggestions are the attributes close to an unknown one
+	Suggestions []string
+	// Warning is set for what may well be right, such as an attribute of an
+	// open object that isn't one of its fields
+	Warning bool
+}
+
+func (i *Issue) String() string {
+	msg := fmt.Sprintf("%s: %s", i.Text, i.Message)
+	if i.Warning {
+		msg = "warning: " + msg
+	}
+	if len(i.Suggestions) > 0 {
+		msg += fmt.Sprintf(", did you mean %s?", strings.Join(i.Suggestions, " or "))
+	}
+	return msg
+}
+
+// Check compiles rule and checks it against the schema of its input: that
+// every attribute exists and that the comparisons make sense for the types of
+// the attributes. Attributes of open objects that aren't among their fields
+// are warnings. The error is a *ParseError for an invalid rule.
+func Check(rule string, schema *Schema, opts ...EvaluatorOption) ([]*Issue, error) {
+	ev, err := NewEvaluator(rule, opts...)
+	if err != nil {
+		return nil, err
+	}
+	c := &checker{rule: []rune(rule)}
+	c.expr(ev.Expr(), schema)
+	return c.issues, nil
+}
+
+type checker struct {
+	rule   []rune
+	issues []*Issue
+}
+
+func (c *checker) report(span Span, suggestions []string, format string, args ...interface{}) {
+	c.issues = append(c.issues, &Issue{
+		Span:        span,
+		Text:        string(c.rule[span.Start:span.End]),
+		Message:     fmt.Sprintf(format, args...),
+		Suggestions: suggestions,
+	})
+}
+
+func (c *checker) warn(span Span, suggestions []string, format string, args ...interface{}) {
+	c.report(span, suggestions, format, args...)
+	c.issues[len(c.issues)-1].Warning = true
+}
+
+func (c *checker) expr(e Expr, schema *Schema) {
+	switch e := e.(type) {
+	case *LogicalExpr:
+		c.expr(e.Left, schema)
+		c.expr(e.Right, schema)
+	case *NotExpr:
+		c.expr(e.Expr, schema)
+	case *PresentExpr:
+		c.path(e.Path, schema, e.Span)
+	case *CompareExpr:
+		left := c.value(e.Left, schema, e.Span)
+		right := c.value(e.Right, schema, e.Span)
+		c.compare(e, left, right)
+	case *QuantifierExpr:
+		c.expr(e.Expr, c.list(e.Path, schema, e.Span))
+	case *CountExpr:
+		c.expr(e.Expr, c.list(e.Path, schema, e.Span))
+		if right := c.value(e.Right, schema, e.Span); !isNumeric(right.Type) && right.Type != TypeAny {
+			c.report(e.Span, nil, "count can't be compared with %s (%s)", e.Right, right.Type)
+		}
+	}
+}
+
+// list is the schema of the elements of the list at path
+func (c *checker) list(path *Path, schema *Schema, span Span) *Schema {
+	s := c.path(path, schema, span)
+	if s.Type != TypeList && s.Type != TypeAny {
+		c.report(span, nil, "%s (%s) is not a list", path, s.Type)
+		return anySchema
+	}
+	return s.elem()
+}
+
+// path is the schema of the value at path, reporting unknown attributes
+func (c *checker) path(path *Path, schema *Schema, span Span) *Schema {
+	return c.segments(path, 0, schema, span)
+}
+
+func (c *checker) segments(path *Path, from int, schema *Schema, span Span) *Schema {
+	cur := schema
+	for i := from; i < len(path.Segments); i++ {
+		if cur.Type == TypeAny {
+			return anySchema
+		}
+		seg := path.Segments[i]
+		prefix := &Path{Segments: path.Segments[:i]}
+		switch seg.Kind {
+		case SegmentField:
+			if cur.Type != TypeObject {
+				c.report(span, nil, "%s (%s) has no attribute %s", prefix, cur.Type, seg.Name)
+				return anySchema
+			}
+			field, ok := cur.field(seg.Name)
+			name := (&Path{Segments: path.Segments[:i+1]}).String()
+			if !ok {
+				c.report(span, cur.closeNames(seg.Name), "unknown attribute %s", name)
+				return anySchema
+			}
+			// an open object can have it, but it may be a misspelt field
+			if _, listed := cur.Fields[seg.Name]; !listed && len(cur.Fields) > 0 {
+				c.warn(span, cur.closeNames(seg.Name), "unknown attribute %s", name)
+			}
+			cur = field
+		case SegmentIndex, SegmentWildcard:
+			if cur.Type != TypeList {
+				c.report(span, nil, "%s (%s) is not a list", prefix, cur.Type)
+				return anySchema
+			}
+			if seg.Kind == SegmentWildcard {
+				return &Schema{Type: TypeList, Elem: c.segments(path, i+1, cur.elem(), span)}
+			}
+			cur = cur.elem()
+		}
+	}
+	return cur
+}
+
+var literalElemTypes = map[LiteralKind]Type{
+	LiteralIntList:    TypeInt,
+	LiteralFloatList:  TypeFloat,
+	LiteralStringList: TypeString,
+	LiteralIPList:     TypeCIDR,
+	LiteralIPRange:    TypeIP,
+}
+
+// value is the schema of a value, checking the operands of arithmetic and
+// function calls
+func (c *checker) value(v Value, schema *Schema, span Span) *Schema {
+	switch v := v.(type) {
+	case *Path:
+		return c.path(v, schema, span)
+	case *Literal:
+		if elem, ok := literalElemTypes[v.Kind]; ok {
+			return &Schema{Type: TypeList, Elem: &Schema{Type: elem}}
+		}
+		if t, ok := literalTypes[v.Kind]; ok {
+			return &Schema{Type: t}
+		}
+	case *ArithExpr:
+		left := c.value(v.Left, schema, span)
+		right := c.value(v.Right, schema, span)
+		for _, operand := range []struct {
+			val Value
+			typ Type
+		}{{v.Left, left.Type}, {v.Right, right.Type}} {
+			if operand.typ != TypeAny && !isNumeric(operand.typ) {
+				c.report(span, nil, "%s (%s) is not a number", operand.val, operand.typ)
+			}
+		}
+		if left.Type == TypeInt && right.Type == TypeInt && v.Op != ArithDiv {
+			return &Schema{Type: TypeInt}
+		}
+		return &Schema{Type: TypeNumber}
+	case *CallExpr:
+		for i, arg := range v.Args {
+			argType := c.value(arg, schema, span).Type
+			if param := v.fn.paramType(i); !param.accepts(argType) {
+				c.report(span, nil, "argument %d of %s has to be %s, not %s", i+1, v.Name, param, argType)
+			}
+		}
+		return &Schema{Type: v.fn.Result}
+	}
+	return anySchema
+}
+
+// compareFamilies is the type a comparison with a literal is done as, which
+// decides the Operation
+var compareFamilies = map[LiteralKind]Type{
+	LiteralBool:       TypeBool,
+	LiteralInt:        TypeNumber,
+	LiteralFloat:      TypeNumber,
+	LiteralIntList:    TypeNumber,
+	LiteralFloatList:  TypeNumber,
+	LiteralString:     TypeString,
+	LiteralStringList: TypeString,
+	LiteralVersion:    TypeVersion,
+	LiteralIP:         TypeIP,
+	LiteralCIDR:       TypeIP,
+	LiteralIPList:     TypeIP,
+	LiteralIPRange:    TypeIP,
+	LiteralIPClass:    TypeIP,
+}
+
+// familyOps are the comparisons the Operation of each type supports
+var familyOps = map[Type][]CompareOp{
+	TypeBool:    {CompareEQ, CompareNE},
+	TypeNumber:  {CompareEQ, CompareNE, CompareGT, CompareLT, CompareGE, CompareLE, CompareIN},
+	TypeString:  {CompareEQ, CompareNE, CompareGT, CompareLT, CompareGE, CompareLE, CompareCO, CompareSW, CompareEW, CompareIN},
+	TypeVersion: {CompareEQ, CompareNE, CompareGT, CompareLT, CompareGE, CompareLE},
+	TypeIP:      {CompareEQ, CompareNE, CompareGT, CompareLT, CompareGE, CompareLE, CompareIN, CompareIS},
+	TypeCIDR:    {CompareEQ, CompareNE},
+	TypeList:    {CompareCO},
+	TypeObject:  {},
+}
+
+func family(t Type) Type {
+	if isNumeric(t) {
+		return TypeNumber
+	}
+	return t
+}
+
+// comparable reports whether values of the two types can be compared, strings
+// being parsed as IP addresses and versions when compared with those
+func comparable(a, b Type) bool {
+	a, b = family(a), family(b)
+	if a == TypeAny || b == TypeAny || a == b {
+		return true
+	}
+	parsed := func(t Type) bool { return t == TypeIP || t == TypeCIDR || t == TypeVersion }
+	return (a == TypeString && parsed(b)) || (b == TypeString && parsed(a)) ||
+		(a == TypeIP && b == TypeCIDR) || (a == TypeCIDR && b == TypeIP)
+}
+
+func (c *checker) compare(e *CompareExpr, left, right *Schema) {
+	if isNullLiteral(e.Right) {
+		if e.Op != CompareEQ && e.Op != CompareNE {
+			c.report(e.Span, nil, "%s can't be used with null", e.Op)
+		}
+		return
+	}
+	if e.Op == CompareMT {
+		if !comparable(left.Type, TypeString) || left.Type == TypeIP || left.Type == TypeVersion {
+			c.report(e.Span, nil, "%s (%s) can't be matched with a regular expression", e.Left, left.Type)
+		}
+		return
+	}
+	// `co` on a list is a membership test
+	if e.Op == CompareCO && left.Type == TypeList {
+		if !comparable(left.elem().Type, right.Type) {
+			c.report(e.Span, nil, "%s (list of %s) can't contain %s (%s)", e.Left, left.elem().Type, e.Right, right.Type)
+		}
+		return
+	}
+
+	fam := family(left.Type)
+	if lit, ok := e.Right.(*Literal); ok {
+		fam = compareFamilies[lit.Kind]
+	}
+	if fam == TypeAny {
+		return
+	}
+	if !containsOp(familyOps[fam], e.Op) {
+		c.report(e.Span, nil, "%s can't be used with %s", e.Op, fam)
+		return
+	}
+
+	rightType := right.Type
+	if e.Op == CompareIN && right.Type == TypeList {
+		rightType = right.elem().Type
+	}
+	if !comparable(left.Type, rightType) || !comparable(left.Type, fam) {
+		c.report(e.Span, nil, "%s (%s) can't be compared with %s (%s)", e.Left, left.Type, e.Right, right.Type)
+	}
+}
+
+func containsOp(ops []CompareOp, op CompareOp) bool {
+	for _, o := range ops {
+		if o == op {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,188 @@
+package parser
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var checkSchema = &Schema{Type: TypeObject, Fields: map[string]*Schema{
+	"name":    {Type: TypeString},
+	"age":     {Type: TypeInt},
+	"score":   {Type: TypeFloat},
+	"active":  {Type: TypeBool},
+	"ip":      {Type: TypeIP},
+	"version": {Type: TypeVersion},
+	"tags":    {Type: TypeList, Elem: &Schema{Type: TypeString}},
+	"user": {Type: TypeObject, Fields: map[string]*Schema{
+		"email":    {Type: TypeString},
+		"username": {Type: TypeString},
+	}},
+	"items": {Type: TypeList, Elem: &Schema{Type: TypeObject, Fields: map[string]*Schema{
+		"sku": {Type: TypeString},
+		"qty": {Type: TypeInt},
+	}}},
+	"labels": {Type: TypeObject, Open: true},
+	"extra":  {Type: TypeAny},
+}}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		rule     string
+		messages []string
+	}{
+		{`name eq "a" and age gt 3 and score le 1.5`, nil},
+		{`age gt score`, nil},
+		{`ip in 10.0.0.0/8 and ip eq "10.0.0.1"`, nil},
+		{`ip ge 10.0.0.0 and ip le 10.0.3.255 and ip in 10.0.0.5-10.0.0.99`, nil},
+		{`ip is private and name is not bogon`, nil},
+		{`age is private`, []string{`age is private: age (int) can't be compared with private (ip)`}},
+		{`name ge 10.0.0.0 and name in [10.0.0.5-10.0.0.99]`, nil},
+		{`age in 10.0.0.5-10.0.0.99`, []string{`age in 10.0.0.5-10.0.0.99: age (int) can't be compared with 10.0.0.5-10.0.0.99 (list)`}},
+		{`version gt 1.2.3 and version eq "1.2.3"`, nil},
+		{`tags co "x" and tags[0] sw "a" and tags[*] co "b"`, nil},
+		{`user.email ew "@example.com" and name mt /^a/`, nil},
+		{`any(items, qty gt 1) and all(items, sku pr)`, nil},
+		{`count(items, qty gt 1) ge 2`, nil},
+		{`labels.anything eq 1 and extra.a[0].b co "x"`, nil},
+		{`len(name) gt 3 and age + 1 lt 10`, nil},
+		{`age in [1, 2] and name in ["a", "b"] and name eq null`, nil},
+		{`ip in [10.0.0.0/8, ::1] and name in [10.0.0.0/8]`, nil},
+
+		{`age co 1`, []string{`age co 1: co can't be used with number`}},
+		{`age in [10.0.0.0/8, ::1]`, []string{`age in [10.0.0.0/8, ::1]: age (int) can't be compared with [10.0.0.0/8, ::1] (list)`}},
+		{`ip gt 1.2.3`, []string{`ip gt 1.2.3: ip (ip) can't be compared with 1.2.3 (version)`}},
+		{`ip eq 1.2.3`, []string{`ip eq 1.2.3: ip (ip) can't be compared with 1.2.3 (version)`}},
+		{`name gt 3`, []string{`name gt 3: name (string) can't be compared with 3 (int)`}},
+		{`active eq "yes"`, []string{`active eq "yes": active (bool) can't be compared with "yes" (string)`}},
+		{`age mt /1/`, []string{`age mt /1/: age (int) can't be matched with a regular expression`}},
+		{`tags co 1`, []string{`tags co 1: tags (list of string) can't contain 1 (int)`}},
+		{`age gt null`, []string{`age gt null: gt can't be used with null`}},
+		{`user.emial pr`, []string{`user.emial pr: unknown attribute user.emial, did you mean email?`}},
+		{`nmae eq "a"`, []string{`nmae eq "a": unknown attribute nmae, did you mean name?`}},
+		{`zzz pr`, []string{`zzz pr: unknown attribute zzz`}},
+		{`name.first pr`, []string{`name.first pr: name (string) has no attribute first`}},
+		{`age[0] eq 1`, []string{`age[0] eq 1: age (int) is not a list`}},
+		{`any(user, email pr)`, []string{`any(user, email pr): user (object) is not a list`}},
+		{`any(items, price gt 1)`, []string{`price gt 1: unknown attribute price`}},
+		{`name + 1 gt 2`, []string{`name + 1 gt 2: name (string) is not a number`}},
+		{`lower(age) eq "a"`, []string{`lower(age) eq "a": argument 1 of lower has to be string, not int`}},
+		{`age gt 1 or nmae eq "a" or ip gt 1.2.3`, []string{
+			`nmae eq "a": unknown attribute nmae, did you mean name?`,
+			`ip gt 1.2.3: ip (ip) can't be compared with 1.2.3 (version)`,
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			issues, err := Check(tt.rule, checkSchema)
+			if !assert.NoError(t, err) {
+				return
+			}
+			var messages []string
+			for _, issue := range issues {
+				messages = append(messages, issue.String())
+			}
+			assert.Equal(t, tt.messages, messages)
+		})
+	}
+}
+
+func TestCheckIssueSpan(t *testing.T) {
+	rule := `age gt 1 and nmae eq "a"`
+	issues, err := Check(rule, checkSchema)
+	assert.NoError(t, err)
+	if assert.Len(t, issues, 1) {
+		assert.Equal(t, Span{Start: 13, End: 24}, issues[0].Span)
+		assert.Equal(t, []string{"name"}, issues[0].Suggestions)
+	}
+}
+
+func TestCheckParseError(t *testing.T) {
+	_, err := Check(`age gt`, checkSchema)
+	var parseErr *ParseError
+	assert.True(t, errors.As(err, &parseErr))
+}
+
+func TestSchemaFromJSONSchema(t *testing.T) {
+	schema, err := SchemaFromJSONSchema([]byte(`{
+		"type": "object",
+		"additionalProperties": false,
+		"properties": {
+			"name": {"type": "string"},
+			"age": {"type": ["integer", "null"]},
+			"score": {"type": "number"},
+			"ip": {"type": "string", "format": "ipv4"},
+			"tags": {"type": "array", "items": {"type": "string"}},
+			"user": {"$ref": "#/definitions/user"},
+			"anything": {}
+		},
+		"definitions": {
+			"user": {
+				"type": "object",
+				"properties": {
+					"email": {"type": "string"},
+					"manager": {"$ref": "#/definitions/user"}
+				},
+				"additionalProperties": false
+			}
+		}
+	}`))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, TypeInt, schema.Fields["age"].Type)
+	assert.Equal(t, TypeIP, schema.Fields["ip"].Type)
+	assert.Equal(t, TypeString, schema.Fields["tags"].Elem.Type)
+	assert.False(t, schema.Open)
+
+	tests := []struct {
+		rule   string
+		issues int
+	}{
+		{`name eq "a" and age gt 1 and score lt 1.5 and ip in 10.0.0.0/8`, 0},
+		{`user.manager.manager.email ew "@example.com"`, 0},
+		{`anything.at.all pr`, 0},
+		{`user.manager.emial pr`, 1},
+		{`ip gt 1.2.3`, 1},
+		{`other pr`, 1},
+	}
+	for _, tt := range tests {
+		issues, err := Check(tt.rule, schema)
+		assert.NoError(t, err, tt.rule)
+		assert.Len(t, issues, tt.issues, tt.rule)
+	}
+
+	// additional properties are allowed by default, but attributes that aren't
+	// properties are likely typos
+	schema, err = SchemaFromJSONSchema([]byte(`{
+		"type": "object",
+		"properties": {
+			"proto": {"type": "integer"},
+			"labels": {"type": "object"}
+		}
+	}`))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.True(t, schema.Open)
+	issues, err := Check(`prot eq 1`, schema)
+	assert.NoError(t, err)
+	if assert.Len(t, issues, 1) {
+		assert.True(t, issues[0].Warning)
+		assert.Equal(t, `warning: prot eq 1: unknown attribute prot, did you mean proto?`, issues[0].String())
+	}
+	issues, err = Check(`proto eq 1 and labels.app eq "web"`, schema)
+	assert.NoError(t, err)
+	assert.Empty(t, issues)
+	issues, err = Check(`proto eq "tcp"`, schema)
+	assert.NoError(t, err)
+	if assert.Len(t, issues, 1) {
+		assert.False(t, issues[0].Warning)
+	}
+
+	_, err = SchemaFromJSONSchema([]byte(`{"$ref": "#/definitions/missing"}`))
+	assert.Error(t, err)
+	_, err = SchemaFromJSONSchema([]byte(`{`))
+	assert.Error(t, err)
+}
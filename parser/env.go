@@ -0,0 +1,88 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Function is a function that rules can call. Every argument is converted to
+// the Go type of its parameter, see Type.convert, before Call gets it, and
+// Call is never called with a missing argument: the result is missing too.
+type Function struct {
+	Params []Type
+	// Optional is the number of trailing Params that can be left out
+	Optional int
+	// Variadic lets the last of Params be repeated any number of times
+	Variadic bool
+	Result   Type
+	Call     func(args []Operand) (Operand, error)
+}
+
+func (f *Function) paramType(i int) Type {
+	if i >= len(f.Params) {
+		return f.Params[len(f.Params)-1]
+	}
+	return f.Params[i]
+}
+
+func (f *Function) checkArgs(n int) error {
+	min := len(f.Params) - f.Optional
+	switch {
+	case f.Variadic && n < min:
+		return fmt.Errorf("takes at least %d arguments, got %d", min, n)
+	case !f.Variadic && (n < min || n > len(f.Params)):
+		if f.Optional == 0 {
+			return fmt.Errorf("takes %d arguments, got %d", min, n)
+		}
+		return fmt.Errorf("takes %d to %d arguments, got %d", min, len(f.Params), n)
+	}
+	return nil
+}
+
+// Env holds the functions rules can call. It is safe for concurrent use, and
+// rules already compiled with an Env don't see the functions registered
+// afterwards.
+type Env struct {
+	mu        sync.RWMutex
+	functions map[string]*Function
+}
+
+// NewEnv returns an Env with the built-in functions
+func NewEnv() *Env {
+	env := &Env{functions: make(map[string]*Function)}
+	for name, fn := range builtinFunctions {
+		fn := fn
+		env.functions[name] = &fn
+	}
+	return env
+}
+
+var defaultEnv = NewEnv()
+
+// Register adds a function. The name has to be a valid attribute name and not
+// be taken already, including by a built-in function.
+func (e *Env) Register(name string, fn Function) error {
+	if !isAttrName(name) {
+		return fmt.Errorf("invalid function name %q", name)
+	}
+	if fn.Call == nil {
+		return errors.New("function " + name + " has no Call")
+	}
+	if fn.Optional < 0 || fn.Optional > len(fn.Params) || (fn.Variadic && len(fn.Params) == 0) {
+		return errors.New("function " + name + " has invalid parameters")
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if _, ok := e.functions[name]; ok {
+		return fmt.Errorf("function %s is already registered", name)
+	}
+	e.functions[name] = &fn
+	return nil
+}
+
+func (e *Env) lookup(name string) *Function {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.functions[name]
+}
@@ -0,0 +1,127 @@
+package parser
+
+import (
+	"errors"
+	"hash/fnv"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testEnv(t *testing.T) *Env {
+	env := NewEnv()
+	assert.NoError(t, env.Register("geo_country", Function{
+		Params: []Type{TypeIP},
+		Result: TypeString,
+		Call: func(args []Operand) (Operand, error) {
+			if args[0].(net.IP).Equal(net.ParseIP("1.1.1.1")) {
+				return "AU", nil
+			}
+			return "US", nil
+		},
+	}))
+	assert.NoError(t, env.Register("hash_bucket", Function{
+		Params: []Type{TypeString, TypeInt},
+		Result: TypeInt,
+		Call: func(args []Operand) (Operand, error) {
+			h := fnv.New32a()
+			h.Write([]byte(args[0].(string)))
+			return int(h.Sum32() % uint32(args[1].(int))), nil
+		},
+	}))
+	assert.NoError(t, env.Register("concat", Function{
+		Params:   []Type{TypeString},
+		Variadic: true,
+		Result:   TypeString,
+		Call: func(args []Operand) (Operand, error) {
+			out := ""
+			for _, arg := range args {
+				out += arg.(string)
+			}
+			return out, nil
+		},
+	}))
+	return env
+}
+
+func TestEnvFunctions(t *testing.T) {
+	env := testEnv(t)
+	input := obj{
+		"src_ip":  "1.1.1.1",
+		"ip":      net.ParseIP("8.8.8.8"),
+		"user_id": "alice",
+		"a":       "x",
+		"b":       "y",
+		"n":       100.0,
+	}
+
+	tests := []testCase{
+		{`geo_country(src_ip) eq "AU"`, input, true, false},
+		{`geo_country(ip) eq "US"`, input, true, false},
+		{`hash_bucket(user_id, 100) lt 100`, input, true, false},
+		{`hash_bucket(user_id, n) ge 0`, input, true, false},
+		{`hash_bucket(user_id, 100) eq hash_bucket("alice", 100)`, input, true, false},
+		{`concat(a, b, "z") eq "xyz"`, input, true, false},
+		{`len(concat(a, b)) eq 2`, input, true, false},
+		{`geo_country(user_id) eq "US"`, input, false, false},
+		{`geo_country(missing) eq "US"`, input, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			ev, err := NewEvaluator(tt.rule, WithEnv(env))
+			if !assert.NoError(t, err) {
+				return
+			}
+			result, err := ev.Process(tt.input)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.result, result, tt.rule)
+		})
+	}
+
+	// functions are per Env
+	_, err := NewEvaluator(`geo_country(src_ip) eq "AU"`)
+	assert.Error(t, err)
+}
+
+func TestEnvCompileErrors(t *testing.T) {
+	env := testEnv(t)
+	tests := []struct {
+		rule   string
+		column int
+	}{
+		{`is_tor_exit(ip) eq true`, 0},
+		{`geo_country(ip, 1) eq "US"`, 0},
+		{`hash_bucket(user_id) eq 1`, 0},
+		{`concat() eq ""`, 0},
+		{`hash_bucket(user_id, 1.5) eq 1`, 21},
+		{`hash_bucket(1, 10) eq 1`, 12},
+		{`geo_country(1.2.3) eq "US"`, 12},
+		{`x eq 1 and lower(len(x)) eq "a"`, 17},
+		{`substr(x, "a") eq "b"`, 10},
+	}
+
+	for _, tt := range tests {
+		_, err := NewEvaluator(tt.rule, WithEnv(env))
+		var parseErr *ParseError
+		if !assert.True(t, errors.As(err, &parseErr), tt.rule) {
+			continue
+		}
+		assert.Equal(t, tt.column, parseErr.Errors[0].Column, tt.rule)
+	}
+}
+
+func TestEnvRegister(t *testing.T) {
+	env := NewEnv()
+	call := func(args []Operand) (Operand, error) { return nil, nil }
+
+	assert.NoError(t, env.Register("f", Function{Params: []Type{TypeAny}, Call: call}))
+	assert.Error(t, env.Register("f", Function{Params: []Type{TypeAny}, Call: call}))
+	assert.Error(t, env.Register("len", Function{Params: []Type{TypeAny}, Call: call}))
+	assert.Error(t, env.Register("not valid", Function{Call: call}))
+	assert.Error(t, env.Register("and", Function{Call: call}))
+	assert.Error(t, env.Register("g", Function{}))
+	assert.Error(t, env.Register("g", Function{Variadic: true, Call: call}))
+	assert.Error(t, env.Register("g", Function{Params: []Type{TypeAny}, Optional: 2, Call: call}))
+}
@@ -1,22 +1,71 @@
 package parser
 
 import (
+	"errors"
 	"fmt"
+	"sync/atomic"
 
 	"github.com/antlr4-go/antlr/v4"
 )
 
+// Evaluator is a compiled rule. It is safe for concurrent use by multiple
+// goroutines.
 type Evaluator struct {
-	lastDebugErr error
-
 	rule    string
-	tree    antlr.ParseTree
+	expr    Expr
 	doPanic bool
+	unknown bool
+	strict  bool
+	// runes is the rule as EvalError.Span counts, kept for strict mode
+	runes []rune
+
+	lastDebugErr atomic.Pointer[error]
 
 	testHookPanic func()
 }
 
-func NewEvaluator(rule string) (ret *Evaluator, retErr error) {
+// Result is the outcome of a single evaluation of a rule
+type Result struct {
+	Match bool
+
+	// Truth is Match in three-valued logic, it is only Unknown for rules
+	// compiled WithUnknown
+	Truth Truth
+
+	// DebugErr is the last non fatal problem seen while evaluating, e.g. an
+	// attribute that is missing from the input
+	DebugErr error
+}
+
+// EvalError is returned by Process and Eval for rules compiled WithStrict, when
+// an attribute is missing or has the wrong type. Fragment is the part of the
+// rule that couldn't be evaluated and Span where it is in the rule.
+type EvalError struct {
+	Path     string
+	Fragment string
+	Span     Span
+	Err      error
+}
+
+func (e *EvalError) Error() string {
+	return fmt.Sprintf("%s in %q: %v", e.Path, e.Fragment, e.Err)
+}
+
+func (e *EvalError) Unwrap() error {
+	return e.Err
+}
+
+func isStrictError(err error) bool {
+	var operandErr *ErrInvalidOperand
+	return err == ErrEvalOperandMissing || errors.As(err, &operandErr)
+}
+
+func NewEvaluator(rule string, opts ...EvaluatorOption) (ret *Evaluator, retErr error) {
+	options := evaluatorOptions{env: defaultEnv}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	// antlr lib has panics for exceptions so we have to put a recover here
 	// in the unlikely case there is an exception
 	defer func() {
@@ -25,23 +74,47 @@ func NewEvaluator(rule string) (ret *Evaluator, retErr error) {
 			retErr = fmt.Errorf("%q", info)
 		}
 	}()
+	listener := newErrorListener()
 	input := antlr.NewInputStream(rule)
 	lex := NewJsonQueryLexer(input)
 	lex.RemoveErrorListeners()
+	lex.AddErrorListener(listener)
 	tokens := antlr.NewCommonTokenStream(lex, antlr.TokenDefaultChannel)
 	p := NewJsonQueryParser(tokens)
-	// TODO: maybe log properly
 	p.RemoveErrorListeners()
-	tree := p.Query()
+	p.AddErrorListener(listener)
+	tree := p.Root()
+	if len(listener.errs) > 0 {
+		return nil, &ParseError{
+			Rule:   rule,
+			Errors: listener.errs,
+		}
+	}
+
+	visitor := NewJsonQueryVisitorImpl()
+	visitor.env = options.env
+	expr, _ := visitor.Visit(tree).(Expr)
+	if len(visitor.errs) > 0 || expr == nil {
+		return nil, &ParseError{
+			Rule:   rule,
+			Errors: visitor.errs,
+		}
+	}
 
-	return &Evaluator{
-		rule: rule,
-		tree: tree,
-	}, nil
+	ev := &Evaluator{
+		rule:    rule,
+		expr:    expr,
+		unknown: options.unknown,
+		strict:  options.strict,
+	}
+	if ev.strict {
+		ev.runes = []rune(rule)
+	}
+	return ev, nil
 }
 
-func NewEvaluatorWithPanicOnParseError(rule string) (ret *Evaluator, retErr error) {
-	ret, retErr = NewEvaluator(rule)
+func NewEvaluatorWithPanicOnParseError(rule string, opts ...EvaluatorOption) (ret *Evaluator, retErr error) {
+	ret, retErr = NewEvaluator(rule, opts...)
 	if retErr != nil {
 		return nil, retErr
 	}
@@ -50,17 +123,52 @@ func NewEvaluatorWithPanicOnParseError(rule string) (ret *Evaluator, retErr erro
 	return ret, nil
 }
 
+// Expr returns the compiled form of the rule
+func (e *Evaluator) Expr() Expr {
+	return e.expr
+}
+
 func (e *Evaluator) Reset() error {
-	e.lastDebugErr = nil
+	e.lastDebugErr.Store(nil)
 	return nil
 }
 
+// LastDebugErr returns the debug error of the most recent Process call. When
+// the evaluator is shared between goroutines use Eval, which returns the debug
+// error of each call.
 func (e *Evaluator) LastDebugErr() error {
-	return e.lastDebugErr
+	if err := e.lastDebugErr.Load(); err != nil {
+		return *err
+	}
+	return nil
+}
+
+func (e *Evaluator) Process(items map[string]interface{}) (bool, error) {
+	res, err := e.Eval(items)
+	return res.Match, err
+}
+
+// ProcessTruth is Process in three-valued logic, so that a rule compiled
+// WithUnknown can tell a rule that doesn't match from one that can't be decided
+// because attributes are missing
+func (e *Evaluator) ProcessTruth(items map[string]interface{}) (Truth, error) {
+	res, err := e.Eval(items)
+	return res.Truth, err
 }
 
-func (e *Evaluator) Process(items map[string]interface{}) (ret bool, retErr error) {
-	e.lastDebugErr = nil
+// ProcessWithTrace is Process that also returns how the rule was evaluated,
+// to explain why it matched or not. Like Process it records LastDebugErr.
+func (e *Evaluator) ProcessWithTrace(items map[string]interface{}) (bool, *Trace, error) {
+	tr := &tracer{}
+	res, err := e.eval(items, tr)
+	return res.Match, tr.root, err
+}
+
+func (e *Evaluator) Eval(items map[string]interface{}) (Result, error) {
+	return e.eval(items, nil)
+}
+
+func (e *Evaluator) eval(items map[string]interface{}, tr *tracer) (ret Result, retErr error) {
 	// antlr lib has panics for exceptions so we have to put a recover here
 	// in the unlikely case there is an exception
 	if !e.doPanic {
@@ -68,26 +176,44 @@ func (e *Evaluator) Process(items map[string]interface{}) (ret bool, retErr erro
 			info := recover()
 			if info != nil {
 				retErr = fmt.Errorf("%q", info)
-				ret = false
+				ret.Match = false
+				ret.Truth = False
 			}
 		}()
 	}
 
-	visitor := NewJsonQueryVisitorImpl(items)
-	if e.doPanic {
-		visitor.doPanic = true
+	s := &evalState{
+		item:    items,
+		doPanic: e.doPanic,
+		unknown: e.unknown,
+		strict:  e.strict,
+		rule:    e.runes,
+		tracer:  tr,
+	}
+	if tr != nil && s.rule == nil {
+		s.rule = []rune(e.rule)
+	}
+	match := s.eval(e.expr)
+	debugErr := s.debugErr()
+
+	// skip the store in the common case so evaluators shared between
+	// goroutines don't all write the same cache line
+	if debugErr != nil {
+		e.lastDebugErr.Store(&debugErr)
+	} else if e.lastDebugErr.Load() != nil {
+		e.lastDebugErr.Store(nil)
 	}
-
-	result := visitor.Visit(e.tree)
-	e.lastDebugErr = visitor.debugErr
 	if e.testHookPanic != nil && !e.doPanic {
 		defer e.testHookPanic()
 	}
-	if result == nil || visitor.err != nil {
-		return false, visitor.err
-	}
 
-	return result.(bool), visitor.err
+	ret.DebugErr = debugErr
+	if s.err != nil {
+		return ret, s.err
+	}
+	ret.Match = match == True
+	ret.Truth = match
+	return ret, nil
 }
 
 func Evaluate(rule string, items map[string]interface{}) bool {
@@ -0,0 +1,80 @@
+package parser
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluatorConcurrent(t *testing.T) {
+	ev, err := NewEvaluator(`x eq 1 and (y sw "ab" or z in [1, 2, 3])`)
+	assert.NoError(t, err)
+
+	inputs := []struct {
+		input    obj
+		result   bool
+		debugErr bool
+	}{
+		{obj{"x": 1, "y": "abc"}, true, false},
+		{obj{"x": 1, "y": "zzz", "z": 2}, true, false},
+		{obj{"x": 2, "y": "abc"}, false, false},
+		{obj{"x": 1, "z": 9}, false, true},
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < 16; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				tt := inputs[(g+i)%len(inputs)]
+				res, err := ev.Eval(tt.input)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.result, res.Match, tt.input)
+				assert.Equal(t, tt.debugErr, res.DebugErr != nil, tt.input)
+			}
+		}(g)
+	}
+	wg.Wait()
+}
+
+func TestEvalDebugErr(t *testing.T) {
+	ev, err := NewEvaluator(`x eq 1`)
+	assert.NoError(t, err)
+
+	res, err := ev.Eval(obj{})
+	assert.NoError(t, err)
+	assert.False(t, res.Match)
+	var nestedErr *NestedError
+	assert.True(t, errors.As(res.DebugErr, &nestedErr))
+	assert.Equal(t, ErrEvalOperandMissing, nestedErr.Original())
+	assert.Equal(t, res.DebugErr, ev.LastDebugErr())
+
+	res, err = ev.Eval(obj{"x": 1})
+	assert.NoError(t, err)
+	assert.True(t, res.Match)
+	assert.NoError(t, res.DebugErr)
+	assert.NoError(t, ev.LastDebugErr())
+}
+
+func TestLiteralCompileErrors(t *testing.T) {
+	tests := []struct {
+		rule   string
+		column int
+	}{
+		{`x eq 99999999999999999999`, 5},
+		{`x in [1, 99999999999999999999]`, 9},
+		{`x eq 1 and y mt /a(b/`, 16},
+	}
+
+	for _, tt := range tests {
+		_, err := NewEvaluator(tt.rule)
+		var parseErr *ParseError
+		if !assert.True(t, errors.As(err, &parseErr), tt.rule) {
+			continue
+		}
+		assert.Equal(t, tt.column, parseErr.Errors[0].Column, tt.rule)
+	}
+}
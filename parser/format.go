@@ -0,0 +1,285 @@
+package parser
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type formatOptions struct {
+	env   *Env
+	width int
+}
+
+type FormatOption func(*formatOptions)
+
+// FormatWithEnv formats rules calling the functions of env
+func FormatWithEnv(env *Env) FormatOption {
+	return func(o *formatOptions) {
+		o.env = env
+	}
+}
+
+// FormatWithWidth splits the and/or chains that don't fit in width characters
+// over several lines, one condition per line
+func FormatWithWidth(width int) FormatOption {
+	return func(o *formatOptions) {
+		o.width = width
+	}
+}
+
+// Format parses rule and returns it in canonical form: operators spelt the
+// same way, one space between tokens, only the parentheses precedence needs
+// and list literals sorted without duplicates, and `not x in [1]` written
+// `x not in [1]`. Rules that mean the same but are written differently format
+// the same; `not x eq 1` is kept apart from `x ne 1`, which is false rather
+// than true for a missing x. The error is a *ParseError for an invalid rule.
+func Format(rule string, opts ...FormatOption) (string, error) {
+	options := formatOptions{env: defaultEnv}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	ev, err := NewEvaluator(rule, WithEnv(options.env))
+	if err != nil {
+		return "", err
+	}
+	return FormatExpr(ev.Expr(), opts...), nil
+}
+
+// FormatExpr renders a compiled rule in the canonical form of Format
+func FormatExpr(e Expr, opts ...FormatOption) string {
+	var options formatOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	f := &formatter{width: options.width}
+	return f.expr(e, "", 0)
+}
+
+const formatIndent = "  "
+
+type formatter struct {
+	width int
+}
+
+// precedence is how tightly an expression binds, to know when it needs
+// parentheses
+func precedence(e Expr) int {
+	switch e := e.(type) {
+	case *LogicalExpr:
+		switch e.Op {
+		case LogicalOr:
+			return 1
+		case LogicalXor:
+			return 2
+		}
+		return 3
+	case *NotExpr:
+		return 4
+	}
+	return 5
+}
+
+// negated returns `not x in [1]` as the comparison `x not in [1]`, which
+// means the same, false if e isn't the negation of a comparison that has a
+// negated form
+func negated(e *NotExpr) (*CompareExpr, bool) {
+	inner := e.Expr
+	if not, ok := inner.(*NotExpr); ok {
+		if cmp, ok := negated(not); ok {
+			inner = cmp
+		}
+	}
+	cmp, ok := inner.(*CompareExpr)
+	if !ok || !cmp.Op.Negatable() {
+		return nil, false
+	}
+	neg := *cmp
+	neg.Not = !cmp.Not
+	return &neg, true
+}
+
+// fits reports whether text starting at column col fits in the width
+func (f *formatter) fits(text string, col int) bool {
+	return f.width <= 0 || col+len(text) <= f.width
+}
+
+// expr renders e starting at column col of a line indented by indent, over
+// several lines if it doesn't fit
+func (f *formatter) expr(e Expr, indent string, col int) string {
+	flat := f.flat(e)
+	if f.fits(flat, col) {
+		return flat
+	}
+	switch e := e.(type) {
+	case *LogicalExpr:
+		terms := chain(e.Op, e, nil)
+		lines := make([]string, len(terms))
+		for i, term := range terms {
+			if i == 0 {
+				lines[i] = f.operand(term, precedence(e), indent, col)
+			} else {
+				op := e.Op.String() + " "
+				lines[i] = op + f.operand(term, precedence(e), indent, len(indent)+len(op))
+			}
+		}
+		return strings.Join(lines, "\n"+indent)
+	case *NotExpr:
+		if cmp, ok := negated(e); ok {
+			return f.flat(cmp)
+		}
+		return "not " + f.operand(e.Expr, precedence(e), indent, col+len("not "))
+	case *QuantifierExpr:
+		return f.quantifier(e.Quantifier.String(), e.Path, e.Expr, indent)
+	case *CountExpr:
+		return f.quantifier("count", e.Path, e.Expr, indent) +
+			" " + e.Op.String() + " " + canonicalValue(e.Right).String()
+	}
+	return flat
+}
+
+// operand renders an operand of an operator of precedence prec, in
+// parentheses if it binds less tightly
+func (f *formatter) operand(e Expr, prec int, indent string, col int) string {
+	if precedence(e) >= prec {
+		return f.expr(e, indent, col)
+	}
+	flat := "(" + f.flat(e) + ")"
+	if f.fits(flat, col) {
+		return flat
+	}
+	inner := indent + formatIndent
+	return "(\n" + inner + f.expr(e, inner, len(inner)) + "\n" + indent + ")"
+}
+
+func (f *formatter) quantifier(name string, path *Path, e Expr, indent string) string {
+	inner := indent + formatIndent
+	return name + "(" + path.String() + ",\n" + inner + f.expr(e, inner, len(inner)) + "\n" + indent + ")"
+}
+
+// flat renders e on a single line
+func (f *formatter) flat(e Expr) string {
+	switch e := e.(type) {
+	case *LogicalExpr:
+		terms := chain(e.Op, e, nil)
+		parts := make([]string, len(terms))
+		for i, term := range terms {
+			parts[i] = f.flatOperand(term, precedence(e))
+		}
+		return strings.Join(parts, " "+e.Op.String()+" ")
+	case *NotExpr:
+		if cmp, ok := negated(e); ok {
+			return f.flat(cmp)
+		}
+		return "not " + f.flatOperand(e.Expr, precedence(e))
+	case *PresentExpr:
+		return e.Path.String() + " pr"
+	case *CompareExpr:
+		op := e.Op.String()
+		if e.Not && e.Op == CompareIS {
+			op += " not"
+		} else if e.Not {
+			op = "not " + op
+		}
+		return canonicalValue(e.Left).String() + " " + op + " " + canonicalValue(e.Right).String()
+	case *QuantifierExpr:
+		return fmt.Sprintf("%s(%s, %s)", e.Quantifier, e.Path, f.flat(e.Expr))
+	case *CountExpr:
+		return fmt.Sprintf("count(%s, %s) %s %s", e.Path, f.flat(e.Expr), e.Op, canonicalValue(e.Right))
+	}
+	return fmt.Sprint(e)
+}
+
+func (f *formatter) flatOperand(e Expr, prec int) string {
+	if precedence(e) >= prec {
+		return f.flat(e)
+	}
+	return "(" + f.flat(e) + ")"
+}
+
+// canonicalValue returns v with its literals in canonical form
+func canonicalValue(v Value) Value {
+	switch v := v.(type) {
+	case *Literal:
+		return canonicalLiteral(v)
+	case *ArithExpr:
+		return &ArithExpr{Op: v.Op, Left: canonicalValue(v.Left), Right: canonicalValue(v.Right)}
+	case *CallExpr:
+		args := make([]Value, len(v.Args))
+		for i, arg := range v.Args {
+			args[i] = canonicalValue(arg)
+		}
+		return &CallExpr{Name: v.Name, Args: args, fn: v.fn}
+	}
+	return v
+}
+
+func canonicalLiteral(l *Literal) *Literal {
+	var text string
+	switch val := l.Val.(type) {
+	case string:
+		text = quoteString(val)
+	case []int:
+		sorted := append([]int(nil), val...)
+		sort.Ints(sorted)
+		elems := make([]string, 0, len(sorted))
+		for i, n := range sorted {
+			if i == 0 || n != sorted[i-1] {
+				elems = append(elems, fmt.Sprint(n))
+			}
+		}
+		text = "[" + strings.Join(elems, ", ") + "]"
+	case []float64:
+		// keep the numbers as they were written, e.g. 1.50
+		texts := strings.Split(strings.Trim(l.Text, "[]"), ",")
+		idx := make([]int, len(val))
+		for i := range idx {
+			idx[i] = i
+		}
+		sort.SliceStable(idx, func(i, j int) bool { return val[idx[i]] < val[idx[j]] })
+		elems := make([]string, 0, len(val))
+		for i, k := range idx {
+			if i == 0 || val[k] != val[idx[i-1]] {
+				elems = append(elems, strings.TrimSpace(texts[k]))
+			}
+		}
+		text = "[" + strings.Join(elems, ", ") + "]"
+	case []string:
+		sorted := append([]string(nil), val...)
+		sort.Strings(sorted)
+		elems := make([]string, 0, len(sorted))
+		for i, s := range sorted {
+			if i == 0 || s != sorted[i-1] {
+				elems = append(elems, quoteString(s))
+			}
+		}
+		text = "[" + strings.Join(elems, ", ") + "]"
+	case IPRange:
+		text = val.String()
+	case IPClass:
+		text = val.String()
+	case *IPList:
+		sorted := append([]IPRange(nil), val.Ranges()...)
+		sort.Slice(sorted, func(i, j int) bool { return compareRanges(sorted[i], sorted[j]) < 0 })
+		elems := make([]string, 0, len(sorted))
+		for i, r := range sorted {
+			if i == 0 || r != sorted[i-1] {
+				elems = append(elems, ipEntryString(r))
+			}
+		}
+		text = "[" + strings.Join(elems, ", ") + "]"
+	default:
+		if l.Kind != LiteralRegex {
+			return l
+		}
+		regex, flags := splitRegex(l.Text)
+		var canonical []byte
+		for _, flag := range "gim" {
+			if strings.ContainsRune(flags, flag) {
+				canonical = append(canonical, byte(flag))
+			}
+		}
+		text = "/" + regex + "/" + string(canonical)
+	}
+	return &Literal{Kind: l.Kind, Val: l.Val, Text: text, op: l.op}
+}
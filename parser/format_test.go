@@ -0,0 +1,142 @@
+package parser
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		rule   string
+		result string
+	}{
+		{`x == 1`, `x eq 1`},
+		{`x equals 1 AND y noteq 2 && z >= 3 || w lte 4`, `x eq 1 and y ne 2 and z ge 3 or w le 4`},
+		{`x  EQ  "a"`, `x eq "a"`},
+		{`name startsWith "a" and name endsWith "z" and name contains "m"`, `name sw "a" and name ew "z" and name co "m"`},
+		{`NOT x eq 1`, `not x eq 1`},
+		{`!(x eq 1)`, `not x eq 1`},
+		{`!(x eq 1 and y pr)`, `not (x eq 1 and y pr)`},
+		{`x not in [3, 1, 2]`, `x not in [1, 2, 3]`},
+		{`not x in [3, 1, 2]`, `x not in [1, 2, 3]`},
+		{`!(x in [1])`, `x not in [1]`},
+		{`not x not in [1]`, `x in [1]`},
+		{`not not x co "a"`, `x co "a"`},
+		{`not ip is private and y pr`, `ip is not private and y pr`},
+		{`x ne 1`, `x ne 1`},
+		{`not x gt 1`, `not x gt 1`},
+		{`(x eq 1) and ((y eq 2))`, `x eq 1 and y eq 2`},
+		{`x eq 1 and (y eq 2 and z eq 3)`, `x eq 1 and y eq 2 and z eq 3`},
+		{`x eq 1 and (y eq 2 or z eq 3)`, `x eq 1 and (y eq 2 or z eq 3)`},
+		{`(x eq 1 and y eq 2) or z eq 3`, `x eq 1 and y eq 2 or z eq 3`},
+		{`x eq 1 or (y eq 2 xor z eq 3)`, `x eq 1 or y eq 2 xor z eq 3`},
+		{`(x eq 1 or y eq 2) xor z eq 3`, `(x eq 1 or y eq 2) xor z eq 3`},
+		{`x in [3, 1, 2, 1]`, `x in [1, 2, 3]`},
+		{`x in [2.50, 1.0, 2.5]`, `x in [1.0, 2.50]`},
+		{`x in ["b", "a","b"]`, `x in ["a", "b"]`},
+		{`x eq "ab\/"`, `x eq "ab/"`},
+		{`x ~= /abc/mi`, `x mt /abc/im`},
+		{`x matches /abc/ggi`, `x mt /abc/gi`},
+		{`ip IN 10.0.0.0/8`, `ip in 10.0.0.0/8`},
+		{`ip in [fd00::/8, 192.168.1.1,10.0.0.0/8, 10.0.0.0/16, 192.168.1.1/32]`, `ip in [10.0.0.0/8, 10.0.0.0/16, 192.168.1.1, fd00::/8]`},
+		{`ip in [10.0.0.5-10.0.0.99, 10.0.0.0-10.0.0.255, 10.0.0.5-10.0.0.9]`, `ip in [10.0.0.0/24, 10.0.0.5-10.0.0.99, 10.0.0.5-10.0.0.9]`},
+		{`ip in FD00::0001-fd00::ff`, `ip in fd00::1-fd00::ff`},
+		{`ip GE 10.0.0.0`, `ip ge 10.0.0.0`},
+		{`ip IS Private`, `ip is private`},
+		{`ip is not link_local`, `ip is not link_local`},
+		{`x["is"] is private`, `x["is"] is private`},
+		{`v >= 1.2.3`, `v ge 1.2.3`},
+		{`x == true and y != null`, `x eq true and y ne null`},
+		{`(a + b) * 2 == c - (d - 1)`, `(a + b) * 2 eq c - (d - 1)`},
+		{`len(name) > max(1,2)`, `len(name) gt max(1, 2)`},
+		{`any( items , qty > 1 )`, `any(items, qty gt 1)`},
+		{`count(items, qty > 1) >= 2`, `count(items, qty gt 1) ge 2`},
+		{`labels["app"] == "web" and labels["a.b"] == "c"`, `labels.app eq "web" and labels["a.b"] eq "c"`},
+		{"x eq 1\n  and y eq 2", `x eq 1 and y eq 2`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			result, err := Format(tt.rule)
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, tt.result, result)
+
+			again, err := Format(result)
+			assert.NoError(t, err)
+			assert.Equal(t, result, again)
+		})
+	}
+}
+
+func TestFormatWidth(t *testing.T) {
+	rule := `src eq "a" and (port eq 22 or port eq 443 or port eq 8080) and ip in 10.0.0.0/8 and any(items, qty gt 1 and sku sw "abcdef")`
+	result, err := Format(rule, FormatWithWidth(40))
+	assert.NoError(t, err)
+	assert.Equal(t, `src eq "a"
+and (
+  port eq 22
+  or port eq 443
+  or port eq 8080
+)
+and ip in 10.0.0.0/8
+and any(items,
+  qty gt 1 and sku sw "abcdef"
+)`, result)
+
+	again, err := Format(result, FormatWithWidth(40))
+	assert.NoError(t, err)
+	assert.Equal(t, result, again)
+
+	flat, err := Format(result)
+	assert.NoError(t, err)
+	assert.Equal(t, `src eq "a" and (port eq 22 or port eq 443 or port eq 8080) and ip in 10.0.0.0/8 and any(items, qty gt 1 and sku sw "abcdef")`, flat)
+
+	negated, err := Format(`not x in [1, 2, 3] and not (y in [4, 5, 6])`, FormatWithWidth(20))
+	assert.NoError(t, err)
+	assert.Equal(t, "x not in [1, 2, 3]\nand y not in [4, 5, 6]", negated)
+
+	short, err := Format(`x == 1 && y == 2`, FormatWithWidth(40))
+	assert.NoError(t, err)
+	assert.Equal(t, `x eq 1 and y eq 2`, short)
+}
+
+func TestFormatKeepsMeaning(t *testing.T) {
+	input := obj{"x": 1, "y": 2, "z": 3, "name": "abc", "items": []interface{}{obj{"qty": 2}}}
+	rules := []string{
+		`x eq 1 and (y eq 3 or z eq 3)`,
+		`(x eq 2 or y eq 2) xor z eq 3`,
+		`not (x eq 1 and y eq 3)`,
+		`not x in [1, 2] and not missing in [1]`,
+		`not missing eq 1`,
+		`x + y * 2 eq 5 and (x + y) * 2 eq 6`,
+		`count(items, qty gt 1) ge 1 or name mt /^ABC$/i`,
+	}
+	for _, rule := range rules {
+		formatted, err := Format(rule, FormatWithWidth(20))
+		if !assert.NoError(t, err, rule) {
+			continue
+		}
+		assert.Equal(t, Evaluate(rule, input), Evaluate(formatted, input), formatted)
+	}
+}
+
+func TestFormatErrors(t *testing.T) {
+	_, err := Format(`x eq`)
+	var parseErr *ParseError
+	assert.True(t, errors.As(err, &parseErr))
+
+	env := NewEnv()
+	assert.NoError(t, env.Register("double", Function{
+		Params: []Type{TypeInt},
+		Result: TypeInt,
+		Call:   func(args []Operand) (Operand, error) { return args[0].(int) * 2, nil },
+	}))
+	_, err = Format(`double(x) eq 2`)
+	assert.Error(t, err)
+	result, err := Format(`double(x) == 2`, FormatWithEnv(env))
+	assert.NoError(t, err)
+	assert.Equal(t, `double(x) eq 2`, result)
+}
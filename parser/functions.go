@@ -0,0 +1,206 @@
+package parser
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+)
+
+var ErrDivisionByZero = errors.New("Division by zero")
+
+var builtinFunctions = map[string]Function{
+	"len":    {Params: []Type{TypeAny}, Result: TypeInt, Call: fnLen},
+	"lower":  {Params: []Type{TypeString}, Result: TypeString, Call: stringFunc(strings.ToLower)},
+	"upper":  {Params: []Type{TypeString}, Result: TypeString, Call: stringFunc(strings.ToUpper)},
+	"trim":   {Params: []Type{TypeString}, Result: TypeString, Call: stringFunc(strings.TrimSpace)},
+	"abs":    {Params: []Type{TypeNumber}, Result: TypeNumber, Call: fnAbs},
+	"min":    {Params: []Type{TypeNumber}, Variadic: true, Result: TypeNumber, Call: minMax(true)},
+	"max":    {Params: []Type{TypeNumber}, Variadic: true, Result: TypeNumber, Call: minMax(false)},
+	"substr": {Params: []Type{TypeString, TypeInt, TypeInt}, Optional: 1, Result: TypeString, Call: fnSubstr},
+}
+
+// fnLen is the number of characters of a string or of elements of a list or
+// map
+func fnLen(args []Operand) (Operand, error) {
+	if str, ok := args[0].(string); ok {
+		return utf8.RuneCountInString(str), nil
+	}
+	if list, ok := listElems(args[0]); ok {
+		return len(list), nil
+	}
+	if val := reflect.ValueOf(args[0]); val.Kind() == reflect.Map {
+		return val.Len(), nil
+	}
+	return nil, newErrInvalidOperand(args[0], "")
+}
+
+func stringFunc(fn func(string) string) func([]Operand) (Operand, error) {
+	return func(args []Operand) (Operand, error) {
+		return fn(args[0].(string)), nil
+	}
+}
+
+func fnAbs(args []Operand) (Operand, error) {
+	if i, ok := intValue(args[0]); ok {
+		if i < 0 {
+			return -i, nil
+		}
+		return i, nil
+	}
+	f, err := toFloat(args[0])
+	if err != nil {
+		return nil, err
+	}
+	return math.Abs(f), nil
+}
+
+// minMax returns min or max, the result being an int if all the arguments are
+func minMax(isMin bool) func([]Operand) (Operand, error) {
+	better := func(a, b float64) bool {
+		if isMin {
+			return a < b
+		}
+		return a > b
+	}
+	return func(args []Operand) (Operand, error) {
+		allInts := true
+		var best float64
+		for i, arg := range args {
+			_, isInt := intValue(arg)
+			allInts = allInts && isInt
+			f, err := toFloat(arg)
+			if err != nil {
+				return nil, err
+			}
+			if i == 0 || better(f, best) {
+				best = f
+			}
+		}
+		if allInts {
+			return int(best), nil
+		}
+		return best, nil
+	}
+}
+
+// fnSubstr is `substr(x, start)` or `substr(x, start, length)` counted in
+// characters. A negative start counts from the end, and the result is cut
+// short when the string is.
+func fnSubstr(args []Operand) (Operand, error) {
+	runes := []rune(args[0].(string))
+	start := args[1].(int)
+	if start < 0 {
+		start += len(runes)
+	}
+	start = clamp(start, 0, len(runes))
+	end := len(runes)
+	if len(args) > 2 {
+		length := args[2].(int)
+		if length < 0 {
+			return nil, newErrInvalidOperand(args[2], 0)
+		}
+		end = clamp(start+length, start, len(runes))
+	}
+	return string(runes[start:end]), nil
+}
+
+func clamp(v, lo, hi int) int {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
+
+// intValue returns v as an int if it holds an integer
+func intValue(v Operand) (int, bool) {
+	switch val := v.(type) {
+	case int:
+		return val, true
+	case int32:
+		return int(val), true
+	case int64:
+		return int(val), true
+	}
+	return 0, false
+}
+
+func isNumber(v Operand) bool {
+	switch v.(type) {
+	case int, int32, int64, float32, float64:
+		return true
+	}
+	return false
+}
+
+func isFloat(v Operand) bool {
+	switch v.(type) {
+	case float32, float64:
+		return true
+	}
+	return false
+}
+
+// numericOperation compares an int with a float as floats rather than
+// truncating the float, so that `a / b gt 10` holds for 10.5 and `x gt 9.5`
+// for 10
+func numericOperation(op Operation, left, right Operand) Operation {
+	if _, ok := op.(*IntOperation); !ok {
+		return op
+	}
+	if isNumber(left) && isNumber(right) && (isFloat(left) || isFloat(right)) {
+		return &FloatOperation{}
+	}
+	return op
+}
+
+// arith divides as floats whatever the operands, so that 7 / 2 is 3.5
+func arith(op ArithOp, left, right Operand) (Operand, error) {
+	if l, ok := intValue(left); ok && op != ArithDiv {
+		if r, ok := intValue(right); ok {
+			return intArith(op, l, r)
+		}
+	}
+	l, err := toFloat(left)
+	if err != nil {
+		return nil, err
+	}
+	r, err := toFloat(right)
+	if err != nil {
+		return nil, err
+	}
+	switch op {
+	case ArithAdd:
+		return l + r, nil
+	case ArithSub:
+		return l - r, nil
+	case ArithMul:
+		return l * r, nil
+	}
+	if r == 0 {
+		return nil, ErrDivisionByZero
+	}
+	if op == ArithMod {
+		return math.Mod(l, r), nil
+	}
+	return l / r, nil
+}
+
+func intArith(op ArithOp, l, r int) (Operand, error) {
+	switch op {
+	case ArithAdd:
+		return l + r, nil
+	case ArithSub:
+		return l - r, nil
+	case ArithMul:
+		return l * r, nil
+	}
+	if r == 0 {
+		return nil, ErrDivisionByZero
+	}
+	return l % r, nil
+}
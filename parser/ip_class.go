@@ -0,0 +1,192 @@
+package parser
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"sort"
+	"strings"
+)
+
+// IPClass is a class of IP addresses a rule can test an address for, e.g.
+// `src is private`. IPv4-mapped IPv6 addresses are classified as the IPv4
+// address.
+type IPClass int
+
+const (
+	// IPClassPrivate is 10.0.0.0/8, 172.16.0.0/12, 192.168.0.0/16 and
+	// fc00::/7, as net.IP.IsPrivate
+	IPClassPrivate IPClass = iota
+	// IPClassLoopback is 127.0.0.0/8 and ::1, as net.IP.IsLoopback
+	IPClassLoopback
+	// IPClassLinkLocal is 169.254.0.0/16 and fe80::/10, as
+	// net.IP.IsLinkLocalUnicast
+	IPClassLinkLocal
+	// IPClassMulticast is 224.0.0.0/4 and ff00::/8, as net.IP.IsMulticast
+	IPClassMulticast
+	// IPClassGlobalUnicast is as net.IP.IsGlobalUnicast, private addresses
+	// included
+	IPClassGlobalUnicast
+	// IPClassBogon is the addresses the IANA special-purpose address
+	// registries mark as not globally reachable, and multicast addresses:
+	// addresses that shouldn't be the source of traffic from the internet
+	IPClassBogon
+	// IPClassIPv4 is the IPv4 addresses, IPv4-mapped ones included
+	IPClassIPv4
+	// IPClassIPv6 is the IPv6 addresses, except IPv4-mapped ones
+	IPClassIPv6
+	// IPClassDocumentation is the ranges reserved for documentation,
+	// 192.0.2.0/24, 198.51.100.0/24, 203.0.113.0/24, 2001:db8::/32 and
+	// 3fff::/20
+	IPClassDocumentation
+)
+
+var ipClassNames = [...]string{
+	IPClassPrivate:       "private",
+	IPClassLoopback:      "loopback",
+	IPClassLinkLocal:     "link_local",
+	IPClassMulticast:     "multicast",
+	IPClassGlobalUnicast: "global_unicast",
+	IPClassBogon:         "bogon",
+	IPClassIPv4:          "ipv4",
+	IPClassIPv6:          "ipv6",
+	IPClassDocumentation: "documentation",
+}
+
+func (c IPClass) String() string {
+	if c >= 0 && int(c) < len(ipClassNames) {
+		return ipClassNames[c]
+	}
+	return fmt.Sprintf("IPClass(%d)", int(c))
+}
+
+// ParseIPClass returns the class of its name in rules, e.g. link_local
+func ParseIPClass(name string) (IPClass, bool) {
+	for c, className := range ipClassNames {
+		if strings.EqualFold(name, className) {
+			return IPClass(c), true
+		}
+	}
+	return 0, false
+}
+
+// ipClassList is the names of the classes, for error messages
+func ipClassList() string {
+	names := append([]string(nil), ipClassNames[:]...)
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// Contains reports whether ip is of the class
+func (c IPClass) Contains(ip net.IP) bool {
+	addr, ok := toAddr(ip)
+	return ok && c.ContainsAddr(addr)
+}
+
+// ContainsAddr is Contains for a netip.Addr
+func (c IPClass) ContainsAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() {
+		return false
+	}
+	switch c {
+	case IPClassPrivate:
+		return addr.IsPrivate()
+	case IPClassLoopback:
+		return addr.IsLoopback()
+	case IPClassLinkLocal:
+		return addr.IsLinkLocalUnicast()
+	case IPClassMulticast:
+		return addr.IsMulticast()
+	case IPClassGlobalUnicast:
+		return addr.IsGlobalUnicast()
+	case IPClassBogon:
+		return addr.IsMulticast() || !globallyReachable(addr)
+	case IPClassIPv4:
+		return addr.Is4()
+	case IPClassIPv6:
+		return addr.Is6()
+	case IPClassDocumentation:
+		for _, prefix := range documentationPrefixes {
+			if prefix.Contains(addr) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ipSpecialPurpose is an entry of the IANA IPv4 and IPv6 Special-Purpose
+// Address Registries
+type ipSpecialPurpose struct {
+	prefix netip.Prefix
+	global bool
+}
+
+// ipSpecialPurposes is the IANA IPv4 and IPv6 Special-Purpose Address
+// Registries, without the deprecated entries and ::ffff:0:0/96 since
+// IPv4-mapped addresses are classified as the IPv4 address. Entries whose
+// reachability is N/A are global. An entry within another one overrides it.
+var ipSpecialPurposes = []ipSpecialPurpose{
+	{netip.MustParsePrefix("0.0.0.0/8"), false},
+	{netip.MustParsePrefix("10.0.0.0/8"), false},
+	{netip.MustParsePrefix("100.64.0.0/10"), false},
+	{netip.MustParsePrefix("127.0.0.0/8"), false},
+	{netip.MustParsePrefix("169.254.0.0/16"), false},
+	{netip.MustParsePrefix("172.16.0.0/12"), false},
+	{netip.MustParsePrefix("192.0.0.0/24"), false},
+	{netip.MustParsePrefix("192.0.0.9/32"), true},
+	{netip.MustParsePrefix("192.0.0.10/32"), true},
+	{netip.MustParsePrefix("192.0.2.0/24"), false},
+	{netip.MustParsePrefix("192.31.196.0/24"), true},
+	{netip.MustParsePrefix("192.52.193.0/24"), true},
+	{netip.MustParsePrefix("192.168.0.0/16"), false},
+	{netip.MustParsePrefix("192.175.48.0/24"), true},
+	{netip.MustParsePrefix("198.18.0.0/15"), false},
+	{netip.MustParsePrefix("198.51.100.0/24"), false},
+	{netip.MustParsePrefix("203.0.113.0/24"), false},
+	{netip.MustParsePrefix("240.0.0.0/4"), false},
+	{netip.MustParsePrefix("255.255.255.255/32"), false},
+
+	{netip.MustParsePrefix("::/128"), false},
+	{netip.MustParsePrefix("::1/128"), false},
+	{netip.MustParsePrefix("64:ff9b::/96"), true},
+	{netip.MustParsePrefix("64:ff9b:1::/48"), false},
+	{netip.MustParsePrefix("100::/64"), false},
+	{netip.MustParsePrefix("2001::/23"), false},
+	{netip.MustParsePrefix("2001::/32"), true},
+	{netip.MustParsePrefix("2001:1::1/128"), true},
+	{netip.MustParsePrefix("2001:1::2/128"), true},
+	{netip.MustParsePrefix("2001:3::/32"), true},
+	{netip.MustParsePrefix("2001:4:112::/48"), true},
+	{netip.MustParsePrefix("2001:20::/28"), true},
+	{netip.MustParsePrefix("2001:30::/28"), true},
+	{netip.MustParsePrefix("2001:db8::/32"), false},
+	{netip.MustParsePrefix("2002::/16"), true},
+	{netip.MustParsePrefix("2620:4f:8000::/48"), true},
+	{netip.MustParsePrefix("3fff::/20"), false},
+	{netip.MustParsePrefix("5f00::/16"), false},
+	{netip.MustParsePrefix("fc00::/7"), false},
+	{netip.MustParsePrefix("fe80::/10"), false},
+}
+
+// globallyReachable reports whether addr is globally reachable according to
+// the most specific special-purpose entry it is in, true if it is in none
+func globallyReachable(addr netip.Addr) bool {
+	bits := -1
+	global := true
+	for _, entry := range ipSpecialPurposes {
+		if entry.prefix.Bits() > bits && entry.prefix.Contains(addr) {
+			bits, global = entry.prefix.Bits(), entry.global
+		}
+	}
+	return global
+}
+
+var documentationPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("192.0.2.0/24"),
+	netip.MustParsePrefix("198.51.100.0/24"),
+	netip.MustParsePrefix("203.0.113.0/24"),
+	netip.MustParsePrefix("2001:db8::/32"),
+	netip.MustParsePrefix("3fff::/20"),
+}
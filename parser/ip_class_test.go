@@ -0,0 +1,61 @@
+package parser
+
+import (
+	"net"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIPClass(t *testing.T) {
+	tests := []struct {
+		ip      string
+		classes []IPClass
+	}{
+		{"10.1.2.3", []IPClass{IPClassPrivate, IPClassGlobalUnicast, IPClassBogon, IPClassIPv4}},
+		{"192.168.1.1", []IPClass{IPClassPrivate, IPClassGlobalUnicast, IPClassBogon, IPClassIPv4}},
+		{"127.0.0.1", []IPClass{IPClassLoopback, IPClassBogon, IPClassIPv4}},
+		{"169.254.169.254", []IPClass{IPClassLinkLocal, IPClassBogon, IPClassIPv4}},
+		{"224.0.0.251", []IPClass{IPClassMulticast, IPClassBogon, IPClassIPv4}},
+		{"100.64.0.1", []IPClass{IPClassGlobalUnicast, IPClassBogon, IPClassIPv4}},
+		{"192.0.2.10", []IPClass{IPClassGlobalUnicast, IPClassBogon, IPClassIPv4, IPClassDocumentation}},
+		{"192.0.0.9", []IPClass{IPClassGlobalUnicast, IPClassIPv4}},
+		{"192.0.0.8", []IPClass{IPClassGlobalUnicast, IPClassBogon, IPClassIPv4}},
+		{"240.0.0.1", []IPClass{IPClassGlobalUnicast, IPClassBogon, IPClassIPv4}},
+		{"255.255.255.255", []IPClass{IPClassBogon, IPClassIPv4}},
+		{"0.0.0.0", []IPClass{IPClassBogon, IPClassIPv4}},
+		{"8.8.8.8", []IPClass{IPClassGlobalUnicast, IPClassIPv4}},
+		{"::ffff:8.8.8.8", []IPClass{IPClassGlobalUnicast, IPClassIPv4}},
+		{"::ffff:10.0.0.1", []IPClass{IPClassPrivate, IPClassGlobalUnicast, IPClassBogon, IPClassIPv4}},
+		{"::1", []IPClass{IPClassLoopback, IPClassBogon, IPClassIPv6}},
+		{"::", []IPClass{IPClassBogon, IPClassIPv6}},
+		{"fe80::1", []IPClass{IPClassLinkLocal, IPClassBogon, IPClassIPv6}},
+		{"fd00::1", []IPClass{IPClassPrivate, IPClassGlobalUnicast, IPClassBogon, IPClassIPv6}},
+		{"ff02::1", []IPClass{IPClassMulticast, IPClassBogon, IPClassIPv6}},
+		{"2001:db8::1", []IPClass{IPClassGlobalUnicast, IPClassBogon, IPClassIPv6, IPClassDocumentation}},
+		{"3fff::1", []IPClass{IPClassGlobalUnicast, IPClassBogon, IPClassIPv6, IPClassDocumentation}},
+		{"2001:2::1", []IPClass{IPClassGlobalUnicast, IPClassBogon, IPClassIPv6}},
+		{"2001::1", []IPClass{IPClassGlobalUnicast, IPClassIPv6}},
+		{"2001:4860:4860::8888", []IPClass{IPClassGlobalUnicast, IPClassIPv6}},
+	}
+	for _, tt := range tests {
+		for class := IPClassPrivate; class <= IPClassDocumentation; class++ {
+			want := false
+			for _, c := range tt.classes {
+				want = want || c == class
+			}
+			assert.Equal(t, want, class.ContainsAddr(netip.MustParseAddr(tt.ip)), "%s is %s", tt.ip, class)
+			assert.Equal(t, want, class.Contains(net.ParseIP(tt.ip)), "%s is %s", tt.ip, class)
+		}
+	}
+	assert.False(t, IPClassIPv4.Contains(nil))
+
+	for class := IPClassPrivate; class <= IPClassDocumentation; class++ {
+		parsed, ok := ParseIPClass(class.String())
+		assert.True(t, ok)
+		assert.Equal(t, class, parsed)
+	}
+	_, ok := ParseIPClass("public")
+	assert.False(t, ok)
+}
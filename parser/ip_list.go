@@ -0,0 +1,129 @@
+package parser
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+)
+
+// IPList is a list of IP addresses, networks and ranges, the value of IP list
+// literals such as [10.0.0.0/8, 192.168.1.1, 10.1.0.5-10.1.0.99, fd00::/8].
+// Every entry is kept as the range of its addresses, and the list as an IPSet
+// built with it. Addresses are looked up in the entries one by one for short
+// lists and in the set for longer ones, networks always in the set.
+type IPList struct {
+	ranges []IPRange
+	set    *IPSet
+}
+
+// ipListScanMax is the length up to which looking an address up in the
+// entries one by one is faster than in an IPSet
+const ipListScanMax = 16
+
+// NewIPList returns the list of nets
+func NewIPList(nets ...*net.IPNet) *IPList {
+	ranges := make([]IPRange, 0, len(nets))
+	for _, ipNet := range nets {
+		if prefix, ok := toPrefix(ipNet); ok {
+			ranges = append(ranges, prefixRange(prefix))
+		}
+	}
+	return newIPList(ranges)
+}
+
+func newIPList(ranges []IPRange) *IPList {
+	l := &IPList{ranges: ranges, set: &IPSet{}}
+	for _, r := range ranges {
+		l.set.AddAddrRange(r.First, r.Last)
+	}
+	// sorted now rather than on the first lookup of an evaluation
+	l.set.sort()
+	return l
+}
+
+// ParseIPList returns the list of entries, each an IP address, a CIDR or a
+// range
+func ParseIPList(entries ...string) (*IPList, error) {
+	ranges := make([]IPRange, len(entries))
+	for i, entry := range entries {
+		r, err := parseIPEntry(entry)
+		if err != nil {
+			return nil, err
+		}
+		ranges[i] = r
+	}
+	return newIPList(ranges), nil
+}
+
+func parseIPEntry(entry string) (IPRange, error) {
+	if strings.Contains(entry, "-") {
+		return ParseIPRange(entry)
+	}
+	if strings.Contains(entry, "/") {
+		if prefix, ok := toPrefix(entry); ok {
+			return prefixRange(prefix), nil
+		}
+		return IPRange{}, fmt.Errorf("invalid CIDR %s", entry)
+	}
+	addr, ok := toAddr(entry)
+	if !ok || strings.Contains(entry, "%") {
+		return IPRange{}, fmt.Errorf("invalid IP address %s", entry)
+	}
+	return IPRange{First: addr, Last: addr}, nil
+}
+
+// Nets returns the entries of the list as CIDRs, a range being as many CIDRs
+// as it takes
+func (l *IPList) Nets() []*net.IPNet {
+	prefixes := l.Prefixes()
+	nets := make([]*net.IPNet, len(prefixes))
+	for i, prefix := range prefixes {
+		nets[i] = prefixNet(prefix)
+	}
+	return nets
+}
+
+// Prefixes is Nets as netip.Prefix
+func (l *IPList) Prefixes() []netip.Prefix {
+	var prefixes []netip.Prefix
+	for _, r := range l.ranges {
+		prefixes = append(prefixes, r.Prefixes()...)
+	}
+	return prefixes
+}
+
+// Ranges returns the entries of the list
+func (l *IPList) Ranges() []IPRange {
+	return l.ranges
+}
+
+// Contains reports whether ip is one of the addresses or in one of the
+// networks of the list
+func (l *IPList) Contains(ip net.IP) bool {
+	addr, ok := toAddr(ip)
+	return ok && l.ContainsAddr(addr)
+}
+
+// ContainsAddr is Contains for a netip.Addr
+func (l *IPList) ContainsAddr(addr netip.Addr) bool {
+	addr = addr.Unmap().WithZone("")
+	if len(l.ranges) > ipListScanMax {
+		return l.set.ContainsAddr(addr)
+	}
+	for _, r := range l.ranges {
+		if r.ContainsAddr(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// String renders the list the way it is written in rules
+func (l *IPList) String() string {
+	entries := make([]string, len(l.ranges))
+	for i, r := range l.ranges {
+		entries[i] = ipEntryString(r)
+	}
+	return "[" + strings.Join(entries, ", ") + "]"
+}
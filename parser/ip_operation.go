@@ -2,27 +2,116 @@ package parser
 
 import (
 	"net"
+	"net/netip"
+	"strings"
 )
 
+// IPCompareOperation compares addresses, given as netip.Addr, netip.AddrPort,
+// net.IP or strings, with addresses, networks and lists of them. A network on
+// the left, a netip.Prefix or a *net.IPNet, is compared as a whole: it is
+// equal to the same network and in a set that has all its addresses. An
+// IPv4-mapped IPv6 address such as ::ffff:1.2.3.4 is the IPv4 address and a
+// network within ::ffff:0:0/96 the IPv4 network, so 1.2.3.4 is in
+// ::ffff:0:0/96 and ::ffff:1.2.3.4 in 1.2.3.0/24. Other IPv6 networks, ::/0
+// included, never contain IPv4 addresses. Addresses are ordered IPv4 ones
+// first, then by their bytes.
 type IPCompareOperation struct {
 	NullOperation
 }
 
-func (o *IPCompareOperation) get(left Operand, right Operand) (net.IP, any, error) {
-	if left == nil {
-		return nil, nil, ErrEvalOperandMissing
+// ipMatcher is a set of addresses an address can be looked up in, e.g. an
+// *IPList, an *IPSet or an IPRange
+type ipMatcher interface {
+	ContainsAddr(addr netip.Addr) bool
+}
+
+// isAddrSet reports whether v is a set of addresses, which decides the
+// operation whatever the type of the address
+func isAddrSet(v Operand) bool {
+	switch v.(type) {
+	case *net.IPNet, netip.Prefix, ipMatcher:
+		return true
 	}
-	leftVal, ok := left.(net.IP)
-	if !ok {
-		var leftStr string
-		if leftStr, ok = left.(string); !ok {
-			return nil, nil, ErrEvalOperandMissing
-		}
+	return false
+}
 
-		leftVal = net.ParseIP(leftStr)
-		if leftVal == nil {
-			return nil, nil, newErrInvalidOperand(left, leftVal)
+// toAddr returns the address of a netip.Addr, a netip.AddrPort, a net.IP or a
+// string, without its zone and with IPv4-mapped addresses as IPv4 ones
+func toAddr(v Operand) (netip.Addr, bool) {
+	var addr netip.Addr
+	switch v := v.(type) {
+	case netip.Addr:
+		addr = v
+	case netip.AddrPort:
+		addr = v.Addr()
+	case net.IP:
+		addr, _ = netip.AddrFromSlice(v)
+	case string:
+		addr, _ = netip.ParseAddr(v)
+	}
+	return addr.Unmap().WithZone(""), addr.IsValid()
+}
+
+// toPrefix returns the network of a netip.Prefix, a *net.IPNet or a string,
+// see normalizePrefix
+func toPrefix(v Operand) (netip.Prefix, bool) {
+	switch v := v.(type) {
+	case netip.Prefix:
+		return normalizePrefix(v)
+	case *net.IPNet:
+		if v == nil {
+			return netip.Prefix{}, false
+		}
+		addr, ok := netip.AddrFromSlice(v.IP)
+		ones, bits := v.Mask.Size()
+		if !ok || bits == 0 {
+			return netip.Prefix{}, false
+		}
+		// a 16 byte IPv4 address with a 4 byte mask
+		if bits == 8*net.IPv4len {
+			addr = addr.Unmap()
+		}
+		return normalizePrefix(netip.PrefixFrom(addr, ones))
+	case string:
+		prefix, err := netip.ParsePrefix(v)
+		if err != nil {
+			return netip.Prefix{}, false
 		}
+		return normalizePrefix(prefix)
+	}
+	return netip.Prefix{}, false
+}
+
+// normalizePrefix masks prefix and turns a network within ::ffff:0:0/96 into
+// the IPv4 network
+func normalizePrefix(prefix netip.Prefix) (netip.Prefix, bool) {
+	if !prefix.IsValid() {
+		return prefix, false
+	}
+	if addr := prefix.Addr(); addr.Is4In6() && prefix.Bits() >= 96 {
+		prefix = netip.PrefixFrom(addr.Unmap(), prefix.Bits()-96)
+	}
+	return prefix.Masked(), true
+}
+
+// hostPrefix is the network of the single address addr
+func hostPrefix(addr netip.Addr) netip.Prefix {
+	return netip.PrefixFrom(addr, addr.BitLen())
+}
+
+// prefixNet is prefix as a *net.IPNet, IPv4 networks being 4 bytes
+func prefixNet(prefix netip.Prefix) *net.IPNet {
+	addr := prefix.Addr()
+	return &net.IPNet{IP: addr.AsSlice(), Mask: net.CIDRMask(prefix.Bits(), addr.BitLen())}
+}
+
+func (o *IPCompareOperation) get(left Operand, right Operand) (netip.Addr, any, error) {
+	if left == nil {
+		return netip.Addr{}, nil, ErrEvalOperandMissing
+	}
+	leftVal, ok := toAddr(left)
+	if !ok {
+		return netip.Addr{}, nil, newErrInvalidOperand(left, leftVal)
 	}
 
 	if right == nil {
@@ -30,38 +119,131 @@ func (o *IPCompareOperation) get(left Operand, right Operand) (net.IP, any, erro
 	}
 
 	var rightVal any
-	if v, ok := right.(string); ok {
-		_, ipNet, err := net.ParseCIDR(v)
-		if err != nil {
-			rightVal = net.ParseIP(v)
+	switch v := right.(type) {
+	case string:
+		// an invalid address is equal to no address
+		if strings.Contains(v, "/") {
+			rightVal, _ = toPrefix(v)
+		} else if r, err := ParseIPRange(v); err == nil {
+			rightVal = r
 		} else {
-			rightVal = ipNet
+			rightVal, _ = toAddr(v)
 		}
-	} else if v, ok := right.(net.IP); ok {
-		rightVal = v
-	} else if v, ok := right.(*net.IPNet); ok {
+	case netip.Addr, netip.AddrPort, net.IP:
+		rightVal, _ = toAddr(v)
+	case netip.Prefix, *net.IPNet:
+		rightVal, _ = toPrefix(v)
+	case []net.IP, []*net.IPNet, []netip.Addr, []netip.Prefix, ipMatcher:
 		rightVal = v
-	} else if v, ok := right.([]net.IP); ok {
-		rightVal = v
-	} else if v, ok := right.([]*net.IPNet); ok {
-		rightVal = v
-	} else {
-		return nil, nil, newErrInvalidOperand(right, rightVal)
+	default:
+		return netip.Addr{}, nil, newErrInvalidOperand(right, rightVal)
 	}
 
 	return leftVal, rightVal, nil
 }
 
+// isNet reports whether v is a network, which is compared as a whole rather
+// than as the address it starts at
+func isNet(v Operand) bool {
+	switch v.(type) {
+	case netip.Prefix, *net.IPNet:
+		return true
+	}
+	return false
+}
+
+// getNet is get for a network on the left. An address on the right is the
+// network of that address alone.
+func (o *IPCompareOperation) getNet(left Operand, right Operand) (netip.Prefix, any, error) {
+	leftVal, ok := toPrefix(left)
+	if !ok {
+		return netip.Prefix{}, nil, newErrInvalidOperand(left, leftVal)
+	}
+	if right == nil {
+		return leftVal, nil, nil
+	}
+
+	var rightVal any
+	switch v := right.(type) {
+	case string:
+		if strings.Contains(v, "/") {
+			rightVal, _ = toPrefix(v)
+		} else if r, err := ParseIPRange(v); err == nil {
+			rightVal = r
+		} else if addr, ok := toAddr(v); ok {
+			rightVal = hostPrefix(addr)
+		}
+	case netip.Addr, netip.AddrPort, net.IP:
+		if addr, ok := toAddr(v); ok {
+			rightVal = hostPrefix(addr)
+		}
+	case netip.Prefix, *net.IPNet:
+		rightVal, _ = toPrefix(v)
+	case []net.IP, []*net.IPNet, []netip.Addr, []netip.Prefix, ipMatcher:
+		rightVal = v
+	default:
+		return netip.Prefix{}, nil, newErrInvalidOperand(right, rightVal)
+	}
+	return leftVal, rightVal, nil
+}
+
+// netIn reports whether every address of prefix is in the set
+func netIn(prefix netip.Prefix, set any) bool {
+	switch v := set.(type) {
+	case netip.Prefix:
+		return v.IsValid() && v.Bits() <= prefix.Bits() && v.Contains(prefix.Addr())
+	case []net.IP:
+		for _, data := range v {
+			if addr, ok := toAddr(data); ok && hostPrefix(addr) == prefix {
+				return true
+			}
+		}
+	case []netip.Addr:
+		for _, data := range v {
+			if addr, ok := toAddr(data); ok && hostPrefix(addr) == prefix {
+				return true
+			}
+		}
+	case []*net.IPNet:
+		for _, data := range v {
+			if p, ok := toPrefix(data); ok && netIn(prefix, p) {
+				return true
+			}
+		}
+	case []netip.Prefix:
+		for _, data := range v {
+			if p, ok := toPrefix(data); ok && netIn(prefix, p) {
+				return true
+			}
+		}
+	case IPRange:
+		return v.ContainsAddr(prefix.Addr()) && v.ContainsAddr(lastAddr(prefix))
+	case *IPList:
+		return v.set.containsAddrRange(prefix.Addr(), lastAddr(prefix))
+	case *IPSet:
+		return v.containsAddrRange(prefix.Addr(), lastAddr(prefix))
+	}
+	return false
+}
+
 func (o *IPCompareOperation) EQ(left Operand, right Operand) (bool, error) {
+	if isNet(left) {
+		l, r, err := o.getNet(left, right)
+		if err != nil {
+			return false, err
+		}
+		rPrefix, ok := r.(netip.Prefix)
+		return ok && rPrefix == l, nil
+	}
 	l, r, err := o.get(left, right)
 	if err != nil {
 		return false, err
 	}
 
-	if rIP, ok := r.(net.IP); ok {
-		return l.Equal(rIP), nil
-	} else if rNet, ok := r.(*net.IPNet); ok {
-		return rNet.Contains(l), nil
+	if rAddr, ok := r.(netip.Addr); ok {
+		return l == rAddr, nil
+	} else if rPrefix, ok := r.(netip.Prefix); ok {
+		return rPrefix.Contains(l), nil
 	}
 
 	return false, nil
@@ -76,28 +258,96 @@ func (o *IPCompareOperation) NE(left Operand, right Operand) (bool, error) {
 	return !value, nil
 }
 
+// compare compares left with the address right
+func (o *IPCompareOperation) compare(left Operand, right Operand) (int, error) {
+	l, r, err := o.get(left, right)
+	if err != nil {
+		return 0, err
+	}
+	rAddr, ok := r.(netip.Addr)
+	if !ok || !rAddr.IsValid() {
+		return 0, newErrInvalidOperand(right, netip.Addr{})
+	}
+	return l.Compare(rAddr), nil
+}
+
+func (o *IPCompareOperation) GT(left Operand, right Operand) (bool, error) {
+	c, err := o.compare(left, right)
+	return c > 0, err
+}
+
+func (o *IPCompareOperation) LT(left Operand, right Operand) (bool, error) {
+	c, err := o.compare(left, right)
+	return c < 0, err
+}
+
+func (o *IPCompareOperation) GE(left Operand, right Operand) (bool, error) {
+	c, err := o.compare(left, right)
+	return c >= 0, err
+}
+
+func (o *IPCompareOperation) LE(left Operand, right Operand) (bool, error) {
+	c, err := o.compare(left, right)
+	return c <= 0, err
+}
+
+// IS reports whether left is of the IPClass right
+func (o *IPCompareOperation) IS(left Operand, right Operand) (bool, error) {
+	l, r, err := o.get(left, right)
+	if err != nil {
+		return false, err
+	}
+	class, ok := r.(IPClass)
+	if !ok {
+		return false, newErrInvalidOperand(right, class)
+	}
+	return class.ContainsAddr(l), nil
+}
+
 func (o *IPCompareOperation) IN(left Operand, right Operand) (bool, error) {
+	if isNet(left) {
+		l, r, err := o.getNet(left, right)
+		if err != nil {
+			return false, err
+		}
+		return netIn(l, r), nil
+	}
 	l, r, err := o.get(left, right)
 	if err != nil {
 		return false, err
 	}
 
-	if v, ok := r.(net.IP); ok {
-		return l.Equal(v), nil
-	} else if v, ok := r.(*net.IPNet); ok {
+	switch v := r.(type) {
+	case netip.Addr:
+		return l == v, nil
+	case netip.Prefix:
 		return v.Contains(l), nil
-	} else if v, ok := r.([]net.IP); ok {
+	case []net.IP:
+		for _, data := range v {
+			if addr, ok := toAddr(data); ok && l == addr {
+				return true, nil
+			}
+		}
+	case []netip.Addr:
+		for _, data := range v {
+			if addr, ok := toAddr(data); ok && l == addr {
+				return true, nil
+			}
+		}
+	case []*net.IPNet:
 		for _, data := range v {
-			if l.Equal(data) {
+			if prefix, ok := toPrefix(data); ok && prefix.Contains(l) {
 				return true, nil
 			}
 		}
-	} else if v, ok := r.([]*net.IPNet); ok {
+	case []netip.Prefix:
 		for _, data := range v {
-			if data.Contains(l) {
+			if prefix, ok := toPrefix(data); ok && prefix.Contains(l) {
 				return true, nil
 			}
 		}
+	case ipMatcher:
+		return v.ContainsAddr(l), nil
 	}
 
 	return false, nil
@@ -0,0 +1,131 @@
+package parser
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+)
+
+// IPRange is the addresses from First to Last, both included, the value of
+// range literals such as 10.0.0.5-10.0.0.99. First and Last are of the same
+// family and IPv4-mapped IPv6 addresses are IPv4 ones.
+type IPRange struct {
+	First, Last netip.Addr
+}
+
+// NewIPRange returns the range from first to last
+func NewIPRange(first, last netip.Addr) (IPRange, error) {
+	r := IPRange{First: first.Unmap().WithZone(""), Last: last.Unmap().WithZone("")}
+	if !r.First.IsValid() || !r.Last.IsValid() || r.First.BitLen() != r.Last.BitLen() {
+		return IPRange{}, fmt.Errorf("invalid IP range %s-%s", first, last)
+	}
+	if r.Last.Less(r.First) {
+		return IPRange{}, fmt.Errorf("invalid IP range %s-%s: %s is after %s", first, last, first, last)
+	}
+	return r, nil
+}
+
+// ParseIPRange parses a range written first-last, e.g. 10.0.0.5-10.0.0.99
+func ParseIPRange(s string) (IPRange, error) {
+	first, last, ok := strings.Cut(s, "-")
+	if !ok || strings.Contains(s, "%") {
+		return IPRange{}, fmt.Errorf("invalid IP range %s", s)
+	}
+	firstAddr, err := netip.ParseAddr(first)
+	if err != nil {
+		return IPRange{}, fmt.Errorf("invalid IP range %s: %v", s, err)
+	}
+	lastAddr, err := netip.ParseAddr(last)
+	if err != nil {
+		return IPRange{}, fmt.Errorf("invalid IP range %s: %v", s, err)
+	}
+	return NewIPRange(firstAddr, lastAddr)
+}
+
+// prefixRange is the range of the addresses of prefix
+func prefixRange(prefix netip.Prefix) IPRange {
+	return IPRange{First: prefix.Addr(), Last: lastAddr(prefix)}
+}
+
+// lastAddr is the last address of the network prefix
+func lastAddr(prefix netip.Prefix) netip.Addr {
+	return setBits(prefix.Addr(), prefix.Bits(), prefix.Addr().BitLen())
+}
+
+// setBits sets the bits of addr from from to to, excluded
+func setBits(addr netip.Addr, from, to int) netip.Addr {
+	bytes, offset := addr.As16(), 128-addr.BitLen()
+	for i := offset + from; i < offset+to; i++ {
+		bytes[i/8] |= 0x80 >> (i % 8)
+	}
+	if addr.Is4() {
+		return netip.AddrFrom16(bytes).Unmap()
+	}
+	return netip.AddrFrom16(bytes)
+}
+
+// Contains reports whether ip is in the range
+func (r IPRange) Contains(ip net.IP) bool {
+	addr, ok := toAddr(ip)
+	return ok && r.ContainsAddr(addr)
+}
+
+// ContainsAddr is Contains for a netip.Addr
+func (r IPRange) ContainsAddr(addr netip.Addr) bool {
+	// addresses of the other family are before or after every address of
+	// the range
+	addr = addr.Unmap().WithZone("")
+	return r.First.IsValid() && !addr.Less(r.First) && !r.Last.Less(addr)
+}
+
+// Prefixes returns the range as the fewest CIDRs, in the order of their
+// addresses
+func (r IPRange) Prefixes() []netip.Prefix {
+	var prefixes []netip.Prefix
+	for first := r.First; first.IsValid(); {
+		// the largest network that starts at first and ends in the range
+		bits := first.BitLen()
+		for bits > 0 {
+			prefix := netip.PrefixFrom(first, bits-1)
+			if prefix.Masked().Addr() != first || r.Last.Less(lastAddr(prefix)) {
+				break
+			}
+			bits--
+		}
+		prefix := netip.PrefixFrom(first, bits)
+		prefixes = append(prefixes, prefix)
+		last := lastAddr(prefix)
+		if last == r.Last {
+			break
+		}
+		first = last.Next()
+	}
+	return prefixes
+}
+
+// String renders the range the way it is written in rules
+func (r IPRange) String() string {
+	return r.First.String() + "-" + r.Last.String()
+}
+
+// ipEntryString renders an entry of an IP list: an address, a CIDR or a
+// range
+func ipEntryString(r IPRange) string {
+	if r.First == r.Last {
+		return r.First.String()
+	}
+	if prefixes := r.Prefixes(); len(prefixes) == 1 {
+		return prefixes[0].String()
+	}
+	return r.String()
+}
+
+// compareRanges orders IPv4 ranges before IPv6 ones, then by first address
+// and larger ranges first
+func compareRanges(a, b IPRange) int {
+	if c := a.First.Compare(b.First); c != 0 {
+		return c
+	}
+	return b.Last.Compare(a.Last)
+}
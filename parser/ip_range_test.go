@@ -0,0 +1,46 @@
+package parser
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIPRange(t *testing.T) {
+	r, err := ParseIPRange("10.0.0.5-10.0.0.99")
+	assert.NoError(t, err)
+	assert.Equal(t, "10.0.0.5-10.0.0.99", r.String())
+	assert.Equal(t, "[10.0.0.5/32 10.0.0.6/31 10.0.0.8/29 10.0.0.16/28 10.0.0.32/27 10.0.0.64/27 10.0.0.96/30]", fmtPrefixes(r.Prefixes()))
+	assert.True(t, r.Contains(net.ParseIP("10.0.0.5")))
+	assert.True(t, r.ContainsAddr(netip.MustParseAddr("::ffff:10.0.0.99")))
+	assert.False(t, r.ContainsAddr(netip.MustParseAddr("10.0.0.100")))
+	assert.False(t, r.ContainsAddr(netip.MustParseAddr("::a00:5")))
+	assert.False(t, IPRange{}.ContainsAddr(netip.MustParseAddr("10.0.0.5")))
+
+	r, err = ParseIPRange("::ffff:10.0.0.0-10.0.0.255")
+	assert.NoError(t, err)
+	assert.Equal(t, "[10.0.0.0/24]", fmtPrefixes(r.Prefixes()))
+	assert.Equal(t, "10.0.0.0/24", ipEntryString(r))
+
+	r, err = ParseIPRange("::-ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff")
+	assert.NoError(t, err)
+	assert.Equal(t, "[::/0]", fmtPrefixes(r.Prefixes()))
+
+	for _, s := range []string{"10.0.0.99-10.0.0.5", "10.0.0.5-fd00::1", "10.0.0.5", "10.0.0.5-", "fe80::1%eth0-fe80::2"} {
+		_, err := ParseIPRange(s)
+		assert.Error(t, err, s)
+	}
+
+	list, err := ParseIPList("10.0.0.0/8", "192.168.1.10-192.168.1.20", "fd00::1")
+	assert.NoError(t, err)
+	assert.Equal(t, "[10.0.0.0/8, 192.168.1.10-192.168.1.20, fd00::1]", list.String())
+	assert.Len(t, list.Nets(), 6)
+	assert.True(t, list.Contains(net.ParseIP("192.168.1.17")))
+}
+
+func fmtPrefixes(prefixes []netip.Prefix) string {
+	return fmt.Sprint(prefixes)
+}
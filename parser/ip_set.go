@@ -0,0 +1,274 @@
+package parser
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"net/netip"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// IPSet is a set of IPv4 and IPv6 addresses built from addresses, CIDRs and
+// ranges, such as a threat feed or a large allow list. It keeps the sorted,
+// merged ranges of its addresses of each family: adding addresses appends a
+// range, the ranges being sorted and merged on the next lookup, and looking an
+// address up is a binary search. IPv4-mapped IPv6 addresses and networks are
+// the IPv4 ones, as in IPCompareOperation. An IPSet is safe for concurrent
+// lookups once built, not while it is being added to.
+type IPSet struct {
+	v4, v6 []addrRange
+	// unsorted is set when v4 or v6 may not be sorted and merged
+	unsorted atomic.Bool
+	mu       sync.Mutex
+}
+
+// addrRange is the addresses from first to last, both included, of a family
+type addrRange struct {
+	first, last uint128
+}
+
+// uint128 is an address as a number
+type uint128 struct {
+	hi, lo uint64
+}
+
+// NewIPSet returns the set of the addresses of nets
+func NewIPSet(nets ...*net.IPNet) *IPSet {
+	s := &IPSet{}
+	for _, ipNet := range nets {
+		s.AddNet(ipNet)
+	}
+	return s
+}
+
+// AddIP adds the address ip
+func (s *IPSet) AddIP(ip net.IP) {
+	if addr, ok := toAddr(ip); ok {
+		s.AddAddr(addr)
+	}
+}
+
+// AddAddr adds the address addr
+func (s *IPSet) AddAddr(addr netip.Addr) {
+	s.AddPrefix(hostPrefix(addr.Unmap().WithZone("")))
+}
+
+// AddNet adds the addresses of ipNet
+func (s *IPSet) AddNet(ipNet *net.IPNet) {
+	if prefix, ok := toPrefix(ipNet); ok {
+		s.AddPrefix(prefix)
+	}
+}
+
+// AddPrefix adds the addresses of prefix
+func (s *IPSet) AddPrefix(prefix netip.Prefix) {
+	prefix, ok := normalizePrefix(prefix)
+	if !ok {
+		return
+	}
+	s.add(prefix.Addr(), lastAddr(prefix))
+}
+
+// AddRange adds the addresses from first to last, both included, of the
+// same family
+func (s *IPSet) AddRange(first, last net.IP) error {
+	firstAddr, ok := toAddr(first)
+	lastAddr, ok2 := toAddr(last)
+	if !ok || !ok2 {
+		return fmt.Errorf("invalid IP range %s-%s", first, last)
+	}
+	return s.AddAddrRange(firstAddr, lastAddr)
+}
+
+// AddAddrRange is AddRange for netip.Addr
+func (s *IPSet) AddAddrRange(first, last netip.Addr) error {
+	first, last = first.Unmap().WithZone(""), last.Unmap().WithZone("")
+	if !first.IsValid() || !last.IsValid() || first.BitLen() != last.BitLen() {
+		return fmt.Errorf("invalid IP range %s-%s", first, last)
+	}
+	if first.Compare(last) > 0 {
+		return fmt.Errorf("invalid IP range %s-%s: %s is after %s", first, last, first, last)
+	}
+	s.add(first, last)
+	return nil
+}
+
+// Merge adds the addresses of other
+func (s *IPSet) Merge(other *IPSet) {
+	other.sort()
+	s.v4 = append(s.v4, other.v4...)
+	s.v6 = append(s.v6, other.v6...)
+	s.unsorted.Store(true)
+}
+
+// Contains reports whether ip is in the set
+func (s *IPSet) Contains(ip net.IP) bool {
+	addr, ok := toAddr(ip)
+	return ok && s.ContainsAddr(addr)
+}
+
+// ContainsAddr is Contains for a netip.Addr. It doesn't allocate.
+func (s *IPSet) ContainsAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() {
+		return false
+	}
+	s.sort()
+	n := addrUint128(addr)
+	return containsRange(s.ranges(addr), n, n)
+}
+
+// containsAddrRange reports whether the addresses from first to last, of the
+// same family, are all in the set
+func (s *IPSet) containsAddrRange(first, last netip.Addr) bool {
+	first, last = first.Unmap(), last.Unmap()
+	if !first.IsValid() {
+		return false
+	}
+	s.sort()
+	return containsRange(s.ranges(first), addrUint128(first), addrUint128(last))
+}
+
+// Nets returns the set as the fewest CIDRs, IPv4 ones first and in the order
+// of their addresses
+func (s *IPSet) Nets() []*net.IPNet {
+	prefixes := s.Prefixes()
+	nets := make([]*net.IPNet, len(prefixes))
+	for i, prefix := range prefixes {
+		nets[i] = prefixNet(prefix)
+	}
+	return nets
+}
+
+// Prefixes is Nets as netip.Prefix
+func (s *IPSet) Prefixes() []netip.Prefix {
+	s.sort()
+	var prefixes []netip.Prefix
+	for _, ranges := range [][]addrRange{s.v4, s.v6} {
+		for _, r := range ranges {
+			prefixes = append(prefixes, IPRange{First: r.first.addr(), Last: r.last.addr()}.Prefixes()...)
+		}
+	}
+	return prefixes
+}
+
+// String renders the set as an IP list of its CIDRs
+func (s *IPSet) String() string {
+	prefixes := s.Prefixes()
+	entries := make([]string, len(prefixes))
+	for i, prefix := range prefixes {
+		entries[i] = ipEntryString(prefixRange(prefix))
+	}
+	return "[" + strings.Join(entries, ", ") + "]"
+}
+
+func (s *IPSet) ranges(addr netip.Addr) []addrRange {
+	if addr.Is4() {
+		return s.v4
+	}
+	return s.v6
+}
+
+// add adds the addresses from first to last, unmapped and of the same family.
+// Ranges added in order, as from a sorted feed, keep the set sorted.
+func (s *IPSet) add(first, last netip.Addr) {
+	r := addrRange{addrUint128(first), addrUint128(last)}
+	ranges := &s.v6
+	if first.Is4() {
+		ranges = &s.v4
+	}
+	if n := len(*ranges); n > 0 && !(*ranges)[n-1].before(r) {
+		s.unsorted.Store(true)
+	}
+	*ranges = append(*ranges, r)
+}
+
+// sort sorts and merges the ranges if ranges were added since it last did
+func (s *IPSet) sort() {
+	if !s.unsorted.Load() {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.unsorted.Load() {
+		s.v4, s.v6 = mergeRanges(s.v4), mergeRanges(s.v6)
+		s.unsorted.Store(false)
+	}
+}
+
+// mergeRanges sorts ranges and merges the ones that overlap or touch, in place
+func mergeRanges(ranges []addrRange) []addrRange {
+	slices.SortFunc(ranges, func(a, b addrRange) int {
+		return a.first.compare(b.first)
+	})
+	merged := ranges[:0]
+	for _, r := range ranges {
+		if n := len(merged); n > 0 && !merged[n-1].before(r) {
+			if merged[n-1].last.compare(r.last) < 0 {
+				merged[n-1].last = r.last
+			}
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
+
+// containsRange reports whether one of the sorted, merged ranges has all the
+// addresses from first to last
+func containsRange(ranges []addrRange, first, last uint128) bool {
+	// the number of ranges starting at or before first
+	lo, hi := 0, len(ranges)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if first.compare(ranges[mid].first) < 0 {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return lo > 0 && ranges[lo-1].last.compare(last) >= 0
+}
+
+// before reports whether r ends before other starts, with addresses between
+// them, so that they can't be merged
+func (r addrRange) before(other addrRange) bool {
+	next, ok := r.last.next()
+	return ok && next.compare(other.first) < 0
+}
+
+func addrUint128(addr netip.Addr) uint128 {
+	bytes := addr.As16()
+	return uint128{binary.BigEndian.Uint64(bytes[:8]), binary.BigEndian.Uint64(bytes[8:])}
+}
+
+// addr is the address n is, IPv4 ones being IPv4-mapped
+func (n uint128) addr() netip.Addr {
+	var bytes [16]byte
+	binary.BigEndian.PutUint64(bytes[:8], n.hi)
+	binary.BigEndian.PutUint64(bytes[8:], n.lo)
+	return netip.AddrFrom16(bytes).Unmap()
+}
+
+func (n uint128) compare(other uint128) int {
+	switch {
+	case n.hi < other.hi || n.hi == other.hi && n.lo < other.lo:
+		return -1
+	case n == other:
+		return 0
+	}
+	return 1
+}
+
+// next is n+1, if n isn't the last IPv6 address
+func (n uint128) next() (uint128, bool) {
+	if n.lo++; n.lo == 0 {
+		if n.hi++; n.hi == 0 {
+			return n, false
+		}
+	}
+	return n, true
+}
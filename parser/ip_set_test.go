@@ -0,0 +1,201 @@
+package parser
+
+import (
+	"fmt"
+	"math/rand"
+	"net"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIPSet(t *testing.T) {
+	set := NewIPSet(CIDRBuilder("10.0.0.0/8", "192.168.1.0/24", "fd00::/8")...)
+	set.AddIP(net.ParseIP("172.16.0.1"))
+	set.AddIP(net.ParseIP("2001:db8::1"))
+
+	tests := []struct {
+		ip       string
+		contains bool
+	}{
+		{"10.0.0.0", true},
+		{"10.255.255.255", true},
+		{"11.0.0.0", false},
+		{"192.168.1.77", true},
+		{"192.168.2.1", false},
+		{"172.16.0.1", true},
+		{"172.16.0.2", false},
+		{"fd12:3456::1", true},
+		{"fe80::1", false},
+		{"2001:db8::1", true},
+		{"2001:db8::2", false},
+		{"::ffff:10.1.2.3", true},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.contains, set.Contains(net.ParseIP(tt.ip)), tt.ip)
+	}
+	assert.False(t, set.Contains(nil))
+	assert.False(t, NewIPSet().Contains(net.ParseIP("10.0.0.1")))
+	assert.True(t, set.ContainsAddr(netip.MustParseAddr("::ffff:192.168.1.1")))
+	assert.False(t, set.ContainsAddr(netip.Addr{}))
+
+	// IPv4-mapped networks are the IPv4 ones
+	mapped := &IPSet{}
+	mapped.AddPrefix(netip.MustParsePrefix("::ffff:172.20.0.0/112"))
+	mapped.AddAddr(netip.MustParseAddr("::ffff:172.21.0.1"))
+	assert.Equal(t, "[172.20.0.0/16, 172.21.0.1]", mapped.String())
+	assert.True(t, mapped.ContainsAddr(netip.MustParseAddr("172.20.3.4")))
+	assert.False(t, mapped.ContainsAddr(netip.MustParseAddr("172.22.0.1")))
+}
+
+func TestIPSetAggregate(t *testing.T) {
+	set := NewIPSet(CIDRBuilder("10.0.0.0/25", "10.0.0.128/26", "10.0.0.192/26", "10.0.1.5/32", "fd00::/9", "fd80::/9")...)
+	assert.Equal(t, "[10.0.0.0/24, 10.0.1.5, fd00::/8]", set.String())
+
+	// a network covering the entries replaces them
+	set.AddNet(CIDRBuilder("10.0.0.0/23")[0])
+	assert.Equal(t, "[10.0.0.0/23, fd00::/8]", set.String())
+	set.AddIP(net.ParseIP("10.0.0.7"))
+	assert.Equal(t, "[10.0.0.0/23, fd00::/8]", set.String())
+
+	other := NewIPSet(CIDRBuilder("10.0.2.0/23", "0.0.0.0/32")...)
+	set.Merge(other)
+	assert.Equal(t, "[0.0.0.0, 10.0.0.0/22, fd00::/8]", set.String())
+}
+
+func TestIPSetRange(t *testing.T) {
+	set := &IPSet{}
+	assert.NoError(t, set.AddRange(net.ParseIP("10.0.0.5"), net.ParseIP("10.0.0.99")))
+	assert.Equal(t, "[10.0.0.5, 10.0.0.6/31, 10.0.0.8/29, 10.0.0.16/28, 10.0.0.32/27, 10.0.0.64/27, 10.0.0.96/30]", set.String())
+	assert.False(t, set.Contains(net.ParseIP("10.0.0.4")))
+	assert.True(t, set.Contains(net.ParseIP("10.0.0.5")))
+	assert.True(t, set.Contains(net.ParseIP("10.0.0.99")))
+	assert.False(t, set.Contains(net.ParseIP("10.0.0.100")))
+
+	assert.NoError(t, set.AddRange(net.ParseIP("10.0.0.0"), net.ParseIP("10.0.0.4")))
+	assert.NoError(t, set.AddRange(net.ParseIP("10.0.0.100"), net.ParseIP("10.0.0.255")))
+	assert.Equal(t, "[10.0.0.0/24]", set.String())
+
+	assert.NoError(t, set.AddRange(net.ParseIP("::"), net.ParseIP("ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff")))
+	assert.Equal(t, "[10.0.0.0/24, ::/0]", set.String())
+
+	assert.Error(t, set.AddRange(net.ParseIP("10.0.0.9"), net.ParseIP("10.0.0.1")))
+	assert.Error(t, set.AddRange(net.ParseIP("10.0.0.1"), net.ParseIP("::1")))
+	assert.Error(t, set.AddRange(nil, net.ParseIP("10.0.0.1")))
+}
+
+func randomNet(r *rand.Rand) *net.IPNet {
+	if r.Intn(4) == 0 {
+		ip := make(net.IP, net.IPv6len)
+		ip[0] = 0xfd
+		r.Read(ip[1:])
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(16+r.Intn(113), 128)}
+	}
+	ip := net.IPv4(10, byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256))).To4()
+	mask := net.CIDRMask(20+r.Intn(13), 32)
+	return &net.IPNet{IP: ip.Mask(mask), Mask: mask}
+}
+
+func TestIPSetMatchesScan(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	nets := make([]*net.IPNet, 2000)
+	for i := range nets {
+		nets[i] = randomNet(r)
+	}
+	set := NewIPSet(nets...)
+	for i := 0; i < 10000; i++ {
+		ip := randomNet(r).IP
+		scan := false
+		for _, ipNet := range nets {
+			scan = scan || ipNet.Contains(ip)
+		}
+		assert.Equal(t, scan, set.Contains(ip), ip.String())
+	}
+
+	// the aggregated networks are the same set
+	again := NewIPSet(set.Nets()...)
+	assert.Equal(t, set.String(), again.String())
+}
+
+func TestIPSetRule(t *testing.T) {
+	// long IP lists are looked up in an IPSet
+	entries := make([]string, 1000)
+	for i := range entries {
+		entries[i] = fmt.Sprintf("10.%d.%d.0/24", i/256, i%256)
+	}
+	rule := fmt.Sprintf("x in [%s, fd00::/8]", strings.Join(entries, ", "))
+	ev, err := NewEvaluator(rule)
+	assert.NoError(t, err)
+	assert.NotNil(t, ev.Expr().(*CompareExpr).Right.(*Literal).Val.(*IPList).set)
+	for ip, contains := range map[string]bool{"10.0.0.1": true, "10.3.231.255": true, "10.3.232.0": false, "fd00::1": true, "::1": false} {
+		match, err := ev.Process(obj{"x": ip})
+		assert.NoError(t, err)
+		assert.Equal(t, contains, match, ip)
+	}
+
+	// a set is a right operand, whatever the type of the address
+	feed := NewIPSet(CIDRBuilder("192.0.2.0/24")...)
+	assert.True(t, Evaluate("x in feed", obj{"x": "192.0.2.1", "feed": feed}))
+	assert.True(t, Evaluate("x in feed", obj{"x": net.ParseIP("192.0.2.1"), "feed": feed}))
+	assert.False(t, Evaluate("x in feed", obj{"x": "192.0.3.1", "feed": feed}))
+	assert.False(t, Evaluate("x in feed", obj{"x": 4.5, "feed": feed}))
+}
+
+// randomHost is a random IPv4 address in 10.0.0.0/8 or IPv6 one in fd00::/8
+func randomHost(r *rand.Rand, v6 bool) netip.Addr {
+	if v6 {
+		var bytes [16]byte
+		r.Read(bytes[:])
+		bytes[0] = 0xfd
+		return netip.AddrFrom16(bytes)
+	}
+	return netip.AddrFrom4([4]byte{10, byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256))})
+}
+
+func BenchmarkIPSetBuild(b *testing.B) {
+	for _, family := range []struct {
+		name string
+		v6   bool
+	}{{"IPv4", false}, {"IPv6", true}} {
+		r := rand.New(rand.NewSource(1))
+		hosts := make([]netip.Addr, 300000)
+		for i := range hosts {
+			hosts[i] = randomHost(r, family.v6)
+		}
+		b.Run(family.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				set := &IPSet{}
+				for _, host := range hosts {
+					set.AddAddr(host)
+				}
+				set.ContainsAddr(hosts[0])
+			}
+		})
+	}
+}
+
+func BenchmarkIPSetContains(b *testing.B) {
+	for _, family := range []struct {
+		name string
+		v6   bool
+	}{{"IPv4", false}, {"IPv6", true}} {
+		r := rand.New(rand.NewSource(1))
+		set := &IPSet{}
+		for i := 0; i < 300000; i++ {
+			set.AddAddr(randomHost(r, family.v6))
+		}
+		addrs := make([]netip.Addr, 1024)
+		for i := range addrs {
+			addrs[i] = randomHost(r, family.v6)
+		}
+		b.Run(family.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				set.ContainsAddr(addrs[i%len(addrs)])
+			}
+		})
+	}
+}
@@ -43,27 +43,29 @@ func jsonquerylexerLexerInit() {
 		"DEFAULT_MODE",
 	}
 	staticData.LiteralNames = []string{
-		"", "'('", "')'", "'pr'", "'.'", "'-'", "'['", "']'", "", "", "", "'null'",
-		"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "",
-		"", "", "", "'\\n'",
+		"", "'('", "')'", "'pr'", "'['", "']'", "", "", "", "", "", "'null'",
+		"", "", "", "", "", "", "", "", "", "", "", "", "'+'", "'-'", "'*'",
+		"'/'", "'%'", "'.'",
 	}
 	staticData.SymbolicNames = []string{
-		"", "", "", "", "", "", "", "", "NOT", "LOGICAL_OPERATOR", "BOOLEAN",
-		"NULL", "IN", "EQ", "NE", "GT", "LT", "GE", "LE", "CO", "SW", "EW",
-		"MT", "ATTRNAME", "VERSION", "STRING", "REGEX", "IP_ADDRESS", "IP_CIDR",
-		"DOUBLE", "INT", "EXP", "NEWLINE", "COMMA", "SP",
+		"", "", "", "", "", "", "NOT", "AND", "XOR", "OR", "BOOLEAN", "NULL",
+		"IN", "IS", "EQ", "NE", "GT", "LT", "GE", "LE", "CO", "SW", "EW", "MT",
+		"PLUS", "MINUS", "STAR", "SLASH", "PERCENT", "JSON_SEP", "ATTRNAME",
+		"VERSION", "STRING", "REGEX", "IP_ADDRESS", "IP_CIDR", "IP_RANGE", "DOUBLE",
+		"INT", "EXP", "COMMA", "SP",
 	}
 	staticData.RuleNames = []string{
-		"T__0", "T__1", "T__2", "T__3", "T__4", "T__5", "T__6", "NOT", "LOGICAL_OPERATOR",
-		"BOOLEAN", "NULL", "IN", "EQ", "NE", "GT", "LT", "GE", "LE", "CO", "SW",
-		"EW", "MT", "ATTRNAME", "ATTR_NAME_CHAR", "DIGIT", "ALPHA", "VERSION",
-		"STRING", "REGEX", "REGEX_ESC", "REGEX_FLAGS", "IP_ADDRESS", "IP_CIDR",
+		"T__0", "T__1", "T__2", "T__3", "T__4", "NOT", "AND", "XOR", "OR", "BOOLEAN",
+		"NULL", "IN", "IS", "EQ", "NE", "GT", "LT", "GE", "LE", "CO", "SW",
+		"EW", "MT", "PLUS", "MINUS", "STAR", "SLASH", "PERCENT", "JSON_SEP",
+		"ATTRNAME", "ATTR_NAME_CHAR", "DIGIT", "ALPHA", "VERSION", "STRING",
+		"REGEX", "REGEX_ESC", "REGEX_FLAGS", "IP_ADDRESS", "IP_CIDR", "IP_RANGE",
 		"IPv4", "OCTET", "IPv6", "HEX_QUARTET", "ESC", "UNICODE", "HEX", "DOUBLE",
 		"INT", "EXP", "NEWLINE", "COMMA", "SP",
 	}
 	staticData.PredictionContextCache = antlr.NewPredictionContextCache()
 	staticData.serializedATN = []int32{
-		4, 0, 34, 509, 6, -1, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2,
+		4, 0, 41, 630, 6, -1, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2,
 		4, 7, 4, 2, 5, 7, 5, 2, 6, 7, 6, 2, 7, 7, 7, 2, 8, 7, 8, 2, 9, 7, 9, 2,
 		10, 7, 10, 2, 11, 7, 11, 2, 12, 7, 12, 2, 13, 7, 13, 2, 14, 7, 14, 2, 15,
 		7, 15, 2, 16, 7, 16, 2, 17, 7, 17, 2, 18, 7, 18, 2, 19, 7, 19, 2, 20, 7,
@@ -71,239 +73,297 @@ func jsonquerylexerLexerInit() {
 		2, 26, 7, 26, 2, 27, 7, 27, 2, 28, 7, 28, 2, 29, 7, 29, 2, 30, 7, 30, 2,
 		31, 7, 31, 2, 32, 7, 32, 2, 33, 7, 33, 2, 34, 7, 34, 2, 35, 7, 35, 2, 36,
 		7, 36, 2, 37, 7, 37, 2, 38, 7, 38, 2, 39, 7, 39, 2, 40, 7, 40, 2, 41, 7,
-		41, 2, 42, 7, 42, 2, 43, 7, 43, 2, 44, 7, 44, 2, 45, 7, 45, 1, 0, 1, 0,
-		1, 1, 1, 1, 1, 2, 1, 2, 1, 2, 1, 3, 1, 3, 1, 4, 1, 4, 1, 5, 1, 5, 1, 6,
-		1, 6, 1, 7, 1, 7, 1, 7, 1, 7, 1, 7, 1, 7, 3, 7, 115, 8, 7, 1, 8, 1, 8,
-		1, 8, 1, 8, 1, 8, 3, 8, 122, 8, 8, 1, 9, 1, 9, 1, 9, 1, 9, 1, 9, 1, 9,
-		1, 9, 1, 9, 1, 9, 3, 9, 133, 8, 9, 1, 10, 1, 10, 1, 10, 1, 10, 1, 10, 1,
-		11, 1, 11, 1, 11, 1, 11, 3, 11, 144, 8, 11, 1, 12, 1, 12, 1, 12, 1, 12,
-		1, 12, 1, 12, 1, 12, 1, 12, 1, 12, 1, 12, 1, 12, 1, 12, 1, 12, 1, 12, 1,
-		12, 1, 12, 1, 12, 1, 12, 3, 12, 164, 8, 12, 1, 13, 1, 13, 1, 13, 1, 13,
-		1, 13, 1, 13, 1, 13, 1, 13, 1, 13, 1, 13, 1, 13, 1, 13, 1, 13, 1, 13, 1,
-		13, 1, 13, 3, 13, 182, 8, 13, 1, 14, 1, 14, 1, 14, 1, 14, 1, 14, 3, 14,
-		189, 8, 14, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15, 3, 15, 196, 8, 15, 1, 16,
-		1, 16, 1, 16, 1, 16, 1, 16, 1, 16, 1, 16, 1, 16, 1, 16, 1, 16, 1, 16, 1,
-		16, 3, 16, 210, 8, 16, 1, 17, 1, 17, 1, 17, 1, 17, 1, 17, 1, 17, 1, 17,
-		1, 17, 1, 17, 1, 17, 1, 17, 1, 17, 3, 17, 224, 8, 17, 1, 18, 1, 18, 1,
-		18, 1, 18, 1, 18, 1, 18, 1, 18, 1, 18, 1, 18, 1, 18, 1, 18, 1, 18, 3, 18,
-		238, 8, 18, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1,
-		19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 3, 19, 254, 8, 19, 1, 20, 1, 20,
-		1, 20, 1, 20, 1, 20, 1, 20, 1, 20, 1, 20, 1, 20, 1, 20, 1, 20, 1, 20, 3,
-		20, 268, 8, 20, 1, 21, 1, 21, 1, 21, 1, 21, 1, 21, 1, 21, 1, 21, 1, 21,
-		1, 21, 1, 21, 1, 21, 1, 21, 1, 21, 1, 21, 1, 21, 1, 21, 1, 21, 1, 21, 1,
-		21, 1, 21, 3, 21, 290, 8, 21, 1, 22, 1, 22, 5, 22, 294, 8, 22, 10, 22,
-		12, 22, 297, 9, 22, 1, 23, 1, 23, 1, 23, 3, 23, 302, 8, 23, 1, 24, 1, 24,
-		1, 25, 1, 25, 1, 26, 1, 26, 1, 26, 1, 26, 1, 26, 1, 26, 1, 27, 1, 27, 1,
-		27, 5, 27, 317, 8, 27, 10, 27, 12, 27, 320, 9, 27, 1, 27, 1, 27, 1, 28,
-		1, 28, 1, 28, 5, 28, 327, 8, 28, 10, 28, 12, 28, 330, 9, 28, 3, 28, 332,
-		8, 28, 1, 28, 1, 28, 3, 28, 336, 8, 28, 1, 28, 3, 28, 339, 8, 28, 1, 28,
-		3, 28, 342, 8, 28, 1, 29, 1, 29, 1, 29, 3, 29, 347, 8, 29, 1, 30, 1, 30,
-		1, 31, 1, 31, 3, 31, 353, 8, 31, 1, 32, 1, 32, 1, 32, 1, 32, 1, 32, 1,
-		32, 1, 32, 1, 32, 3, 32, 363, 8, 32, 1, 33, 1, 33, 1, 33, 1, 33, 1, 33,
-		1, 33, 1, 33, 1, 33, 1, 34, 1, 34, 1, 34, 1, 34, 1, 34, 1, 34, 1, 34, 1,
-		34, 1, 34, 1, 34, 1, 34, 1, 34, 1, 34, 3, 34, 386, 8, 34, 1, 35, 1, 35,
-		1, 35, 1, 35, 1, 35, 1, 35, 1, 35, 1, 35, 1, 35, 1, 35, 1, 35, 1, 35, 1,
-		35, 1, 35, 1, 35, 1, 35, 1, 35, 1, 35, 3, 35, 406, 8, 35, 1, 35, 1, 35,
-		1, 35, 1, 35, 1, 35, 1, 35, 5, 35, 414, 8, 35, 10, 35, 12, 35, 417, 9,
-		35, 1, 35, 1, 35, 1, 35, 1, 35, 5, 35, 423, 8, 35, 10, 35, 12, 35, 426,
-		9, 35, 1, 35, 1, 35, 1, 35, 1, 35, 1, 35, 1, 35, 5, 35, 434, 8, 35, 10,
-		35, 12, 35, 437, 9, 35, 1, 35, 3, 35, 440, 8, 35, 1, 36, 1, 36, 3, 36,
-		444, 8, 36, 1, 36, 3, 36, 447, 8, 36, 1, 36, 3, 36, 450, 8, 36, 1, 37,
-		1, 37, 1, 37, 3, 37, 455, 8, 37, 1, 38, 1, 38, 1, 38, 1, 38, 1, 38, 1,
-		38, 1, 39, 1, 39, 1, 40, 3, 40, 466, 8, 40, 1, 40, 1, 40, 1, 40, 4, 40,
-		471, 8, 40, 11, 40, 12, 40, 472, 1, 40, 3, 40, 476, 8, 40, 1, 41, 1, 41,
-		1, 41, 5, 41, 481, 8, 41, 10, 41, 12, 41, 484, 9, 41, 3, 41, 486, 8, 41,
-		1, 42, 1, 42, 3, 42, 490, 8, 42, 1, 42, 1, 42, 1, 43, 1, 43, 1, 44, 1,
-		44, 5, 44, 498, 8, 44, 10, 44, 12, 44, 501, 9, 44, 1, 45, 1, 45, 5, 45,
-		505, 8, 45, 10, 45, 12, 45, 508, 9, 45, 0, 0, 46, 1, 1, 3, 2, 5, 3, 7,
-		4, 9, 5, 11, 6, 13, 7, 15, 8, 17, 9, 19, 10, 21, 11, 23, 12, 25, 13, 27,
-		14, 29, 15, 31, 16, 33, 17, 35, 18, 37, 19, 39, 20, 41, 21, 43, 22, 45,
-		23, 47, 0, 49, 0, 51, 0, 53, 24, 55, 25, 57, 26, 59, 0, 61, 0, 63, 27,
-		65, 28, 67, 0, 69, 0, 71, 0, 73, 0, 75, 0, 77, 0, 79, 0, 81, 29, 83, 30,
-		85, 31, 87, 32, 89, 33, 91, 34, 1, 0, 14, 3, 0, 45, 45, 58, 58, 95, 95,
-		2, 0, 65, 90, 97, 122, 2, 0, 34, 34, 92, 92, 2, 0, 47, 47, 92, 92, 10,
-		0, 47, 47, 66, 66, 68, 68, 83, 83, 87, 87, 92, 92, 98, 98, 100, 100, 115,
-		115, 119, 119, 3, 0, 103, 103, 105, 105, 109, 109, 1, 0, 48, 53, 1, 0,
-		48, 52, 1, 0, 48, 57, 1, 0, 49, 57, 8, 0, 34, 34, 47, 47, 92, 92, 98, 98,
-		102, 102, 110, 110, 114, 114, 116, 116, 3, 0, 48, 57, 65, 70, 97, 102,
-		2, 0, 69, 69, 101, 101, 2, 0, 43, 43, 45, 45, 565, 0, 1, 1, 0, 0, 0, 0,
-		3, 1, 0, 0, 0, 0, 5, 1, 0, 0, 0, 0, 7, 1, 0, 0, 0, 0, 9, 1, 0, 0, 0, 0,
-		11, 1, 0, 0, 0, 0, 13, 1, 0, 0, 0, 0, 15, 1, 0, 0, 0, 0, 17, 1, 0, 0, 0,
-		0, 19, 1, 0, 0, 0, 0, 21, 1, 0, 0, 0, 0, 23, 1, 0, 0, 0, 0, 25, 1, 0, 0,
-		0, 0, 27, 1, 0, 0, 0, 0, 29, 1, 0, 0, 0, 0, 31, 1, 0, 0, 0, 0, 33, 1, 0,
-		0, 0, 0, 35, 1, 0, 0, 0, 0, 37, 1, 0, 0, 0, 0, 39, 1, 0, 0, 0, 0, 41, 1,
-		0, 0, 0, 0, 43, 1, 0, 0, 0, 0, 45, 1, 0, 0, 0, 0, 53, 1, 0, 0, 0, 0, 55,
-		1, 0, 0, 0, 0, 57, 1, 0, 0, 0, 0, 63, 1, 0, 0, 0, 0, 65, 1, 0, 0, 0, 0,
-		81, 1, 0, 0, 0, 0, 83, 1, 0, 0, 0, 0, 85, 1, 0, 0, 0, 0, 87, 1, 0, 0, 0,
-		0, 89, 1, 0, 0, 0, 0, 91, 1, 0, 0, 0, 1, 93, 1, 0, 0, 0, 3, 95, 1, 0, 0,
-		0, 5, 97, 1, 0, 0, 0, 7, 100, 1, 0, 0, 0, 9, 102, 1, 0, 0, 0, 11, 104,
-		1, 0, 0, 0, 13, 106, 1, 0, 0, 0, 15, 114, 1, 0, 0, 0, 17, 121, 1, 0, 0,
-		0, 19, 132, 1, 0, 0, 0, 21, 134, 1, 0, 0, 0, 23, 143, 1, 0, 0, 0, 25, 163,
-		1, 0, 0, 0, 27, 181, 1, 0, 0, 0, 29, 188, 1, 0, 0, 0, 31, 195, 1, 0, 0,
-		0, 33, 209, 1, 0, 0, 0, 35, 223, 1, 0, 0, 0, 37, 237, 1, 0, 0, 0, 39, 253,
-		1, 0, 0, 0, 41, 267, 1, 0, 0, 0, 43, 289, 1, 0, 0, 0, 45, 291, 1, 0, 0,
-		0, 47, 301, 1, 0, 0, 0, 49, 303, 1, 0, 0, 0, 51, 305, 1, 0, 0, 0, 53, 307,
-		1, 0, 0, 0, 55, 313, 1, 0, 0, 0, 57, 323, 1, 0, 0, 0, 59, 346, 1, 0, 0,
-		0, 61, 348, 1, 0, 0, 0, 63, 352, 1, 0, 0, 0, 65, 362, 1, 0, 0, 0, 67, 364,
-		1, 0, 0, 0, 69, 385, 1, 0, 0, 0, 71, 439, 1, 0, 0, 0, 73, 441, 1, 0, 0,
-		0, 75, 451, 1, 0, 0, 0, 77, 456, 1, 0, 0, 0, 79, 462, 1, 0, 0, 0, 81, 465,
-		1, 0, 0, 0, 83, 485, 1, 0, 0, 0, 85, 487, 1, 0, 0, 0, 87, 493, 1, 0, 0,
-		0, 89, 495, 1, 0, 0, 0, 91, 502, 1, 0, 0, 0, 93, 94, 5, 40, 0, 0, 94, 2,
-		1, 0, 0, 0, 95, 96, 5, 41, 0, 0, 96, 4, 1, 0, 0, 0, 97, 98, 5, 112, 0,
-		0, 98, 99, 5, 114, 0, 0, 99, 6, 1, 0, 0, 0, 100, 101, 5, 46, 0, 0, 101,
-		8, 1, 0, 0, 0, 102, 103, 5, 45, 0, 0, 103, 10, 1, 0, 0, 0, 104, 105, 5,
-		91, 0, 0, 105, 12, 1, 0, 0, 0, 106, 107, 5, 93, 0, 0, 107, 14, 1, 0, 0,
-		0, 108, 109, 5, 110, 0, 0, 109, 110, 5, 111, 0, 0, 110, 115, 5, 116, 0,
-		0, 111, 112, 5, 78, 0, 0, 112, 113, 5, 79, 0, 0, 113, 115, 5, 84, 0, 0,
-		114, 108, 1, 0, 0, 0, 114, 111, 1, 0, 0, 0, 115, 16, 1, 0, 0, 0, 116, 117,
-		5, 97, 0, 0, 117, 118, 5, 110, 0, 0, 118, 122, 5, 100, 0, 0, 119, 120,
-		5, 111, 0, 0, 120, 122, 5, 114, 0, 0, 121, 116, 1, 0, 0, 0, 121, 119, 1,
-		0, 0, 0, 122, 18, 1, 0, 0, 0, 123, 124, 5, 116, 0, 0, 124, 125, 5, 114,
-		0, 0, 125, 126, 5, 117, 0, 0, 126, 133, 5, 101, 0, 0, 127, 128, 5, 102,
-		0, 0, 128, 129, 5, 97, 0, 0, 129, 130, 5, 108, 0, 0, 130, 131, 5, 115,
-		0, 0, 131, 133, 5, 101, 0, 0, 132, 123, 1, 0, 0, 0, 132, 127, 1, 0, 0,
-		0, 133, 20, 1, 0, 0, 0, 134, 135, 5, 110, 0, 0, 135, 136, 5, 117, 0, 0,
-		136, 137, 5, 108, 0, 0, 137, 138, 5, 108, 0, 0, 138, 22, 1, 0, 0, 0, 139,
-		140, 5, 73, 0, 0, 140, 144, 5, 78, 0, 0, 141, 142, 5, 105, 0, 0, 142, 144,
-		5, 110, 0, 0, 143, 139, 1, 0, 0, 0, 143, 141, 1, 0, 0, 0, 144, 24, 1, 0,
-		0, 0, 145, 146, 5, 101, 0, 0, 146, 164, 5, 113, 0, 0, 147, 148, 5, 69,
-		0, 0, 148, 164, 5, 81, 0, 0, 149, 150, 5, 101, 0, 0, 150, 151, 5, 113,
-		0, 0, 151, 152, 5, 117, 0, 0, 152, 153, 5, 97, 0, 0, 153, 154, 5, 108,
-		0, 0, 154, 164, 5, 115, 0, 0, 155, 156, 5, 69, 0, 0, 156, 157, 5, 81, 0,
-		0, 157, 158, 5, 85, 0, 0, 158, 159, 5, 65, 0, 0, 159, 160, 5, 76, 0, 0,
-		160, 164, 5, 83, 0, 0, 161, 162, 5, 61, 0, 0, 162, 164, 5, 61, 0, 0, 163,
-		145, 1, 0, 0, 0, 163, 147, 1, 0, 0, 0, 163, 149, 1, 0, 0, 0, 163, 155,
-		1, 0, 0, 0, 163, 161, 1, 0, 0, 0, 164, 26, 1, 0, 0, 0, 165, 166, 5, 110,
-		0, 0, 166, 182, 5, 101, 0, 0, 167, 168, 5, 78, 0, 0, 168, 182, 5, 69, 0,
-		0, 169, 170, 5, 110, 0, 0, 170, 171, 5, 111, 0, 0, 171, 172, 5, 116, 0,
-		0, 172, 173, 5, 101, 0, 0, 173, 182, 5, 113, 0, 0, 174, 175, 5, 78, 0,
-		0, 175, 176, 5, 79, 0, 0, 176, 177, 5, 84, 0, 0, 177, 178, 5, 69, 0, 0,
-		178, 182, 5, 81, 0, 0, 179, 180, 5, 33, 0, 0, 180, 182, 5, 61, 0, 0, 181,
-		165, 1, 0, 0, 0, 181, 167, 1, 0, 0, 0, 181, 169, 1, 0, 0, 0, 181, 174,
-		1, 0, 0, 0, 181, 179, 1, 0, 0, 0, 182, 28, 1, 0, 0, 0, 183, 184, 5, 103,
-		0, 0, 184, 189, 5, 116, 0, 0, 185, 186, 5, 71, 0, 0, 186, 189, 5, 84, 0,
-		0, 187, 189, 5, 62, 0, 0, 188, 183, 1, 0, 0, 0, 188, 185, 1, 0, 0, 0, 188,
-		187, 1, 0, 0, 0, 189, 30, 1, 0, 0, 0, 190, 191, 5, 108, 0, 0, 191, 196,
-		5, 116, 0, 0, 192, 193, 5, 76, 0, 0, 193, 196, 5, 84, 0, 0, 194, 196, 5,
-		60, 0, 0, 195, 190, 1, 0, 0, 0, 195, 192, 1, 0, 0, 0, 195, 194, 1, 0, 0,
-		0, 196, 32, 1, 0, 0, 0, 197, 198, 5, 103, 0, 0, 198, 210, 5, 101, 0, 0,
-		199, 200, 5, 71, 0, 0, 200, 210, 5, 69, 0, 0, 201, 202, 5, 103, 0, 0, 202,
-		203, 5, 116, 0, 0, 203, 210, 5, 101, 0, 0, 204, 205, 5, 71, 0, 0, 205,
-		206, 5, 84, 0, 0, 206, 210, 5, 69, 0, 0, 207, 208, 5, 62, 0, 0, 208, 210,
-		5, 61, 0, 0, 209, 197, 1, 0, 0, 0, 209, 199, 1, 0, 0, 0, 209, 201, 1, 0,
-		0, 0, 209, 204, 1, 0, 0, 0, 209, 207, 1, 0, 0, 0, 210, 34, 1, 0, 0, 0,
-		211, 212, 5, 108, 0, 0, 212, 224, 5, 101, 0, 0, 213, 214, 5, 76, 0, 0,
-		214, 224, 5, 69, 0, 0, 215, 216, 5, 108, 0, 0, 216, 217, 5, 116, 0, 0,
-		217, 224, 5, 101, 0, 0, 218, 219, 5, 76, 0, 0, 219, 220, 5, 84, 0, 0, 220,
-		224, 5, 69, 0, 0, 221, 222, 5, 60, 0, 0, 222, 224, 5, 61, 0, 0, 223, 211,
-		1, 0, 0, 0, 223, 213, 1, 0, 0, 0, 223, 215, 1, 0, 0, 0, 223, 218, 1, 0,
-		0, 0, 223, 221, 1, 0, 0, 0, 224, 36, 1, 0, 0, 0, 225, 226, 5, 99, 0, 0,
-		226, 238, 5, 111, 0, 0, 227, 228, 5, 67, 0, 0, 228, 238, 5, 79, 0, 0, 229,
-		230, 5, 99, 0, 0, 230, 231, 5, 111, 0, 0, 231, 232, 5, 110, 0, 0, 232,
-		233, 5, 116, 0, 0, 233, 234, 5, 97, 0, 0, 234, 235, 5, 105, 0, 0, 235,
-		236, 5, 110, 0, 0, 236, 238, 5, 115, 0, 0, 237, 225, 1, 0, 0, 0, 237, 227,
-		1, 0, 0, 0, 237, 229, 1, 0, 0, 0, 238, 38, 1, 0, 0, 0, 239, 240, 5, 115,
-		0, 0, 240, 254, 5, 119, 0, 0, 241, 242, 5, 83, 0, 0, 242, 254, 5, 87, 0,
-		0, 243, 244, 5, 115, 0, 0, 244, 245, 5, 116, 0, 0, 245, 246, 5, 97, 0,
-		0, 246, 247, 5, 114, 0, 0, 247, 248, 5, 116, 0, 0, 248, 249, 5, 115, 0,
-		0, 249, 250, 5, 87, 0, 0, 250, 251, 5, 105, 0, 0, 251, 252, 5, 116, 0,
-		0, 252, 254, 5, 104, 0, 0, 253, 239, 1, 0, 0, 0, 253, 241, 1, 0, 0, 0,
-		253, 243, 1, 0, 0, 0, 254, 40, 1, 0, 0, 0, 255, 256, 5, 101, 0, 0, 256,
-		268, 5, 119, 0, 0, 257, 258, 5, 69, 0, 0, 258, 268, 5, 87, 0, 0, 259, 260,
-		5, 101, 0, 0, 260, 261, 5, 110, 0, 0, 261, 262, 5, 100, 0, 0, 262, 263,
-		5, 115, 0, 0, 263, 264, 5, 87, 0, 0, 264, 265, 5, 105, 0, 0, 265, 266,
-		5, 116, 0, 0, 266, 268, 5, 104, 0, 0, 267, 255, 1, 0, 0, 0, 267, 257, 1,
-		0, 0, 0, 267, 259, 1, 0, 0, 0, 268, 42, 1, 0, 0, 0, 269, 270, 5, 109, 0,
-		0, 270, 290, 5, 116, 0, 0, 271, 272, 5, 77, 0, 0, 272, 290, 5, 84, 0, 0,
-		273, 274, 5, 109, 0, 0, 274, 275, 5, 97, 0, 0, 275, 276, 5, 116, 0, 0,
-		276, 277, 5, 99, 0, 0, 277, 278, 5, 104, 0, 0, 278, 279, 5, 101, 0, 0,
-		279, 290, 5, 115, 0, 0, 280, 281, 5, 77, 0, 0, 281, 282, 5, 65, 0, 0, 282,
-		283, 5, 84, 0, 0, 283, 284, 5, 67, 0, 0, 284, 285, 5, 72, 0, 0, 285, 286,
-		5, 69, 0, 0, 286, 290, 5, 83, 0, 0, 287, 288, 5, 126, 0, 0, 288, 290, 5,
-		61, 0, 0, 289, 269, 1, 0, 0, 0, 289, 271, 1, 0, 0, 0, 289, 273, 1, 0, 0,
-		0, 289, 280, 1, 0, 0, 0, 289, 287, 1, 0, 0, 0, 290, 44, 1, 0, 0, 0, 291,
-		295, 3, 51, 25, 0, 292, 294, 3, 47, 23, 0, 293, 292, 1, 0, 0, 0, 294, 297,
-		1, 0, 0, 0, 295, 293, 1, 0, 0, 0, 295, 296, 1, 0, 0, 0, 296, 46, 1, 0,
-		0, 0, 297, 295, 1, 0, 0, 0, 298, 302, 7, 0, 0, 0, 299, 302, 3, 49, 24,
-		0, 300, 302, 3, 51, 25, 0, 301, 298, 1, 0, 0, 0, 301, 299, 1, 0, 0, 0,
-		301, 300, 1, 0, 0, 0, 302, 48, 1, 0, 0, 0, 303, 304, 2, 48, 57, 0, 304,
-		50, 1, 0, 0, 0, 305, 306, 7, 1, 0, 0, 306, 52, 1, 0, 0, 0, 307, 308, 3,
-		83, 41, 0, 308, 309, 5, 46, 0, 0, 309, 310, 3, 83, 41, 0, 310, 311, 5,
-		46, 0, 0, 311, 312, 3, 83, 41, 0, 312, 54, 1, 0, 0, 0, 313, 318, 5, 34,
-		0, 0, 314, 317, 3, 75, 37, 0, 315, 317, 8, 2, 0, 0, 316, 314, 1, 0, 0,
-		0, 316, 315, 1, 0, 0, 0, 317, 320, 1, 0, 0, 0, 318, 316, 1, 0, 0, 0, 318,
-		319, 1, 0, 0, 0, 319, 321, 1, 0, 0, 0, 320, 318, 1, 0, 0, 0, 321, 322,
-		5, 34, 0, 0, 322, 56, 1, 0, 0, 0, 323, 331, 5, 47, 0, 0, 324, 332, 3, 59,
-		29, 0, 325, 327, 8, 3, 0, 0, 326, 325, 1, 0, 0, 0, 327, 330, 1, 0, 0, 0,
-		328, 326, 1, 0, 0, 0, 328, 329, 1, 0, 0, 0, 329, 332, 1, 0, 0, 0, 330,
-		328, 1, 0, 0, 0, 331, 324, 1, 0, 0, 0, 331, 328, 1, 0, 0, 0, 332, 333,
-		1, 0, 0, 0, 333, 335, 5, 47, 0, 0, 334, 336, 3, 61, 30, 0, 335, 334, 1,
-		0, 0, 0, 335, 336, 1, 0, 0, 0, 336, 338, 1, 0, 0, 0, 337, 339, 3, 61, 30,
-		0, 338, 337, 1, 0, 0, 0, 338, 339, 1, 0, 0, 0, 339, 341, 1, 0, 0, 0, 340,
-		342, 3, 61, 30, 0, 341, 340, 1, 0, 0, 0, 341, 342, 1, 0, 0, 0, 342, 58,
-		1, 0, 0, 0, 343, 347, 3, 75, 37, 0, 344, 345, 5, 92, 0, 0, 345, 347, 7,
-		4, 0, 0, 346, 343, 1, 0, 0, 0, 346, 344, 1, 0, 0, 0, 347, 60, 1, 0, 0,
-		0, 348, 349, 7, 5, 0, 0, 349, 62, 1, 0, 0, 0, 350, 353, 3, 67, 33, 0, 351,
-		353, 3, 71, 35, 0, 352, 350, 1, 0, 0, 0, 352, 351, 1, 0, 0, 0, 353, 64,
-		1, 0, 0, 0, 354, 355, 3, 67, 33, 0, 355, 356, 5, 47, 0, 0, 356, 357, 3,
-		83, 41, 0, 357, 363, 1, 0, 0, 0, 358, 359, 3, 71, 35, 0, 359, 360, 5, 47,
-		0, 0, 360, 361, 3, 83, 41, 0, 361, 363, 1, 0, 0, 0, 362, 354, 1, 0, 0,
-		0, 362, 358, 1, 0, 0, 0, 363, 66, 1, 0, 0, 0, 364, 365, 3, 69, 34, 0, 365,
-		366, 5, 46, 0, 0, 366, 367, 3, 69, 34, 0, 367, 368, 5, 46, 0, 0, 368, 369,
-		3, 69, 34, 0, 369, 370, 5, 46, 0, 0, 370, 371, 3, 69, 34, 0, 371, 68, 1,
-		0, 0, 0, 372, 373, 5, 50, 0, 0, 373, 374, 5, 53, 0, 0, 374, 375, 1, 0,
-		0, 0, 375, 386, 7, 6, 0, 0, 376, 377, 5, 50, 0, 0, 377, 378, 7, 7, 0, 0,
-		378, 386, 7, 8, 0, 0, 379, 380, 5, 49, 0, 0, 380, 381, 7, 8, 0, 0, 381,
-		386, 7, 8, 0, 0, 382, 383, 7, 9, 0, 0, 383, 386, 7, 8, 0, 0, 384, 386,
-		7, 8, 0, 0, 385, 372, 1, 0, 0, 0, 385, 376, 1, 0, 0, 0, 385, 379, 1, 0,
-		0, 0, 385, 382, 1, 0, 0, 0, 385, 384, 1, 0, 0, 0, 386, 70, 1, 0, 0, 0,
-		387, 388, 3, 73, 36, 0, 388, 389, 5, 58, 0, 0, 389, 390, 1, 0, 0, 0, 390,
-		391, 3, 73, 36, 0, 391, 392, 5, 58, 0, 0, 392, 393, 1, 0, 0, 0, 393, 394,
-		3, 73, 36, 0, 394, 395, 5, 58, 0, 0, 395, 396, 1, 0, 0, 0, 396, 397, 3,
-		73, 36, 0, 397, 398, 5, 58, 0, 0, 398, 399, 1, 0, 0, 0, 399, 400, 3, 73,
-		36, 0, 400, 401, 5, 58, 0, 0, 401, 402, 1, 0, 0, 0, 402, 405, 3, 73, 36,
-		0, 403, 404, 5, 58, 0, 0, 404, 406, 3, 73, 36, 0, 405, 403, 1, 0, 0, 0,
-		405, 406, 1, 0, 0, 0, 406, 440, 1, 0, 0, 0, 407, 408, 5, 58, 0, 0, 408,
-		409, 5, 58, 0, 0, 409, 415, 1, 0, 0, 0, 410, 411, 3, 73, 36, 0, 411, 412,
-		5, 58, 0, 0, 412, 414, 1, 0, 0, 0, 413, 410, 1, 0, 0, 0, 414, 417, 1, 0,
-		0, 0, 415, 413, 1, 0, 0, 0, 415, 416, 1, 0, 0, 0, 416, 418, 1, 0, 0, 0,
-		417, 415, 1, 0, 0, 0, 418, 440, 3, 73, 36, 0, 419, 420, 3, 73, 36, 0, 420,
-		421, 5, 58, 0, 0, 421, 423, 1, 0, 0, 0, 422, 419, 1, 0, 0, 0, 423, 426,
-		1, 0, 0, 0, 424, 422, 1, 0, 0, 0, 424, 425, 1, 0, 0, 0, 425, 427, 1, 0,
-		0, 0, 426, 424, 1, 0, 0, 0, 427, 428, 5, 58, 0, 0, 428, 429, 5, 58, 0,
-		0, 429, 435, 1, 0, 0, 0, 430, 431, 3, 73, 36, 0, 431, 432, 5, 58, 0, 0,
-		432, 434, 1, 0, 0, 0, 433, 430, 1, 0, 0, 0, 434, 437, 1, 0, 0, 0, 435,
-		433, 1, 0, 0, 0, 435, 436, 1, 0, 0, 0, 436, 438, 1, 0, 0, 0, 437, 435,
-		1, 0, 0, 0, 438, 440, 3, 73, 36, 0, 439, 387, 1, 0, 0, 0, 439, 407, 1,
-		0, 0, 0, 439, 424, 1, 0, 0, 0, 440, 72, 1, 0, 0, 0, 441, 443, 3, 79, 39,
-		0, 442, 444, 3, 79, 39, 0, 443, 442, 1, 0, 0, 0, 443, 444, 1, 0, 0, 0,
-		444, 446, 1, 0, 0, 0, 445, 447, 3, 79, 39, 0, 446, 445, 1, 0, 0, 0, 446,
-		447, 1, 0, 0, 0, 447, 449, 1, 0, 0, 0, 448, 450, 3, 79, 39, 0, 449, 448,
-		1, 0, 0, 0, 449, 450, 1, 0, 0, 0, 450, 74, 1, 0, 0, 0, 451, 454, 5, 92,
-		0, 0, 452, 455, 7, 10, 0, 0, 453, 455, 3, 77, 38, 0, 454, 452, 1, 0, 0,
-		0, 454, 453, 1, 0, 0, 0, 455, 76, 1, 0, 0, 0, 456, 457, 5, 117, 0, 0, 457,
-		458, 3, 79, 39, 0, 458, 459, 3, 79, 39, 0, 459, 460, 3, 79, 39, 0, 460,
-		461, 3, 79, 39, 0, 461, 78, 1, 0, 0, 0, 462, 463, 7, 11, 0, 0, 463, 80,
-		1, 0, 0, 0, 464, 466, 5, 45, 0, 0, 465, 464, 1, 0, 0, 0, 465, 466, 1, 0,
-		0, 0, 466, 467, 1, 0, 0, 0, 467, 468, 3, 83, 41, 0, 468, 470, 5, 46, 0,
-		0, 469, 471, 7, 8, 0, 0, 470, 469, 1, 0, 0, 0, 471, 472, 1, 0, 0, 0, 472,
-		470, 1, 0, 0, 0, 472, 473, 1, 0, 0, 0, 473, 475, 1, 0, 0, 0, 474, 476,
-		3, 85, 42, 0, 475, 474, 1, 0, 0, 0, 475, 476, 1, 0, 0, 0, 476, 82, 1, 0,
-		0, 0, 477, 486, 5, 48, 0, 0, 478, 482, 7, 9, 0, 0, 479, 481, 7, 8, 0, 0,
-		480, 479, 1, 0, 0, 0, 481, 484, 1, 0, 0, 0, 482, 480, 1, 0, 0, 0, 482,
-		483, 1, 0, 0, 0, 483, 486, 1, 0, 0, 0, 484, 482, 1, 0, 0, 0, 485, 477,
-		1, 0, 0, 0, 485, 478, 1, 0, 0, 0, 486, 84, 1, 0, 0, 0, 487, 489, 7, 12,
-		0, 0, 488, 490, 7, 13, 0, 0, 489, 488, 1, 0, 0, 0, 489, 490, 1, 0, 0, 0,
-		490, 491, 1, 0, 0, 0, 491, 492, 3, 83, 41, 0, 492, 86, 1, 0, 0, 0, 493,
-		494, 5, 10, 0, 0, 494, 88, 1, 0, 0, 0, 495, 499, 5, 44, 0, 0, 496, 498,
-		5, 32, 0, 0, 497, 496, 1, 0, 0, 0, 498, 501, 1, 0, 0, 0, 499, 497, 1, 0,
-		0, 0, 499, 500, 1, 0, 0, 0, 500, 90, 1, 0, 0, 0, 501, 499, 1, 0, 0, 0,
-		502, 506, 5, 32, 0, 0, 503, 505, 3, 87, 43, 0, 504, 503, 1, 0, 0, 0, 505,
-		508, 1, 0, 0, 0, 506, 504, 1, 0, 0, 0, 506, 507, 1, 0, 0, 0, 507, 92, 1,
-		0, 0, 0, 508, 506, 1, 0, 0, 0, 45, 0, 114, 121, 132, 143, 163, 181, 188,
-		195, 209, 223, 237, 253, 267, 289, 295, 301, 316, 318, 328, 331, 335, 338,
-		341, 346, 352, 362, 385, 405, 415, 424, 435, 439, 443, 446, 449, 454, 465,
-		472, 475, 482, 485, 489, 499, 506, 0,
+		41, 2, 42, 7, 42, 2, 43, 7, 43, 2, 44, 7, 44, 2, 45, 7, 45, 2, 46, 7, 46,
+		2, 47, 7, 47, 2, 48, 7, 48, 2, 49, 7, 49, 2, 50, 7, 50, 2, 51, 7, 51, 2,
+		52, 7, 52, 2, 53, 7, 53, 1, 0, 1, 0, 1, 1, 1, 1, 1, 2, 1, 2, 1, 2, 1, 3,
+		1, 3, 1, 4, 1, 4, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 3, 5, 128,
+		8, 5, 1, 6, 1, 6, 1, 6, 1, 6, 1, 6, 1, 6, 1, 6, 1, 6, 3, 6, 138, 8, 6,
+		1, 7, 1, 7, 1, 7, 1, 7, 1, 7, 1, 7, 3, 7, 146, 8, 7, 1, 8, 1, 8, 1, 8,
+		1, 8, 1, 8, 1, 8, 3, 8, 154, 8, 8, 1, 9, 1, 9, 1, 9, 1, 9, 1, 9, 1, 9,
+		1, 9, 1, 9, 1, 9, 3, 9, 165, 8, 9, 1, 10, 1, 10, 1, 10, 1, 10, 1, 10, 1,
+		11, 1, 11, 1, 11, 1, 11, 3, 11, 176, 8, 11, 1, 12, 1, 12, 1, 12, 1, 12,
+		3, 12, 182, 8, 12, 1, 13, 1, 13, 1, 13, 1, 13, 1, 13, 1, 13, 1, 13, 1,
+		13, 1, 13, 1, 13, 1, 13, 1, 13, 1, 13, 1, 13, 1, 13, 1, 13, 1, 13, 1, 13,
+		3, 13, 202, 8, 13, 1, 14, 1, 14, 1, 14, 1, 14, 1, 14, 1, 14, 1, 14, 1,
+		14, 1, 14, 1, 14, 1, 14, 1, 14, 1, 14, 1, 14, 1, 14, 1, 14, 3, 14, 220,
+		8, 14, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15, 3, 15, 227, 8, 15, 1, 16, 1,
+		16, 1, 16, 1, 16, 1, 16, 3, 16, 234, 8, 16, 1, 17, 1, 17, 1, 17, 1, 17,
+		1, 17, 1, 17, 1, 17, 1, 17, 1, 17, 1, 17, 1, 17, 1, 17, 3, 17, 248, 8,
+		17, 1, 18, 1, 18, 1, 18, 1, 18, 1, 18, 1, 18, 1, 18, 1, 18, 1, 18, 1, 18,
+		1, 18, 1, 18, 3, 18, 262, 8, 18, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1,
+		19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 3, 19, 276, 8, 19, 1, 20,
+		1, 20, 1, 20, 1, 20, 1, 20, 1, 20, 1, 20, 1, 20, 1, 20, 1, 20, 1, 20, 1,
+		20, 1, 20, 1, 20, 3, 20, 292, 8, 20, 1, 21, 1, 21, 1, 21, 1, 21, 1, 21,
+		1, 21, 1, 21, 1, 21, 1, 21, 1, 21, 1, 21, 1, 21, 3, 21, 306, 8, 21, 1,
+		22, 1, 22, 1, 22, 1, 22, 1, 22, 1, 22, 1, 22, 1, 22, 1, 22, 1, 22, 1, 22,
+		1, 22, 1, 22, 1, 22, 1, 22, 1, 22, 1, 22, 1, 22, 1, 22, 1, 22, 3, 22, 328,
+		8, 22, 1, 23, 1, 23, 1, 24, 1, 24, 1, 25, 1, 25, 1, 26, 1, 26, 1, 27, 1,
+		27, 1, 28, 1, 28, 1, 29, 1, 29, 5, 29, 344, 8, 29, 10, 29, 12, 29, 347,
+		9, 29, 1, 30, 1, 30, 1, 30, 3, 30, 352, 8, 30, 1, 31, 1, 31, 1, 32, 1,
+		32, 1, 33, 1, 33, 1, 33, 1, 33, 1, 33, 1, 33, 1, 34, 1, 34, 1, 34, 5, 34,
+		367, 8, 34, 10, 34, 12, 34, 370, 9, 34, 1, 34, 1, 34, 1, 35, 1, 35, 1,
+		35, 1, 35, 5, 35, 378, 8, 35, 10, 35, 12, 35, 381, 9, 35, 3, 35, 383, 8,
+		35, 1, 35, 1, 35, 3, 35, 387, 8, 35, 1, 35, 3, 35, 390, 8, 35, 1, 35, 3,
+		35, 393, 8, 35, 1, 36, 1, 36, 1, 36, 3, 36, 398, 8, 36, 1, 37, 1, 37, 1,
+		38, 1, 38, 3, 38, 404, 8, 38, 1, 39, 1, 39, 1, 39, 1, 39, 1, 39, 1, 39,
+		1, 39, 1, 39, 3, 39, 414, 8, 39, 1, 40, 1, 40, 3, 40, 418, 8, 40, 1, 40,
+		1, 40, 1, 40, 3, 40, 423, 8, 40, 1, 41, 1, 41, 1, 41, 1, 41, 1, 41, 1,
+		41, 1, 41, 1, 41, 1, 42, 1, 42, 1, 42, 1, 42, 1, 42, 1, 42, 1, 42, 1, 42,
+		1, 42, 1, 42, 1, 42, 1, 42, 1, 42, 3, 42, 446, 8, 42, 1, 43, 1, 43, 1,
+		43, 1, 43, 1, 43, 1, 43, 1, 43, 1, 43, 1, 43, 1, 43, 1, 43, 1, 43, 1, 43,
+		1, 43, 1, 43, 1, 43, 1, 43, 1, 43, 3, 43, 466, 8, 43, 1, 43, 1, 43, 1,
+		43, 1, 43, 1, 43, 1, 43, 5, 43, 474, 8, 43, 10, 43, 12, 43, 477, 9, 43,
+		1, 43, 1, 43, 1, 43, 1, 43, 5, 43, 483, 8, 43, 10, 43, 12, 43, 486, 9,
+		43, 1, 43, 1, 43, 1, 43, 1, 43, 5, 43, 492, 8, 43, 10, 43, 12, 43, 495,
+		9, 43, 1, 43, 1, 43, 1, 43, 1, 43, 4, 43, 501, 8, 43, 11, 43, 12, 43, 502,
+		1, 43, 1, 43, 1, 43, 1, 43, 1, 43, 1, 43, 1, 43, 1, 43, 1, 43, 1, 43, 1,
+		43, 1, 43, 1, 43, 1, 43, 1, 43, 1, 43, 1, 43, 1, 43, 1, 43, 1, 43, 1, 43,
+		1, 43, 1, 43, 1, 43, 1, 43, 1, 43, 1, 43, 1, 43, 1, 43, 1, 43, 5, 43, 535,
+		8, 43, 10, 43, 12, 43, 538, 9, 43, 1, 43, 1, 43, 1, 43, 1, 43, 4, 43, 544,
+		8, 43, 11, 43, 12, 43, 545, 1, 43, 1, 43, 1, 43, 1, 43, 5, 43, 552, 8,
+		43, 10, 43, 12, 43, 555, 9, 43, 1, 43, 1, 43, 3, 43, 559, 8, 43, 1, 44,
+		1, 44, 3, 44, 563, 8, 44, 1, 44, 3, 44, 566, 8, 44, 1, 44, 3, 44, 569,
+		8, 44, 1, 45, 1, 45, 1, 45, 3, 45, 574, 8, 45, 1, 46, 1, 46, 1, 46, 1,
+		46, 1, 46, 1, 46, 1, 47, 1, 47, 1, 48, 3, 48, 585, 8, 48, 1, 48, 1, 48,
+		1, 48, 4, 48, 590, 8, 48, 11, 48, 12, 48, 591, 1, 48, 3, 48, 595, 8, 48,
+		1, 49, 1, 49, 1, 49, 5, 49, 600, 8, 49, 10, 49, 12, 49, 603, 9, 49, 3,
+		49, 605, 8, 49, 1, 50, 1, 50, 3, 50, 609, 8, 50, 1, 50, 1, 50, 1, 51, 3,
+		51, 614, 8, 51, 1, 51, 1, 51, 1, 52, 1, 52, 5, 52, 620, 8, 52, 10, 52,
+		12, 52, 623, 9, 52, 1, 53, 1, 53, 4, 53, 627, 8, 53, 11, 53, 12, 53, 628,
+		0, 0, 54, 1, 1, 3, 2, 5, 3, 7, 4, 9, 5, 11, 6, 13, 7, 15, 8, 17, 9, 19,
+		10, 21, 11, 23, 12, 25, 13, 27, 14, 29, 15, 31, 16, 33, 17, 35, 18, 37,
+		19, 39, 20, 41, 21, 43, 22, 45, 23, 47, 24, 49, 25, 51, 26, 53, 27, 55,
+		28, 57, 29, 59, 30, 61, 0, 63, 0, 65, 0, 67, 31, 69, 32, 71, 33, 73, 0,
+		75, 0, 77, 34, 79, 35, 81, 36, 83, 0, 85, 0, 87, 0, 89, 0, 91, 0, 93, 0,
+		95, 0, 97, 37, 99, 38, 101, 39, 103, 0, 105, 40, 107, 41, 1, 0, 15, 2,
+		0, 45, 45, 95, 95, 2, 0, 65, 90, 97, 122, 2, 0, 34, 34, 92, 92, 2, 0, 47,
+		47, 92, 92, 10, 0, 47, 47, 66, 66, 68, 68, 83, 83, 87, 87, 92, 92, 98,
+		98, 100, 100, 115, 115, 119, 119, 3, 0, 103, 103, 105, 105, 109, 109, 1,
+		0, 48, 53, 1, 0, 48, 52, 1, 0, 48, 57, 1, 0, 49, 57, 8, 0, 34, 34, 47,
+		47, 92, 92, 98, 98, 102, 102, 110, 110, 114, 114, 116, 116, 3, 0, 48, 57,
+		65, 70, 97, 102, 2, 0, 69, 69, 101, 101, 2, 0, 43, 43, 45, 45, 2, 0, 9,
+		9, 32, 32, 704, 0, 1, 1, 0, 0, 0, 0, 3, 1, 0, 0, 0, 0, 5, 1, 0, 0, 0, 0,
+		7, 1, 0, 0, 0, 0, 9, 1, 0, 0, 0, 0, 11, 1, 0, 0, 0, 0, 13, 1, 0, 0, 0,
+		0, 15, 1, 0, 0, 0, 0, 17, 1, 0, 0, 0, 0, 19, 1, 0, 0, 0, 0, 21, 1, 0, 0,
+		0, 0, 23, 1, 0, 0, 0, 0, 25, 1, 0, 0, 0, 0, 27, 1, 0, 0, 0, 0, 29, 1, 0,
+		0, 0, 0, 31, 1, 0, 0, 0, 0, 33, 1, 0, 0, 0, 0, 35, 1, 0, 0, 0, 0, 37, 1,
+		0, 0, 0, 0, 39, 1, 0, 0, 0, 0, 41, 1, 0, 0, 0, 0, 43, 1, 0, 0, 0, 0, 45,
+		1, 0, 0, 0, 0, 47, 1, 0, 0, 0, 0, 49, 1, 0, 0, 0, 0, 51, 1, 0, 0, 0, 0,
+		53, 1, 0, 0, 0, 0, 55, 1, 0, 0, 0, 0, 57, 1, 0, 0, 0, 0, 59, 1, 0, 0, 0,
+		0, 67, 1, 0, 0, 0, 0, 69, 1, 0, 0, 0, 0, 71, 1, 0, 0, 0, 0, 77, 1, 0, 0,
+		0, 0, 79, 1, 0, 0, 0, 0, 81, 1, 0, 0, 0, 0, 97, 1, 0, 0, 0, 0, 99, 1, 0,
+		0, 0, 0, 101, 1, 0, 0, 0, 0, 105, 1, 0, 0, 0, 0, 107, 1, 0, 0, 0, 1, 109,
+		1, 0, 0, 0, 3, 111, 1, 0, 0, 0, 5, 113, 1, 0, 0, 0, 7, 116, 1, 0, 0, 0,
+		9, 118, 1, 0, 0, 0, 11, 127, 1, 0, 0, 0, 13, 137, 1, 0, 0, 0, 15, 145,
+		1, 0, 0, 0, 17, 153, 1, 0, 0, 0, 19, 164, 1, 0, 0, 0, 21, 166, 1, 0, 0,
+		0, 23, 175, 1, 0, 0, 0, 25, 181, 1, 0, 0, 0, 27, 201, 1, 0, 0, 0, 29, 219,
+		1, 0, 0, 0, 31, 226, 1, 0, 0, 0, 33, 233, 1, 0, 0, 0, 35, 247, 1, 0, 0,
+		0, 37, 261, 1, 0, 0, 0, 39, 275, 1, 0, 0, 0, 41, 291, 1, 0, 0, 0, 43, 305,
+		1, 0, 0, 0, 45, 327, 1, 0, 0, 0, 47, 329, 1, 0, 0, 0, 49, 331, 1, 0, 0,
+		0, 51, 333, 1, 0, 0, 0, 53, 335, 1, 0, 0, 0, 55, 337, 1, 0, 0, 0, 57, 339,
+		1, 0, 0, 0, 59, 341, 1, 0, 0, 0, 61, 351, 1, 0, 0, 0, 63, 353, 1, 0, 0,
+		0, 65, 355, 1, 0, 0, 0, 67, 357, 1, 0, 0, 0, 69, 363, 1, 0, 0, 0, 71, 373,
+		1, 0, 0, 0, 73, 397, 1, 0, 0, 0, 75, 399, 1, 0, 0, 0, 77, 403, 1, 0, 0,
+		0, 79, 413, 1, 0, 0, 0, 81, 417, 1, 0, 0, 0, 83, 424, 1, 0, 0, 0, 85, 445,
+		1, 0, 0, 0, 87, 558, 1, 0, 0, 0, 89, 560, 1, 0, 0, 0, 91, 570, 1, 0, 0,
+		0, 93, 575, 1, 0, 0, 0, 95, 581, 1, 0, 0, 0, 97, 584, 1, 0, 0, 0, 99, 604,
+		1, 0, 0, 0, 101, 606, 1, 0, 0, 0, 103, 613, 1, 0, 0, 0, 105, 617, 1, 0,
+		0, 0, 107, 626, 1, 0, 0, 0, 109, 110, 5, 40, 0, 0, 110, 2, 1, 0, 0, 0,
+		111, 112, 5, 41, 0, 0, 112, 4, 1, 0, 0, 0, 113, 114, 5, 112, 0, 0, 114,
+		115, 5, 114, 0, 0, 115, 6, 1, 0, 0, 0, 116, 117, 5, 91, 0, 0, 117, 8, 1,
+		0, 0, 0, 118, 119, 5, 93, 0, 0, 119, 10, 1, 0, 0, 0, 120, 121, 5, 110,
+		0, 0, 121, 122, 5, 111, 0, 0, 122, 128, 5, 116, 0, 0, 123, 124, 5, 78,
+		0, 0, 124, 125, 5, 79, 0, 0, 125, 128, 5, 84, 0, 0, 126, 128, 5, 33, 0,
+		0, 127, 120, 1, 0, 0, 0, 127, 123, 1, 0, 0, 0, 127, 126, 1, 0, 0, 0, 128,
+		12, 1, 0, 0, 0, 129, 130, 5, 97, 0, 0, 130, 131, 5, 110, 0, 0, 131, 138,
+		5, 100, 0, 0, 132, 133, 5, 65, 0, 0, 133, 134, 5, 78, 0, 0, 134, 138, 5,
+		68, 0, 0, 135, 136, 5, 38, 0, 0, 136, 138, 5, 38, 0, 0, 137, 129, 1, 0,
+		0, 0, 137, 132, 1, 0, 0, 0, 137, 135, 1, 0, 0, 0, 138, 14, 1, 0, 0, 0,
+		139, 140, 5, 120, 0, 0, 140, 141, 5, 111, 0, 0, 141, 146, 5, 114, 0, 0,
+		142, 143, 5, 88, 0, 0, 143, 144, 5, 79, 0, 0, 144, 146, 5, 82, 0, 0, 145,
+		139, 1, 0, 0, 0, 145, 142, 1, 0, 0, 0, 146, 16, 1, 0, 0, 0, 147, 148, 5,
+		111, 0, 0, 148, 154, 5, 114, 0, 0, 149, 150, 5, 79, 0, 0, 150, 154, 5,
+		82, 0, 0, 151, 152, 5, 124, 0, 0, 152, 154, 5, 124, 0, 0, 153, 147, 1,
+		0, 0, 0, 153, 149, 1, 0, 0, 0, 153, 151, 1, 0, 0, 0, 154, 18, 1, 0, 0,
+		0, 155, 156, 5, 116, 0, 0, 156, 157, 5, 114, 0, 0, 157, 158, 5, 117, 0,
+		0, 158, 165, 5, 101, 0, 0, 159, 160, 5, 102, 0, 0, 160, 161, 5, 97, 0,
+		0, 161, 162, 5, 108, 0, 0, 162, 163, 5, 115, 0, 0, 163, 165, 5, 101, 0,
+		0, 164, 155, 1, 0, 0, 0, 164, 159, 1, 0, 0, 0, 165, 20, 1, 0, 0, 0, 166,
+		167, 5, 110, 0, 0, 167, 168, 5, 117, 0, 0, 168, 169, 5, 108, 0, 0, 169,
+		170, 5, 108, 0, 0, 170, 22, 1, 0, 0, 0, 171, 172, 5, 73, 0, 0, 172, 176,
+		5, 78, 0, 0, 173, 174, 5, 105, 0, 0, 174, 176, 5, 110, 0, 0, 175, 171,
+		1, 0, 0, 0, 175, 173, 1, 0, 0, 0, 176, 24, 1, 0, 0, 0, 177, 178, 5, 73,
+		0, 0, 178, 182, 5, 83, 0, 0, 179, 180, 5, 105, 0, 0, 180, 182, 5, 115,
+		0, 0, 181, 177, 1, 0, 0, 0, 181, 179, 1, 0, 0, 0, 182, 26, 1, 0, 0, 0,
+		183, 184, 5, 101, 0, 0, 184, 202, 5, 113, 0, 0, 185, 186, 5, 69, 0, 0,
+		186, 202, 5, 81, 0, 0, 187, 188, 5, 101, 0, 0, 188, 189, 5, 113, 0, 0,
+		189, 190, 5, 117, 0, 0, 190, 191, 5, 97, 0, 0, 191, 192, 5, 108, 0, 0,
+		192, 202, 5, 115, 0, 0, 193, 194, 5, 69, 0, 0, 194, 195, 5, 81, 0, 0, 195,
+		196, 5, 85, 0, 0, 196, 197, 5, 65, 0, 0, 197, 198, 5, 76, 0, 0, 198, 202,
+		5, 83, 0, 0, 199, 200, 5, 61, 0, 0, 200, 202, 5, 61, 0, 0, 201, 183, 1,
+		0, 0, 0, 201, 185, 1, 0, 0, 0, 201, 187, 1, 0, 0, 0, 201, 193, 1, 0, 0,
+		0, 201, 199, 1, 0, 0, 0, 202, 28, 1, 0, 0, 0, 203, 204, 5, 110, 0, 0, 204,
+		220, 5, 101, 0, 0, 205, 206, 5, 78, 0, 0, 206, 220, 5, 69, 0, 0, 207, 208,
+		5, 110, 0, 0, 208, 209, 5, 111, 0, 0, 209, 210, 5, 116, 0, 0, 210, 211,
+		5, 101, 0, 0, 211, 220, 5, 113, 0, 0, 212, 213, 5, 78, 0, 0, 213, 214,
+		5, 79, 0, 0, 214, 215, 5, 84, 0, 0, 215, 216, 5, 69, 0, 0, 216, 220, 5,
+		81, 0, 0, 217, 218, 5, 33, 0, 0, 218, 220, 5, 61, 0, 0, 219, 203, 1, 0,
+		0, 0, 219, 205, 1, 0, 0, 0, 219, 207, 1, 0, 0, 0, 219, 212, 1, 0, 0, 0,
+		219, 217, 1, 0, 0, 0, 220, 30, 1, 0, 0, 0, 221, 222, 5, 103, 0, 0, 222,
+		227, 5, 116, 0, 0, 223, 224, 5, 71, 0, 0, 224, 227, 5, 84, 0, 0, 225, 227,
+		5, 62, 0, 0, 226, 221, 1, 0, 0, 0, 226, 223, 1, 0, 0, 0, 226, 225, 1, 0,
+		0, 0, 227, 32, 1, 0, 0, 0, 228, 229, 5, 108, 0, 0, 229, 234, 5, 116, 0,
+		0, 230, 231, 5, 76, 0, 0, 231, 234, 5, 84, 0, 0, 232, 234, 5, 60, 0, 0,
+		233, 228, 1, 0, 0, 0, 233, 230, 1, 0, 0, 0, 233, 232, 1, 0, 0, 0, 234,
+		34, 1, 0, 0, 0, 235, 236, 5, 103, 0, 0, 236, 248, 5, 101, 0, 0, 237, 238,
+		5, 71, 0, 0, 238, 248, 5, 69, 0, 0, 239, 240, 5, 103, 0, 0, 240, 241, 5,
+		116, 0, 0, 241, 248, 5, 101, 0, 0, 242, 243, 5, 71, 0, 0, 243, 244, 5,
+		84, 0, 0, 244, 248, 5, 69, 0, 0, 245, 246, 5, 62, 0, 0, 246, 248, 5, 61,
+		0, 0, 247, 235, 1, 0, 0, 0, 247, 237, 1, 0, 0, 0, 247, 239, 1, 0, 0, 0,
+		247, 242, 1, 0, 0, 0, 247, 245, 1, 0, 0, 0, 248, 36, 1, 0, 0, 0, 249, 250,
+		5, 108, 0, 0, 250, 262, 5, 101, 0, 0, 251, 252, 5, 76, 0, 0, 252, 262,
+		5, 69, 0, 0, 253, 254, 5, 108, 0, 0, 254, 255, 5, 116, 0, 0, 255, 262,
+		5, 101, 0, 0, 256, 257, 5, 76, 0, 0, 257, 258, 5, 84, 0, 0, 258, 262, 5,
+		69, 0, 0, 259, 260, 5, 60, 0, 0, 260, 262, 5, 61, 0, 0, 261, 249, 1, 0,
+		0, 0, 261, 251, 1, 0, 0, 0, 261, 253, 1, 0, 0, 0, 261, 256, 1, 0, 0, 0,
+		261, 259, 1, 0, 0, 0, 262, 38, 1, 0, 0, 0, 263, 264, 5, 99, 0, 0, 264,
+		276, 5, 111, 0, 0, 265, 266, 5, 67, 0, 0, 266, 276, 5, 79, 0, 0, 267, 268,
+		5, 99, 0, 0, 268, 269, 5, 111, 0, 0, 269, 270, 5, 110, 0, 0, 270, 271,
+		5, 116, 0, 0, 271, 272, 5, 97, 0, 0, 272, 273, 5, 105, 0, 0, 273, 274,
+		5, 110, 0, 0, 274, 276, 5, 115, 0, 0, 275, 263, 1, 0, 0, 0, 275, 265, 1,
+		0, 0, 0, 275, 267, 1, 0, 0, 0, 276, 40, 1, 0, 0, 0, 277, 278, 5, 115, 0,
+		0, 278, 292, 5, 119, 0, 0, 279, 280, 5, 83, 0, 0, 280, 292, 5, 87, 0, 0,
+		281, 282, 5, 115, 0, 0, 282, 283, 5, 116, 0, 0, 283, 284, 5, 97, 0, 0,
+		284, 285, 5, 114, 0, 0, 285, 286, 5, 116, 0, 0, 286, 287, 5, 115, 0, 0,
+		287, 288, 5, 87, 0, 0, 288, 289, 5, 105, 0, 0, 289, 290, 5, 116, 0, 0,
+		290, 292, 5, 104, 0, 0, 291, 277, 1, 0, 0, 0, 291, 279, 1, 0, 0, 0, 291,
+		281, 1, 0, 0, 0, 292, 42, 1, 0, 0, 0, 293, 294, 5, 101, 0, 0, 294, 306,
+		5, 119, 0, 0, 295, 296, 5, 69, 0, 0, 296, 306, 5, 87, 0, 0, 297, 298, 5,
+		101, 0, 0, 298, 299, 5, 110, 0, 0, 299, 300, 5, 100, 0, 0, 300, 301, 5,
+		115, 0, 0, 301, 302, 5, 87, 0, 0, 302, 303, 5, 105, 0, 0, 303, 304, 5,
+		116, 0, 0, 304, 306, 5, 104, 0, 0, 305, 293, 1, 0, 0, 0, 305, 295, 1, 0,
+		0, 0, 305, 297, 1, 0, 0, 0, 306, 44, 1, 0, 0, 0, 307, 308, 5, 109, 0, 0,
+		308, 328, 5, 116, 0, 0, 309, 310, 5, 77, 0, 0, 310, 328, 5, 84, 0, 0, 311,
+		312, 5, 109, 0, 0, 312, 313, 5, 97, 0, 0, 313, 314, 5, 116, 0, 0, 314,
+		315, 5, 99, 0, 0, 315, 316, 5, 104, 0, 0, 316, 317, 5, 101, 0, 0, 317,
+		328, 5, 115, 0, 0, 318, 319, 5, 77, 0, 0, 319, 320, 5, 65, 0, 0, 320, 321,
+		5, 84, 0, 0, 321, 322, 5, 67, 0, 0, 322, 323, 5, 72, 0, 0, 323, 324, 5,
+		69, 0, 0, 324, 328, 5, 83, 0, 0, 325, 326, 5, 126, 0, 0, 326, 328, 5, 61,
+		0, 0, 327, 307, 1, 0, 0, 0, 327, 309, 1, 0, 0, 0, 327, 311, 1, 0, 0, 0,
+		327, 318, 1, 0, 0, 0, 327, 325, 1, 0, 0, 0, 328, 46, 1, 0, 0, 0, 329, 330,
+		5, 43, 0, 0, 330, 48, 1, 0, 0, 0, 331, 332, 5, 45, 0, 0, 332, 50, 1, 0,
+		0, 0, 333, 334, 5, 42, 0, 0, 334, 52, 1, 0, 0, 0, 335, 336, 5, 47, 0, 0,
+		336, 54, 1, 0, 0, 0, 337, 338, 5, 37, 0, 0, 338, 56, 1, 0, 0, 0, 339, 340,
+		5, 46, 0, 0, 340, 58, 1, 0, 0, 0, 341, 345, 3, 65, 32, 0, 342, 344, 3,
+		61, 30, 0, 343, 342, 1, 0, 0, 0, 344, 347, 1, 0, 0, 0, 345, 343, 1, 0,
+		0, 0, 345, 346, 1, 0, 0, 0, 346, 60, 1, 0, 0, 0, 347, 345, 1, 0, 0, 0,
+		348, 352, 7, 0, 0, 0, 349, 352, 3, 63, 31, 0, 350, 352, 3, 65, 32, 0, 351,
+		348, 1, 0, 0, 0, 351, 349, 1, 0, 0, 0, 351, 350, 1, 0, 0, 0, 352, 62, 1,
+		0, 0, 0, 353, 354, 2, 48, 57, 0, 354, 64, 1, 0, 0, 0, 355, 356, 7, 1, 0,
+		0, 356, 66, 1, 0, 0, 0, 357, 358, 3, 99, 49, 0, 358, 359, 5, 46, 0, 0,
+		359, 360, 3, 99, 49, 0, 360, 361, 5, 46, 0, 0, 361, 362, 3, 99, 49, 0,
+		362, 68, 1, 0, 0, 0, 363, 368, 5, 34, 0, 0, 364, 367, 3, 91, 45, 0, 365,
+		367, 8, 2, 0, 0, 366, 364, 1, 0, 0, 0, 366, 365, 1, 0, 0, 0, 367, 370,
+		1, 0, 0, 0, 368, 366, 1, 0, 0, 0, 368, 369, 1, 0, 0, 0, 369, 371, 1, 0,
+		0, 0, 370, 368, 1, 0, 0, 0, 371, 372, 5, 34, 0, 0, 372, 70, 1, 0, 0, 0,
+		373, 374, 5, 47, 0, 0, 374, 382, 4, 35, 0, 0, 375, 383, 3, 73, 36, 0, 376,
+		378, 8, 3, 0, 0, 377, 376, 1, 0, 0, 0, 378, 381, 1, 0, 0, 0, 379, 377,
+		1, 0, 0, 0, 379, 380, 1, 0, 0, 0, 380, 383, 1, 0, 0, 0, 381, 379, 1, 0,
+		0, 0, 382, 375, 1, 0, 0, 0, 382, 379, 1, 0, 0, 0, 383, 384, 1, 0, 0, 0,
+		384, 386, 5, 47, 0, 0, 385, 387, 3, 75, 37, 0, 386, 385, 1, 0, 0, 0, 386,
+		387, 1, 0, 0, 0, 387, 389, 1, 0, 0, 0, 388, 390, 3, 75, 37, 0, 389, 388,
+		1, 0, 0, 0, 389, 390, 1, 0, 0, 0, 390, 392, 1, 0, 0, 0, 391, 393, 3, 75,
+		37, 0, 392, 391, 1, 0, 0, 0, 392, 393, 1, 0, 0, 0, 393, 72, 1, 0, 0, 0,
+		394, 398, 3, 91, 45, 0, 395, 396, 5, 92, 0, 0, 396, 398, 7, 4, 0, 0, 397,
+		394, 1, 0, 0, 0, 397, 395, 1, 0, 0, 0, 398, 74, 1, 0, 0, 0, 399, 400, 7,
+		5, 0, 0, 400, 76, 1, 0, 0, 0, 401, 404, 3, 83, 41, 0, 402, 404, 3, 87,
+		43, 0, 403, 401, 1, 0, 0, 0, 403, 402, 1, 0, 0, 0, 404, 78, 1, 0, 0, 0,
+		405, 406, 3, 83, 41, 0, 406, 407, 5, 47, 0, 0, 407, 408, 3, 99, 49, 0,
+		408, 414, 1, 0, 0, 0, 409, 410, 3, 87, 43, 0, 410, 411, 5, 47, 0, 0, 411,
+		412, 3, 99, 49, 0, 412, 414, 1, 0, 0, 0, 413, 405, 1, 0, 0, 0, 413, 409,
+		1, 0, 0, 0, 414, 80, 1, 0, 0, 0, 415, 418, 3, 83, 41, 0, 416, 418, 3, 87,
+		43, 0, 417, 415, 1, 0, 0, 0, 417, 416, 1, 0, 0, 0, 418, 419, 1, 0, 0, 0,
+		419, 422, 5, 45, 0, 0, 420, 423, 3, 83, 41, 0, 421, 423, 3, 87, 43, 0,
+		422, 420, 1, 0, 0, 0, 422, 421, 1, 0, 0, 0, 423, 82, 1, 0, 0, 0, 424, 425,
+		3, 85, 42, 0, 425, 426, 5, 46, 0, 0, 426, 427, 3, 85, 42, 0, 427, 428,
+		5, 46, 0, 0, 428, 429, 3, 85, 42, 0, 429, 430, 5, 46, 0, 0, 430, 431, 3,
+		85, 42, 0, 431, 84, 1, 0, 0, 0, 432, 433, 5, 50, 0, 0, 433, 434, 5, 53,
+		0, 0, 434, 435, 1, 0, 0, 0, 435, 446, 7, 6, 0, 0, 436, 437, 5, 50, 0, 0,
+		437, 438, 7, 7, 0, 0, 438, 446, 7, 8, 0, 0, 439, 440, 5, 49, 0, 0, 440,
+		441, 7, 8, 0, 0, 441, 446, 7, 8, 0, 0, 442, 443, 7, 9, 0, 0, 443, 446,
+		7, 8, 0, 0, 444, 446, 7, 8, 0, 0, 445, 432, 1, 0, 0, 0, 445, 436, 1, 0,
+		0, 0, 445, 439, 1, 0, 0, 0, 445, 442, 1, 0, 0, 0, 445, 444, 1, 0, 0, 0,
+		446, 86, 1, 0, 0, 0, 447, 448, 3, 89, 44, 0, 448, 449, 5, 58, 0, 0, 449,
+		450, 1, 0, 0, 0, 450, 451, 3, 89, 44, 0, 451, 452, 5, 58, 0, 0, 452, 453,
+		1, 0, 0, 0, 453, 454, 3, 89, 44, 0, 454, 455, 5, 58, 0, 0, 455, 456, 1,
+		0, 0, 0, 456, 457, 3, 89, 44, 0, 457, 458, 5, 58, 0, 0, 458, 459, 1, 0,
+		0, 0, 459, 460, 3, 89, 44, 0, 460, 461, 5, 58, 0, 0, 461, 462, 1, 0, 0,
+		0, 462, 465, 3, 89, 44, 0, 463, 464, 5, 58, 0, 0, 464, 466, 3, 89, 44,
+		0, 465, 463, 1, 0, 0, 0, 465, 466, 1, 0, 0, 0, 466, 559, 1, 0, 0, 0, 467,
+		468, 5, 58, 0, 0, 468, 469, 5, 58, 0, 0, 469, 475, 1, 0, 0, 0, 470, 471,
+		3, 89, 44, 0, 471, 472, 5, 58, 0, 0, 472, 474, 1, 0, 0, 0, 473, 470, 1,
+		0, 0, 0, 474, 477, 1, 0, 0, 0, 475, 473, 1, 0, 0, 0, 475, 476, 1, 0, 0,
+		0, 476, 478, 1, 0, 0, 0, 477, 475, 1, 0, 0, 0, 478, 559, 3, 89, 44, 0,
+		479, 480, 3, 89, 44, 0, 480, 481, 5, 58, 0, 0, 481, 483, 1, 0, 0, 0, 482,
+		479, 1, 0, 0, 0, 483, 486, 1, 0, 0, 0, 484, 482, 1, 0, 0, 0, 484, 485,
+		1, 0, 0, 0, 485, 487, 1, 0, 0, 0, 486, 484, 1, 0, 0, 0, 487, 493, 5, 58,
+		0, 0, 488, 489, 3, 89, 44, 0, 489, 490, 5, 58, 0, 0, 490, 492, 1, 0, 0,
+		0, 491, 488, 1, 0, 0, 0, 492, 495, 1, 0, 0, 0, 493, 491, 1, 0, 0, 0, 493,
+		494, 1, 0, 0, 0, 494, 496, 1, 0, 0, 0, 495, 493, 1, 0, 0, 0, 496, 559,
+		3, 89, 44, 0, 497, 498, 3, 89, 44, 0, 498, 499, 5, 58, 0, 0, 499, 501,
+		1, 0, 0, 0, 500, 497, 1, 0, 0, 0, 501, 502, 1, 0, 0, 0, 502, 500, 1, 0,
+		0, 0, 502, 503, 1, 0, 0, 0, 503, 504, 1, 0, 0, 0, 504, 505, 5, 58, 0, 0,
+		505, 559, 1, 0, 0, 0, 506, 507, 5, 58, 0, 0, 507, 559, 5, 58, 0, 0, 508,
+		509, 3, 89, 44, 0, 509, 510, 5, 58, 0, 0, 510, 511, 1, 0, 0, 0, 511, 512,
+		3, 89, 44, 0, 512, 513, 5, 58, 0, 0, 513, 514, 1, 0, 0, 0, 514, 515, 3,
+		89, 44, 0, 515, 516, 5, 58, 0, 0, 516, 517, 1, 0, 0, 0, 517, 518, 3, 89,
+		44, 0, 518, 519, 5, 58, 0, 0, 519, 520, 1, 0, 0, 0, 520, 521, 3, 89, 44,
+		0, 521, 522, 5, 58, 0, 0, 522, 523, 1, 0, 0, 0, 523, 524, 3, 89, 44, 0,
+		524, 525, 5, 58, 0, 0, 525, 526, 1, 0, 0, 0, 526, 527, 3, 83, 41, 0, 527,
+		559, 1, 0, 0, 0, 528, 529, 5, 58, 0, 0, 529, 530, 5, 58, 0, 0, 530, 536,
+		1, 0, 0, 0, 531, 532, 3, 89, 44, 0, 532, 533, 5, 58, 0, 0, 533, 535, 1,
+		0, 0, 0, 534, 531, 1, 0, 0, 0, 535, 538, 1, 0, 0, 0, 536, 534, 1, 0, 0,
+		0, 536, 537, 1, 0, 0, 0, 537, 539, 1, 0, 0, 0, 538, 536, 1, 0, 0, 0, 539,
+		559, 3, 83, 41, 0, 540, 541, 3, 89, 44, 0, 541, 542, 5, 58, 0, 0, 542,
+		544, 1, 0, 0, 0, 543, 540, 1, 0, 0, 0, 544, 545, 1, 0, 0, 0, 545, 543,
+		1, 0, 0, 0, 545, 546, 1, 0, 0, 0, 546, 547, 1, 0, 0, 0, 547, 553, 5, 58,
+		0, 0, 548, 549, 3, 89, 44, 0, 549, 550, 5, 58, 0, 0, 550, 552, 1, 0, 0,
+		0, 551, 548, 1, 0, 0, 0, 552, 555, 1, 0, 0, 0, 553, 551, 1, 0, 0, 0, 553,
+		554, 1, 0, 0, 0, 554, 556, 1, 0, 0, 0, 555, 553, 1, 0, 0, 0, 556, 557,
+		3, 83, 41, 0, 557, 559, 1, 0, 0, 0, 558, 447, 1, 0, 0, 0, 558, 467, 1,
+		0, 0, 0, 558, 484, 1, 0, 0, 0, 558, 500, 1, 0, 0, 0, 558, 506, 1, 0, 0,
+		0, 558, 508, 1, 0, 0, 0, 558, 528, 1, 0, 0, 0, 558, 543, 1, 0, 0, 0, 559,
+		88, 1, 0, 0, 0, 560, 562, 3, 95, 47, 0, 561, 563, 3, 95, 47, 0, 562, 561,
+		1, 0, 0, 0, 562, 563, 1, 0, 0, 0, 563, 565, 1, 0, 0, 0, 564, 566, 3, 95,
+		47, 0, 565, 564, 1, 0, 0, 0, 565, 566, 1, 0, 0, 0, 566, 568, 1, 0, 0, 0,
+		567, 569, 3, 95, 47, 0, 568, 567, 1, 0, 0, 0, 568, 569, 1, 0, 0, 0, 569,
+		90, 1, 0, 0, 0, 570, 573, 5, 92, 0, 0, 571, 574, 7, 10, 0, 0, 572, 574,
+		3, 93, 46, 0, 573, 571, 1, 0, 0, 0, 573, 572, 1, 0, 0, 0, 574, 92, 1, 0,
+		0, 0, 575, 576, 5, 117, 0, 0, 576, 577, 3, 95, 47, 0, 577, 578, 3, 95,
+		47, 0, 578, 579, 3, 95, 47, 0, 579, 580, 3, 95, 47, 0, 580, 94, 1, 0, 0,
+		0, 581, 582, 7, 11, 0, 0, 582, 96, 1, 0, 0, 0, 583, 585, 5, 45, 0, 0, 584,
+		583, 1, 0, 0, 0, 584, 585, 1, 0, 0, 0, 585, 586, 1, 0, 0, 0, 586, 587,
+		3, 99, 49, 0, 587, 589, 5, 46, 0, 0, 588, 590, 7, 8, 0, 0, 589, 588, 1,
+		0, 0, 0, 590, 591, 1, 0, 0, 0, 591, 589, 1, 0, 0, 0, 591, 592, 1, 0, 0,
+		0, 592, 594, 1, 0, 0, 0, 593, 595, 3, 101, 50, 0, 594, 593, 1, 0, 0, 0,
+		594, 595, 1, 0, 0, 0, 595, 98, 1, 0, 0, 0, 596, 605, 5, 48, 0, 0, 597,
+		601, 7, 9, 0, 0, 598, 600, 7, 8, 0, 0, 599, 598, 1, 0, 0, 0, 600, 603,
+		1, 0, 0, 0, 601, 599, 1, 0, 0, 0, 601, 602, 1, 0, 0, 0, 602, 605, 1, 0,
+		0, 0, 603, 601, 1, 0, 0, 0, 604, 596, 1, 0, 0, 0, 604, 597, 1, 0, 0, 0,
+		605, 100, 1, 0, 0, 0, 606, 608, 7, 12, 0, 0, 607, 609, 7, 13, 0, 0, 608,
+		607, 1, 0, 0, 0, 608, 609, 1, 0, 0, 0, 609, 610, 1, 0, 0, 0, 610, 611,
+		3, 99, 49, 0, 611, 102, 1, 0, 0, 0, 612, 614, 5, 13, 0, 0, 613, 612, 1,
+		0, 0, 0, 613, 614, 1, 0, 0, 0, 614, 615, 1, 0, 0, 0, 615, 616, 5, 10, 0,
+		0, 616, 104, 1, 0, 0, 0, 617, 621, 5, 44, 0, 0, 618, 620, 5, 32, 0, 0,
+		619, 618, 1, 0, 0, 0, 620, 623, 1, 0, 0, 0, 621, 619, 1, 0, 0, 0, 621,
+		622, 1, 0, 0, 0, 622, 106, 1, 0, 0, 0, 623, 621, 1, 0, 0, 0, 624, 627,
+		7, 14, 0, 0, 625, 627, 3, 103, 51, 0, 626, 624, 1, 0, 0, 0, 626, 625, 1,
+		0, 0, 0, 627, 628, 1, 0, 0, 0, 628, 626, 1, 0, 0, 0, 628, 629, 1, 0, 0,
+		0, 629, 108, 1, 0, 0, 0, 56, 0, 127, 137, 145, 153, 164, 175, 181, 201,
+		219, 226, 233, 247, 261, 275, 291, 305, 327, 345, 351, 366, 368, 379, 382,
+		386, 389, 392, 397, 403, 413, 417, 422, 445, 465, 475, 484, 493, 502, 536,
+		545, 553, 558, 562, 565, 568, 573, 584, 591, 594, 601, 604, 608, 613, 621,
+		626, 628, 0,
 	}
 	deserializer := antlr.NewATNDeserializer(nil)
 	staticData.atn = deserializer.Deserialize(staticData.serializedATN)
@@ -344,38 +404,65 @@ func NewJsonQueryLexer(input antlr.CharStream) *JsonQueryLexer {
 
 // JsonQueryLexer tokens.
 const (
-	JsonQueryLexerT__0             = 1
-	JsonQueryLexerT__1             = 2
-	JsonQueryLexerT__2             = 3
-	JsonQueryLexerT__3             = 4
-	JsonQueryLexerT__4             = 5
-	JsonQueryLexerT__5             = 6
-	JsonQueryLexerT__6             = 7
-	JsonQueryLexerNOT              = 8
-	JsonQueryLexerLOGICAL_OPERATOR = 9
-	JsonQueryLexerBOOLEAN          = 10
-	JsonQueryLexerNULL             = 11
-	JsonQueryLexerIN               = 12
-	JsonQueryLexerEQ               = 13
-	JsonQueryLexerNE               = 14
-	JsonQueryLexerGT               = 15
-	JsonQueryLexerLT               = 16
-	JsonQueryLexerGE               = 17
-	JsonQueryLexerLE               = 18
-	JsonQueryLexerCO               = 19
-	JsonQueryLexerSW               = 20
-	JsonQueryLexerEW               = 21
-	JsonQueryLexerMT               = 22
-	JsonQueryLexerATTRNAME         = 23
-	JsonQueryLexerVERSION          = 24
-	JsonQueryLexerSTRING           = 25
-	JsonQueryLexerREGEX            = 26
-	JsonQueryLexerIP_ADDRESS       = 27
-	JsonQueryLexerIP_CIDR          = 28
-	JsonQueryLexerDOUBLE           = 29
-	JsonQueryLexerINT              = 30
-	JsonQueryLexerEXP              = 31
-	JsonQueryLexerNEWLINE          = 32
-	JsonQueryLexerCOMMA            = 33
-	JsonQueryLexerSP               = 34
+	JsonQueryLexerT__0       = 1
+	JsonQueryLexerT__1       = 2
+	JsonQueryLexerT__2       = 3
+	JsonQueryLexerT__3       = 4
+	JsonQueryLexerT__4       = 5
+	JsonQueryLexerNOT        = 6
+	JsonQueryLexerAND        = 7
+	JsonQueryLexerXOR        = 8
+	JsonQueryLexerOR         = 9
+	JsonQueryLexerBOOLEAN    = 10
+	JsonQueryLexerNULL       = 11
+	JsonQueryLexerIN         = 12
+	JsonQueryLexerIS         = 13
+	JsonQueryLexerEQ         = 14
+	JsonQueryLexerNE         = 15
+	JsonQueryLexerGT         = 16
+	JsonQueryLexerLT         = 17
+	JsonQueryLexerGE         = 18
+	JsonQueryLexerLE         = 19
+	JsonQueryLexerCO         = 20
+	JsonQueryLexerSW         = 21
+	JsonQueryLexerEW         = 22
+	JsonQueryLexerMT         = 23
+	JsonQueryLexerPLUS       = 24
+	JsonQueryLexerMINUS      = 25
+	JsonQueryLexerSTAR       = 26
+	JsonQueryLexerSLASH      = 27
+	JsonQueryLexerPERCENT    = 28
+	JsonQueryLexerJSON_SEP   = 29
+	JsonQueryLexerATTRNAME   = 30
+	JsonQueryLexerVERSION    = 31
+	JsonQueryLexerSTRING     = 32
+	JsonQueryLexerREGEX      = 33
+	JsonQueryLexerIP_ADDRESS = 34
+	JsonQueryLexerIP_CIDR    = 35
+	JsonQueryLexerIP_RANGE   = 36
+	JsonQueryLexerDOUBLE     = 37
+	JsonQueryLexerINT        = 38
+	JsonQueryLexerEXP        = 39
+	JsonQueryLexerCOMMA      = 40
+	JsonQueryLexerSP         = 41
 )
+
+func (l *JsonQueryLexer) Sempred(localctx antlr.RuleContext, ruleIndex, predIndex int) bool {
+	switch ruleIndex {
+	case 35:
+		return l.REGEX_Sempred(localctx, predIndex)
+
+	default:
+		panic("No registered predicate for: " + fmt.Sprint(ruleIndex))
+	}
+}
+
+func (l *JsonQueryLexer) REGEX_Sempred(localctx antlr.RuleContext, predIndex int) bool {
+	switch predIndex {
+	case 0:
+		return l.regexAllowed()
+
+	default:
+		panic("No predicate with index: " + fmt.Sprint(predIndex))
+	}
+}
@@ -33,93 +33,173 @@ var JsonQueryParserStaticData struct {
 func jsonqueryParserInit() {
 	staticData := &JsonQueryParserStaticData
 	staticData.LiteralNames = []string{
-		"", "'('", "')'", "'pr'", "'.'", "'-'", "'['", "']'", "", "", "", "'null'",
-		"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "",
-		"", "", "", "'\\n'",
+		"", "'('", "')'", "'pr'", "'['", "']'", "", "", "", "", "", "'null'",
+		"", "", "", "", "", "", "", "", "", "", "", "", "'+'", "'-'", "'*'",
+		"'/'", "'%'", "'.'",
 	}
 	staticData.SymbolicNames = []string{
-		"", "", "", "", "", "", "", "", "NOT", "LOGICAL_OPERATOR", "BOOLEAN",
-		"NULL", "IN", "EQ", "NE", "GT", "LT", "GE", "LE", "CO", "SW", "EW",
-		"MT", "ATTRNAME", "VERSION", "STRING", "REGEX", "IP_ADDRESS", "IP_CIDR",
-		"DOUBLE", "INT", "EXP", "NEWLINE", "COMMA", "SP",
+		"", "", "", "", "", "", "NOT", "AND", "XOR", "OR", "BOOLEAN", "NULL",
+		"IN", "IS", "EQ", "NE", "GT", "LT", "GE", "LE", "CO", "SW", "EW", "MT",
+		"PLUS", "MINUS", "STAR", "SLASH", "PERCENT", "JSON_SEP", "ATTRNAME",
+		"VERSION", "STRING", "REGEX", "IP_ADDRESS", "IP_CIDR", "IP_RANGE", "DOUBLE",
+		"INT", "EXP", "COMMA", "SP",
 	}
 	staticData.RuleNames = []string{
-		"query", "attrPath", "valueAttrPath", "subAttr", "valueSubAttr", "value",
-		"regexValue", "ipValue", "listIPs", "subListOfIPs", "listStrings", "subListOfStrings",
-		"listDoubles", "subListOfDoubles", "listInts", "subListOfInts",
+		"root", "query", "arith", "attrPath", "valueAttrPath", "subAttr", "value",
+		"regexValue", "ipClass", "ipValue", "listIPs", "subListOfIPs", "listStrings",
+		"subListOfStrings", "listDoubles", "subListOfDoubles", "listInts", "subListOfInts",
 	}
 	staticData.PredictionContextCache = antlr.NewPredictionContextCache()
 	staticData.serializedATN = []int32{
-		4, 1, 34, 164, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2, 4, 7,
+		4, 1, 41, 326, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2, 4, 7,
 		4, 2, 5, 7, 5, 2, 6, 7, 6, 2, 7, 7, 7, 2, 8, 7, 8, 2, 9, 7, 9, 2, 10, 7,
 		10, 2, 11, 7, 11, 2, 12, 7, 12, 2, 13, 7, 13, 2, 14, 7, 14, 2, 15, 7, 15,
-		1, 0, 1, 0, 3, 0, 35, 8, 0, 1, 0, 3, 0, 38, 8, 0, 1, 0, 1, 0, 3, 0, 42,
-		8, 0, 1, 0, 1, 0, 3, 0, 46, 8, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1,
-		0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1,
-		0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 3, 0, 72, 8, 0, 1, 0, 1, 0, 1, 0, 1, 0,
-		1, 0, 5, 0, 79, 8, 0, 10, 0, 12, 0, 82, 9, 0, 1, 1, 1, 1, 3, 1, 86, 8,
-		1, 1, 2, 1, 2, 3, 2, 90, 8, 2, 1, 3, 1, 3, 1, 3, 1, 4, 1, 4, 1, 4, 1, 5,
-		1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 3, 5, 104, 8, 5, 1, 5, 1, 5, 3, 5, 108, 8,
-		5, 1, 5, 1, 5, 1, 5, 1, 5, 3, 5, 114, 8, 5, 1, 6, 1, 6, 3, 6, 118, 8, 6,
-		1, 7, 1, 7, 1, 8, 1, 8, 1, 8, 1, 9, 1, 9, 1, 9, 1, 9, 1, 9, 1, 9, 1, 9,
-		3, 9, 132, 8, 9, 1, 10, 1, 10, 1, 10, 1, 11, 1, 11, 1, 11, 1, 11, 1, 11,
-		3, 11, 142, 8, 11, 1, 12, 1, 12, 1, 12, 1, 13, 1, 13, 1, 13, 1, 13, 1,
-		13, 3, 13, 152, 8, 13, 1, 14, 1, 14, 1, 14, 1, 15, 1, 15, 1, 15, 1, 15,
-		1, 15, 3, 15, 162, 8, 15, 1, 15, 0, 1, 0, 16, 0, 2, 4, 6, 8, 10, 12, 14,
-		16, 18, 20, 22, 24, 26, 28, 30, 0, 3, 1, 0, 12, 21, 1, 0, 12, 14, 1, 0,
-		27, 28, 174, 0, 71, 1, 0, 0, 0, 2, 83, 1, 0, 0, 0, 4, 87, 1, 0, 0, 0, 6,
-		91, 1, 0, 0, 0, 8, 94, 1, 0, 0, 0, 10, 113, 1, 0, 0, 0, 12, 117, 1, 0,
-		0, 0, 14, 119, 1, 0, 0, 0, 16, 121, 1, 0, 0, 0, 18, 131, 1, 0, 0, 0, 20,
-		133, 1, 0, 0, 0, 22, 141, 1, 0, 0, 0, 24, 143, 1, 0, 0, 0, 26, 151, 1,
-		0, 0, 0, 28, 153, 1, 0, 0, 0, 30, 161, 1, 0, 0, 0, 32, 34, 6, 0, -1, 0,
-		33, 35, 5, 8, 0, 0, 34, 33, 1, 0, 0, 0, 34, 35, 1, 0, 0, 0, 35, 37, 1,
-		0, 0, 0, 36, 38, 5, 34, 0, 0, 37, 36, 1, 0, 0, 0, 37, 38, 1, 0, 0, 0, 38,
-		39, 1, 0, 0, 0, 39, 41, 5, 1, 0, 0, 40, 42, 5, 34, 0, 0, 41, 40, 1, 0,
-		0, 0, 41, 42, 1, 0, 0, 0, 42, 43, 1, 0, 0, 0, 43, 45, 3, 0, 0, 0, 44, 46,
-		5, 34, 0, 0, 45, 44, 1, 0, 0, 0, 45, 46, 1, 0, 0, 0, 46, 47, 1, 0, 0, 0,
-		47, 48, 5, 2, 0, 0, 48, 72, 1, 0, 0, 0, 49, 50, 3, 2, 1, 0, 50, 51, 5,
-		34, 0, 0, 51, 52, 5, 3, 0, 0, 52, 72, 1, 0, 0, 0, 53, 54, 3, 2, 1, 0, 54,
-		55, 5, 34, 0, 0, 55, 56, 7, 0, 0, 0, 56, 57, 5, 34, 0, 0, 57, 58, 3, 10,
-		5, 0, 58, 72, 1, 0, 0, 0, 59, 60, 3, 2, 1, 0, 60, 61, 5, 34, 0, 0, 61,
-		62, 5, 22, 0, 0, 62, 63, 5, 34, 0, 0, 63, 64, 3, 12, 6, 0, 64, 72, 1, 0,
-		0, 0, 65, 66, 3, 2, 1, 0, 66, 67, 5, 34, 0, 0, 67, 68, 7, 1, 0, 0, 68,
-		69, 5, 34, 0, 0, 69, 70, 3, 14, 7, 0, 70, 72, 1, 0, 0, 0, 71, 32, 1, 0,
-		0, 0, 71, 49, 1, 0, 0, 0, 71, 53, 1, 0, 0, 0, 71, 59, 1, 0, 0, 0, 71, 65,
-		1, 0, 0, 0, 72, 80, 1, 0, 0, 0, 73, 74, 10, 5, 0, 0, 74, 75, 5, 34, 0,
-		0, 75, 76, 5, 9, 0, 0, 76, 77, 5, 34, 0, 0, 77, 79, 3, 0, 0, 6, 78, 73,
-		1, 0, 0, 0, 79, 82, 1, 0, 0, 0, 80, 78, 1, 0, 0, 0, 80, 81, 1, 0, 0, 0,
-		81, 1, 1, 0, 0, 0, 82, 80, 1, 0, 0, 0, 83, 85, 5, 23, 0, 0, 84, 86, 3,
-		6, 3, 0, 85, 84, 1, 0, 0, 0, 85, 86, 1, 0, 0, 0, 86, 3, 1, 0, 0, 0, 87,
-		89, 5, 23, 0, 0, 88, 90, 3, 8, 4, 0, 89, 88, 1, 0, 0, 0, 89, 90, 1, 0,
-		0, 0, 90, 5, 1, 0, 0, 0, 91, 92, 5, 4, 0, 0, 92, 93, 3, 2, 1, 0, 93, 7,
-		1, 0, 0, 0, 94, 95, 5, 4, 0, 0, 95, 96, 3, 4, 2, 0, 96, 9, 1, 0, 0, 0,
-		97, 114, 5, 10, 0, 0, 98, 114, 5, 11, 0, 0, 99, 114, 5, 24, 0, 0, 100,
-		114, 5, 25, 0, 0, 101, 114, 5, 29, 0, 0, 102, 104, 5, 5, 0, 0, 103, 102,
-		1, 0, 0, 0, 103, 104, 1, 0, 0, 0, 104, 105, 1, 0, 0, 0, 105, 107, 5, 30,
-		0, 0, 106, 108, 5, 31, 0, 0, 107, 106, 1, 0, 0, 0, 107, 108, 1, 0, 0, 0,
-		108, 114, 1, 0, 0, 0, 109, 114, 3, 28, 14, 0, 110, 114, 3, 24, 12, 0, 111,
-		114, 3, 20, 10, 0, 112, 114, 3, 4, 2, 0, 113, 97, 1, 0, 0, 0, 113, 98,
-		1, 0, 0, 0, 113, 99, 1, 0, 0, 0, 113, 100, 1, 0, 0, 0, 113, 101, 1, 0,
-		0, 0, 113, 103, 1, 0, 0, 0, 113, 109, 1, 0, 0, 0, 113, 110, 1, 0, 0, 0,
-		113, 111, 1, 0, 0, 0, 113, 112, 1, 0, 0, 0, 114, 11, 1, 0, 0, 0, 115, 118,
-		5, 26, 0, 0, 116, 118, 3, 4, 2, 0, 117, 115, 1, 0, 0, 0, 117, 116, 1, 0,
-		0, 0, 118, 13, 1, 0, 0, 0, 119, 120, 7, 2, 0, 0, 120, 15, 1, 0, 0, 0, 121,
-		122, 5, 6, 0, 0, 122, 123, 3, 18, 9, 0, 123, 17, 1, 0, 0, 0, 124, 125,
-		3, 14, 7, 0, 125, 126, 5, 33, 0, 0, 126, 127, 3, 18, 9, 0, 127, 132, 1,
-		0, 0, 0, 128, 129, 3, 14, 7, 0, 129, 130, 5, 7, 0, 0, 130, 132, 1, 0, 0,
-		0, 131, 124, 1, 0, 0, 0, 131, 128, 1, 0, 0, 0, 132, 19, 1, 0, 0, 0, 133,
-		134, 5, 6, 0, 0, 134, 135, 3, 22, 11, 0, 135, 21, 1, 0, 0, 0, 136, 137,
-		5, 25, 0, 0, 137, 138, 5, 33, 0, 0, 138, 142, 3, 22, 11, 0, 139, 140, 5,
-		25, 0, 0, 140, 142, 5, 7, 0, 0, 141, 136, 1, 0, 0, 0, 141, 139, 1, 0, 0,
-		0, 142, 23, 1, 0, 0, 0, 143, 144, 5, 6, 0, 0, 144, 145, 3, 26, 13, 0, 145,
-		25, 1, 0, 0, 0, 146, 147, 5, 29, 0, 0, 147, 148, 5, 33, 0, 0, 148, 152,
-		3, 26, 13, 0, 149, 150, 5, 29, 0, 0, 150, 152, 5, 7, 0, 0, 151, 146, 1,
-		0, 0, 0, 151, 149, 1, 0, 0, 0, 152, 27, 1, 0, 0, 0, 153, 154, 5, 6, 0,
-		0, 154, 155, 3, 30, 15, 0, 155, 29, 1, 0, 0, 0, 156, 157, 5, 30, 0, 0,
-		157, 158, 5, 33, 0, 0, 158, 162, 3, 30, 15, 0, 159, 160, 5, 30, 0, 0, 160,
-		162, 5, 7, 0, 0, 161, 156, 1, 0, 0, 0, 161, 159, 1, 0, 0, 0, 162, 31, 1,
-		0, 0, 0, 16, 34, 37, 41, 45, 71, 80, 85, 89, 103, 107, 113, 117, 131, 141,
-		151, 161,
+		2, 16, 7, 16, 2, 17, 7, 17, 1, 0, 1, 0, 1, 0, 1, 1, 1, 1, 3, 1, 42, 8,
+		1, 1, 1, 1, 1, 3, 1, 46, 8, 1, 1, 1, 1, 1, 3, 1, 50, 8, 1, 1, 1, 1, 1,
+		1, 1, 1, 1, 3, 1, 56, 8, 1, 1, 1, 1, 1, 1, 1, 1, 1, 3, 1, 62, 8, 1, 1,
+		1, 1, 1, 3, 1, 66, 8, 1, 1, 1, 1, 1, 3, 1, 70, 8, 1, 1, 1, 1, 1, 3, 1,
+		74, 8, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 3, 1, 81, 8, 1, 1, 1, 1, 1, 3,
+		1, 85, 8, 1, 1, 1, 1, 1, 3, 1, 89, 8, 1, 1, 1, 1, 1, 3, 1, 93, 8, 1, 1,
+		1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+		1, 1, 1, 3, 1, 109, 8, 1, 1, 1, 1, 1, 1, 1, 1, 1, 3, 1, 115, 8, 1, 1, 1,
+		1, 1, 1, 1, 1, 1, 3, 1, 121, 8, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+		1, 1, 1, 1, 3, 1, 131, 8, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+		1, 1, 1, 1, 1, 1, 3, 1, 143, 8, 1, 1, 1, 1, 1, 3, 1, 147, 8, 1, 1, 1, 1,
+		1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+		1, 1, 1, 5, 1, 164, 8, 1, 10, 1, 12, 1, 167, 9, 1, 1, 2, 1, 2, 1, 2, 3,
+		2, 172, 8, 2, 1, 2, 1, 2, 3, 2, 176, 8, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2,
+		3, 2, 183, 8, 2, 1, 2, 1, 2, 1, 2, 1, 2, 3, 2, 189, 8, 2, 1, 2, 1, 2, 3,
+		2, 193, 8, 2, 1, 2, 1, 2, 3, 2, 197, 8, 2, 1, 2, 5, 2, 200, 8, 2, 10, 2,
+		12, 2, 203, 9, 2, 1, 2, 3, 2, 206, 8, 2, 1, 2, 1, 2, 1, 2, 3, 2, 211, 8,
+		2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 5, 2, 223,
+		8, 2, 10, 2, 12, 2, 226, 9, 2, 1, 3, 1, 3, 5, 3, 230, 8, 3, 10, 3, 12,
+		3, 233, 9, 3, 1, 4, 1, 4, 5, 4, 237, 8, 4, 10, 4, 12, 4, 240, 9, 4, 1,
+		5, 1, 5, 1, 5, 1, 5, 3, 5, 246, 8, 5, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 1,
+		5, 1, 5, 1, 5, 3, 5, 256, 8, 5, 1, 6, 1, 6, 1, 6, 1, 6, 1, 6, 1, 6, 3,
+		6, 264, 8, 6, 1, 6, 1, 6, 3, 6, 268, 8, 6, 1, 6, 1, 6, 1, 6, 1, 6, 3, 6,
+		274, 8, 6, 1, 7, 1, 7, 3, 7, 278, 8, 7, 1, 8, 1, 8, 1, 9, 1, 9, 1, 10,
+		1, 10, 1, 10, 1, 11, 1, 11, 1, 11, 1, 11, 1, 11, 1, 11, 1, 11, 3, 11, 294,
+		8, 11, 1, 12, 1, 12, 1, 12, 1, 13, 1, 13, 1, 13, 1, 13, 1, 13, 3, 13, 304,
+		8, 13, 1, 14, 1, 14, 1, 14, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15, 3, 15, 314,
+		8, 15, 1, 16, 1, 16, 1, 16, 1, 17, 1, 17, 1, 17, 1, 17, 1, 17, 3, 17, 324,
+		8, 17, 1, 17, 0, 2, 2, 4, 18, 0, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22,
+		24, 26, 28, 30, 32, 34, 0, 6, 1, 0, 14, 19, 2, 0, 12, 12, 14, 19, 2, 0,
+		12, 12, 14, 22, 1, 0, 26, 28, 1, 0, 24, 25, 1, 0, 34, 36, 370, 0, 36, 1,
+		0, 0, 0, 2, 146, 1, 0, 0, 0, 4, 210, 1, 0, 0, 0, 6, 227, 1, 0, 0, 0, 8,
+		234, 1, 0, 0, 0, 10, 255, 1, 0, 0, 0, 12, 273, 1, 0, 0, 0, 14, 277, 1,
+		0, 0, 0, 16, 279, 1, 0, 0, 0, 18, 281, 1, 0, 0, 0, 20, 283, 1, 0, 0, 0,
+		22, 293, 1, 0, 0, 0, 24, 295, 1, 0, 0, 0, 26, 303, 1, 0, 0, 0, 28, 305,
+		1, 0, 0, 0, 30, 313, 1, 0, 0, 0, 32, 315, 1, 0, 0, 0, 34, 323, 1, 0, 0,
+		0, 36, 37, 3, 2, 1, 0, 37, 38, 5, 0, 0, 1, 38, 1, 1, 0, 0, 0, 39, 41, 6,
+		1, -1, 0, 40, 42, 5, 41, 0, 0, 41, 40, 1, 0, 0, 0, 41, 42, 1, 0, 0, 0,
+		42, 43, 1, 0, 0, 0, 43, 45, 5, 1, 0, 0, 44, 46, 5, 41, 0, 0, 45, 44, 1,
+		0, 0, 0, 45, 46, 1, 0, 0, 0, 46, 47, 1, 0, 0, 0, 47, 49, 3, 2, 1, 0, 48,
+		50, 5, 41, 0, 0, 49, 48, 1, 0, 0, 0, 49, 50, 1, 0, 0, 0, 50, 51, 1, 0,
+		0, 0, 51, 52, 5, 2, 0, 0, 52, 147, 1, 0, 0, 0, 53, 55, 5, 6, 0, 0, 54,
+		56, 5, 41, 0, 0, 55, 54, 1, 0, 0, 0, 55, 56, 1, 0, 0, 0, 56, 57, 1, 0,
+		0, 0, 57, 147, 3, 2, 1, 11, 58, 59, 5, 30, 0, 0, 59, 61, 5, 1, 0, 0, 60,
+		62, 5, 41, 0, 0, 61, 60, 1, 0, 0, 0, 61, 62, 1, 0, 0, 0, 62, 63, 1, 0,
+		0, 0, 63, 65, 3, 6, 3, 0, 64, 66, 5, 41, 0, 0, 65, 64, 1, 0, 0, 0, 65,
+		66, 1, 0, 0, 0, 66, 67, 1, 0, 0, 0, 67, 69, 5, 40, 0, 0, 68, 70, 5, 41,
+		0, 0, 69, 68, 1, 0, 0, 0, 69, 70, 1, 0, 0, 0, 70, 71, 1, 0, 0, 0, 71, 73,
+		3, 2, 1, 0, 72, 74, 5, 41, 0, 0, 73, 72, 1, 0, 0, 0, 73, 74, 1, 0, 0, 0,
+		74, 75, 1, 0, 0, 0, 75, 76, 5, 2, 0, 0, 76, 147, 1, 0, 0, 0, 77, 78, 5,
+		30, 0, 0, 78, 80, 5, 1, 0, 0, 79, 81, 5, 41, 0, 0, 80, 79, 1, 0, 0, 0,
+		80, 81, 1, 0, 0, 0, 81, 82, 1, 0, 0, 0, 82, 84, 3, 6, 3, 0, 83, 85, 5,
+		41, 0, 0, 84, 83, 1, 0, 0, 0, 84, 85, 1, 0, 0, 0, 85, 86, 1, 0, 0, 0, 86,
+		88, 5, 40, 0, 0, 87, 89, 5, 41, 0, 0, 88, 87, 1, 0, 0, 0, 88, 89, 1, 0,
+		0, 0, 89, 90, 1, 0, 0, 0, 90, 92, 3, 2, 1, 0, 91, 93, 5, 41, 0, 0, 92,
+		91, 1, 0, 0, 0, 92, 93, 1, 0, 0, 0, 93, 94, 1, 0, 0, 0, 94, 95, 5, 2, 0,
+		0, 95, 96, 5, 41, 0, 0, 96, 97, 7, 0, 0, 0, 97, 98, 5, 41, 0, 0, 98, 99,
+		3, 12, 6, 0, 99, 147, 1, 0, 0, 0, 100, 101, 3, 6, 3, 0, 101, 102, 5, 41,
+		0, 0, 102, 103, 5, 3, 0, 0, 103, 147, 1, 0, 0, 0, 104, 105, 3, 4, 2, 0,
+		105, 108, 5, 41, 0, 0, 106, 107, 5, 6, 0, 0, 107, 109, 5, 41, 0, 0, 108,
+		106, 1, 0, 0, 0, 108, 109, 1, 0, 0, 0, 109, 110, 1, 0, 0, 0, 110, 111,
+		7, 1, 0, 0, 111, 114, 5, 41, 0, 0, 112, 115, 3, 18, 9, 0, 113, 115, 3,
+		20, 10, 0, 114, 112, 1, 0, 0, 0, 114, 113, 1, 0, 0, 0, 115, 147, 1, 0,
+		0, 0, 116, 117, 3, 4, 2, 0, 117, 120, 5, 41, 0, 0, 118, 119, 5, 6, 0, 0,
+		119, 121, 5, 41, 0, 0, 120, 118, 1, 0, 0, 0, 120, 121, 1, 0, 0, 0, 121,
+		122, 1, 0, 0, 0, 122, 123, 7, 2, 0, 0, 123, 124, 5, 41, 0, 0, 124, 125,
+		3, 4, 2, 0, 125, 147, 1, 0, 0, 0, 126, 127, 3, 4, 2, 0, 127, 130, 5, 41,
+		0, 0, 128, 129, 5, 6, 0, 0, 129, 131, 5, 41, 0, 0, 130, 128, 1, 0, 0, 0,
+		130, 131, 1, 0, 0, 0, 131, 132, 1, 0, 0, 0, 132, 133, 5, 23, 0, 0, 133,
+		134, 5, 41, 0, 0, 134, 135, 3, 14, 7, 0, 135, 147, 1, 0, 0, 0, 136, 137,
+		3, 4, 2, 0, 137, 138, 5, 41, 0, 0, 138, 139, 5, 13, 0, 0, 139, 142, 5,
+		41, 0, 0, 140, 141, 5, 6, 0, 0, 141, 143, 5, 41, 0, 0, 142, 140, 1, 0,
+		0, 0, 142, 143, 1, 0, 0, 0, 143, 144, 1, 0, 0, 0, 144, 145, 3, 16, 8, 0,
+		145, 147, 1, 0, 0, 0, 146, 39, 1, 0, 0, 0, 146, 53, 1, 0, 0, 0, 146, 58,
+		1, 0, 0, 0, 146, 77, 1, 0, 0, 0, 146, 100, 1, 0, 0, 0, 146, 104, 1, 0,
+		0, 0, 146, 116, 1, 0, 0, 0, 146, 126, 1, 0, 0, 0, 146, 136, 1, 0, 0, 0,
+		147, 165, 1, 0, 0, 0, 148, 149, 10, 10, 0, 0, 149, 150, 5, 41, 0, 0, 150,
+		151, 5, 7, 0, 0, 151, 152, 5, 41, 0, 0, 152, 164, 3, 2, 1, 11, 153, 154,
+		10, 9, 0, 0, 154, 155, 5, 41, 0, 0, 155, 156, 5, 8, 0, 0, 156, 157, 5,
+		41, 0, 0, 157, 164, 3, 2, 1, 10, 158, 159, 10, 8, 0, 0, 159, 160, 5, 41,
+		0, 0, 160, 161, 5, 9, 0, 0, 161, 162, 5, 41, 0, 0, 162, 164, 3, 2, 1, 9,
+		163, 148, 1, 0, 0, 0, 163, 153, 1, 0, 0, 0, 163, 158, 1, 0, 0, 0, 164,
+		167, 1, 0, 0, 0, 165, 163, 1, 0, 0, 0, 165, 166, 1, 0, 0, 0, 166, 3, 1,
+		0, 0, 0, 167, 165, 1, 0, 0, 0, 168, 169, 6, 2, -1, 0, 169, 171, 5, 1, 0,
+		0, 170, 172, 5, 41, 0, 0, 171, 170, 1, 0, 0, 0, 171, 172, 1, 0, 0, 0, 172,
+		173, 1, 0, 0, 0, 173, 175, 3, 4, 2, 0, 174, 176, 5, 41, 0, 0, 175, 174,
+		1, 0, 0, 0, 175, 176, 1, 0, 0, 0, 176, 177, 1, 0, 0, 0, 177, 178, 5, 2,
+		0, 0, 178, 211, 1, 0, 0, 0, 179, 180, 5, 30, 0, 0, 180, 182, 5, 1, 0, 0,
+		181, 183, 5, 41, 0, 0, 182, 181, 1, 0, 0, 0, 182, 183, 1, 0, 0, 0, 183,
+		184, 1, 0, 0, 0, 184, 211, 5, 2, 0, 0, 185, 186, 5, 30, 0, 0, 186, 188,
+		5, 1, 0, 0, 187, 189, 5, 41, 0, 0, 188, 187, 1, 0, 0, 0, 188, 189, 1, 0,
+		0, 0, 189, 190, 1, 0, 0, 0, 190, 201, 3, 4, 2, 0, 191, 193, 5, 41, 0, 0,
+		192, 191, 1, 0, 0, 0, 192, 193, 1, 0, 0, 0, 193, 194, 1, 0, 0, 0, 194,
+		196, 5, 40, 0, 0, 195, 197, 5, 41, 0, 0, 196, 195, 1, 0, 0, 0, 196, 197,
+		1, 0, 0, 0, 197, 198, 1, 0, 0, 0, 198, 200, 3, 4, 2, 0, 199, 192, 1, 0,
+		0, 0, 200, 203, 1, 0, 0, 0, 201, 199, 1, 0, 0, 0, 201, 202, 1, 0, 0, 0,
+		202, 205, 1, 0, 0, 0, 203, 201, 1, 0, 0, 0, 204, 206, 5, 41, 0, 0, 205,
+		204, 1, 0, 0, 0, 205, 206, 1, 0, 0, 0, 206, 207, 1, 0, 0, 0, 207, 208,
+		5, 2, 0, 0, 208, 211, 1, 0, 0, 0, 209, 211, 3, 12, 6, 0, 210, 168, 1, 0,
+		0, 0, 210, 179, 1, 0, 0, 0, 210, 185, 1, 0, 0, 0, 210, 209, 1, 0, 0, 0,
+		211, 224, 1, 0, 0, 0, 212, 213, 10, 3, 0, 0, 213, 214, 5, 41, 0, 0, 214,
+		215, 7, 3, 0, 0, 215, 216, 5, 41, 0, 0, 216, 223, 3, 4, 2, 4, 217, 218,
+		10, 2, 0, 0, 218, 219, 5, 41, 0, 0, 219, 220, 7, 4, 0, 0, 220, 221, 5,
+		41, 0, 0, 221, 223, 3, 4, 2, 3, 222, 212, 1, 0, 0, 0, 222, 217, 1, 0, 0,
+		0, 223, 226, 1, 0, 0, 0, 224, 222, 1, 0, 0, 0, 224, 225, 1, 0, 0, 0, 225,
+		5, 1, 0, 0, 0, 226, 224, 1, 0, 0, 0, 227, 231, 5, 30, 0, 0, 228, 230, 3,
+		10, 5, 0, 229, 228, 1, 0, 0, 0, 230, 233, 1, 0, 0, 0, 231, 229, 1, 0, 0,
+		0, 231, 232, 1, 0, 0, 0, 232, 7, 1, 0, 0, 0, 233, 231, 1, 0, 0, 0, 234,
+		238, 5, 30, 0, 0, 235, 237, 3, 10, 5, 0, 236, 235, 1, 0, 0, 0, 237, 240,
+		1, 0, 0, 0, 238, 236, 1, 0, 0, 0, 238, 239, 1, 0, 0, 0, 239, 9, 1, 0, 0,
+		0, 240, 238, 1, 0, 0, 0, 241, 242, 5, 29, 0, 0, 242, 256, 5, 30, 0, 0,
+		243, 245, 5, 4, 0, 0, 244, 246, 5, 25, 0, 0, 245, 244, 1, 0, 0, 0, 245,
+		246, 1, 0, 0, 0, 246, 247, 1, 0, 0, 0, 247, 248, 5, 38, 0, 0, 248, 256,
+		5, 5, 0, 0, 249, 250, 5, 4, 0, 0, 250, 251, 5, 26, 0, 0, 251, 256, 5, 5,
+		0, 0, 252, 253, 5, 4, 0, 0, 253, 254, 5, 32, 0, 0, 254, 256, 5, 5, 0, 0,
+		255, 241, 1, 0, 0, 0, 255, 243, 1, 0, 0, 0, 255, 249, 1, 0, 0, 0, 255,
+		252, 1, 0, 0, 0, 256, 11, 1, 0, 0, 0, 257, 274, 5, 10, 0, 0, 258, 274,
+		5, 11, 0, 0, 259, 274, 5, 31, 0, 0, 260, 274, 5, 32, 0, 0, 261, 274, 5,
+		37, 0, 0, 262, 264, 5, 25, 0, 0, 263, 262, 1, 0, 0, 0, 263, 264, 1, 0,
+		0, 0, 264, 265, 1, 0, 0, 0, 265, 267, 5, 38, 0, 0, 266, 268, 5, 39, 0,
+		0, 267, 266, 1, 0, 0, 0, 267, 268, 1, 0, 0, 0, 268, 274, 1, 0, 0, 0, 269,
+		274, 3, 32, 16, 0, 270, 274, 3, 28, 14, 0, 271, 274, 3, 24, 12, 0, 272,
+		274, 3, 8, 4, 0, 273, 257, 1, 0, 0, 0, 273, 258, 1, 0, 0, 0, 273, 259,
+		1, 0, 0, 0, 273, 260, 1, 0, 0, 0, 273, 261, 1, 0, 0, 0, 273, 263, 1, 0,
+		0, 0, 273, 269, 1, 0, 0, 0, 273, 270, 1, 0, 0, 0, 273, 271, 1, 0, 0, 0,
+		273, 272, 1, 0, 0, 0, 274, 13, 1, 0, 0, 0, 275, 278, 5, 33, 0, 0, 276,
+		278, 3, 8, 4, 0, 277, 275, 1, 0, 0, 0, 277, 276, 1, 0, 0, 0, 278, 15, 1,
+		0, 0, 0, 279, 280, 5, 30, 0, 0, 280, 17, 1, 0, 0, 0, 281, 282, 7, 5, 0,
+		0, 282, 19, 1, 0, 0, 0, 283, 284, 5, 4, 0, 0, 284, 285, 3, 22, 11, 0, 285,
+		21, 1, 0, 0, 0, 286, 287, 3, 18, 9, 0, 287, 288, 5, 40, 0, 0, 288, 289,
+		3, 22, 11, 0, 289, 294, 1, 0, 0, 0, 290, 291, 3, 18, 9, 0, 291, 292, 5,
+		5, 0, 0, 292, 294, 1, 0, 0, 0, 293, 286, 1, 0, 0, 0, 293, 290, 1, 0, 0,
+		0, 294, 23, 1, 0, 0, 0, 295, 296, 5, 4, 0, 0, 296, 297, 3, 26, 13, 0, 297,
+		25, 1, 0, 0, 0, 298, 299, 5, 32, 0, 0, 299, 300, 5, 40, 0, 0, 300, 304,
+		3, 26, 13, 0, 301, 302, 5, 32, 0, 0, 302, 304, 5, 5, 0, 0, 303, 298, 1,
+		0, 0, 0, 303, 301, 1, 0, 0, 0, 304, 27, 1, 0, 0, 0, 305, 306, 5, 4, 0,
+		0, 306, 307, 3, 30, 15, 0, 307, 29, 1, 0, 0, 0, 308, 309, 5, 37, 0, 0,
+		309, 310, 5, 40, 0, 0, 310, 314, 3, 30, 15, 0, 311, 312, 5, 37, 0, 0, 312,
+		314, 5, 5, 0, 0, 313, 308, 1, 0, 0, 0, 313, 311, 1, 0, 0, 0, 314, 31, 1,
+		0, 0, 0, 315, 316, 5, 4, 0, 0, 316, 317, 3, 34, 17, 0, 317, 33, 1, 0, 0,
+		0, 318, 319, 5, 38, 0, 0, 319, 320, 5, 40, 0, 0, 320, 324, 3, 34, 17, 0,
+		321, 322, 5, 38, 0, 0, 322, 324, 5, 5, 0, 0, 323, 318, 1, 0, 0, 0, 323,
+		321, 1, 0, 0, 0, 324, 35, 1, 0, 0, 0, 43, 41, 45, 49, 55, 61, 65, 69, 73,
+		80, 84, 88, 92, 108, 114, 120, 130, 142, 146, 163, 165, 171, 175, 182,
+		188, 192, 196, 201, 205, 210, 222, 224, 231, 238, 245, 255, 263, 267, 273,
+		277, 293, 303, 313, 323,
 	}
 	deserializer := antlr.NewATNDeserializer(nil)
 	staticData.atn = deserializer.Deserialize(staticData.serializedATN)
@@ -157,63 +237,187 @@ func NewJsonQueryParser(input antlr.TokenStream) *JsonQueryParser {
 
 // JsonQueryParser tokens.
 const (
-	JsonQueryParserEOF              = antlr.TokenEOF
-	JsonQueryParserT__0             = 1
-	JsonQueryParserT__1             = 2
-	JsonQueryParserT__2             = 3
-	JsonQueryParserT__3             = 4
-	JsonQueryParserT__4             = 5
-	JsonQueryParserT__5             = 6
-	JsonQueryParserT__6             = 7
-	JsonQueryParserNOT              = 8
-	JsonQueryParserLOGICAL_OPERATOR = 9
-	JsonQueryParserBOOLEAN          = 10
-	JsonQueryParserNULL             = 11
-	JsonQueryParserIN               = 12
-	JsonQueryParserEQ               = 13
-	JsonQueryParserNE               = 14
-	JsonQueryParserGT               = 15
-	JsonQueryParserLT               = 16
-	JsonQueryParserGE               = 17
-	JsonQueryParserLE               = 18
-	JsonQueryParserCO               = 19
-	JsonQueryParserSW               = 20
-	JsonQueryParserEW               = 21
-	JsonQueryParserMT               = 22
-	JsonQueryParserATTRNAME         = 23
-	JsonQueryParserVERSION          = 24
-	JsonQueryParserSTRING           = 25
-	JsonQueryParserREGEX            = 26
-	JsonQueryParserIP_ADDRESS       = 27
-	JsonQueryParserIP_CIDR          = 28
-	JsonQueryParserDOUBLE           = 29
-	JsonQueryParserINT              = 30
-	JsonQueryParserEXP              = 31
-	JsonQueryParserNEWLINE          = 32
-	JsonQueryParserCOMMA            = 33
-	JsonQueryParserSP               = 34
+	JsonQueryParserEOF        = antlr.TokenEOF
+	JsonQueryParserT__0       = 1
+	JsonQueryParserT__1       = 2
+	JsonQueryParserT__2       = 3
+	JsonQueryParserT__3       = 4
+	JsonQueryParserT__4       = 5
+	JsonQueryParserNOT        = 6
+	JsonQueryParserAND        = 7
+	JsonQueryParserXOR        = 8
+	JsonQueryParserOR         = 9
+	JsonQueryParserBOOLEAN    = 10
+	JsonQueryParserNULL       = 11
+	JsonQueryParserIN         = 12
+	JsonQueryParserIS         = 13
+	JsonQueryParserEQ         = 14
+	JsonQueryParserNE         = 15
+	JsonQueryParserGT         = 16
+	JsonQueryParserLT         = 17
+	JsonQueryParserGE         = 18
+	JsonQueryParserLE         = 19
+	JsonQueryParserCO         = 20
+	JsonQueryParserSW         = 21
+	JsonQueryParserEW         = 22
+	JsonQueryParserMT         = 23
+	JsonQueryParserPLUS       = 24
+	JsonQueryParserMINUS      = 25
+	JsonQueryParserSTAR       = 26
+	JsonQueryParserSLASH      = 27
+	JsonQueryParserPERCENT    = 28
+	JsonQueryParserJSON_SEP   = 29
+	JsonQueryParserATTRNAME   = 30
+	JsonQueryParserVERSION    = 31
+	JsonQueryParserSTRING     = 32
+	JsonQueryParserREGEX      = 33
+	JsonQueryParserIP_ADDRESS = 34
+	JsonQueryParserIP_CIDR    = 35
+	JsonQueryParserIP_RANGE   = 36
+	JsonQueryParserDOUBLE     = 37
+	JsonQueryParserINT        = 38
+	JsonQueryParserEXP        = 39
+	JsonQueryParserCOMMA      = 40
+	JsonQueryParserSP         = 41
 )
 
 // JsonQueryParser rules.
 const (
-	JsonQueryParserRULE_query            = 0
-	JsonQueryParserRULE_attrPath         = 1
-	JsonQueryParserRULE_valueAttrPath    = 2
-	JsonQueryParserRULE_subAttr          = 3
-	JsonQueryParserRULE_valueSubAttr     = 4
-	JsonQueryParserRULE_value            = 5
-	JsonQueryParserRULE_regexValue       = 6
-	JsonQueryParserRULE_ipValue          = 7
-	JsonQueryParserRULE_listIPs          = 8
-	JsonQueryParserRULE_subListOfIPs     = 9
-	JsonQueryParserRULE_listStrings      = 10
-	JsonQueryParserRULE_subListOfStrings = 11
-	JsonQueryParserRULE_listDoubles      = 12
-	JsonQueryParserRULE_subListOfDoubles = 13
-	JsonQueryParserRULE_listInts         = 14
-	JsonQueryParserRULE_subListOfInts    = 15
+	JsonQueryParserRULE_root             = 0
+	JsonQueryParserRULE_query            = 1
+	JsonQueryParserRULE_arith            = 2
+	JsonQueryParserRULE_attrPath         = 3
+	JsonQueryParserRULE_valueAttrPath    = 4
+	JsonQueryParserRULE_subAttr          = 5
+	JsonQueryParserRULE_value            = 6
+	JsonQueryParserRULE_regexValue       = 7
+	JsonQueryParserRULE_ipClass          = 8
+	JsonQueryParserRULE_ipValue          = 9
+	JsonQueryParserRULE_listIPs          = 10
+	JsonQueryParserRULE_subListOfIPs     = 11
+	JsonQueryParserRULE_listStrings      = 12
+	JsonQueryParserRULE_subListOfStrings = 13
+	JsonQueryParserRULE_listDoubles      = 14
+	JsonQueryParserRULE_subListOfDoubles = 15
+	JsonQueryParserRULE_listInts         = 16
+	JsonQueryParserRULE_subListOfInts    = 17
 )
 
+// IRootContext is an interface to support dynamic dispatch.
+type IRootContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	Query() IQueryContext
+	EOF() antlr.TerminalNode
+
+	// IsRootContext differentiates from other interfaces.
+	IsRootContext()
+}
+
+type RootContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyRootContext() *RootContext {
+	var p = new(RootContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = JsonQueryParserRULE_root
+	return p
+}
+
+func InitEmptyRootContext(p *RootContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = JsonQueryParserRULE_root
+}
+
+func (*RootContext) IsRootContext() {}
+
+func NewRootContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *RootContext {
+	var p = new(RootContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = JsonQueryParserRULE_root
+
+	return p
+}
+
+func (s *RootContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *RootContext) Query() IQueryContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IQueryContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IQueryContext)
+}
+
+func (s *RootContext) EOF() antlr.TerminalNode {
+	return s.GetToken(JsonQueryParserEOF, 0)
+}
+
+func (s *RootContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *RootContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *RootContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case JsonQueryVisitor:
+		return t.VisitRoot(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *JsonQueryParser) Root() (localctx IRootContext) {
+	localctx = NewRootContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 0, JsonQueryParserRULE_root)
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(36)
+		p.query(0)
+	}
+	{
+		p.SetState(37)
+		p.Match(JsonQueryParserEOF)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit // Trick to prevent compiler error if the label is not used
+}
+
 // IQueryContext is an interface to support dynamic dispatch.
 type IQueryContext interface {
 	antlr.ParserRuleContext
@@ -268,13 +472,12 @@ func (s *QueryContext) ToStringTree(ruleNames []string, recog antlr.Recognizer)
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-type CompareExpContext struct {
+type QuantifierExpContext struct {
 	QueryContext
-	op antlr.Token
 }
 
-func NewCompareExpContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *CompareExpContext {
-	var p = new(CompareExpContext)
+func NewQuantifierExpContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *QuantifierExpContext {
+	var p = new(QuantifierExpContext)
 
 	InitEmptyQueryContext(&p.QueryContext)
 	p.parser = parser
@@ -283,15 +486,15 @@ func NewCompareExpContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *Com
 	return p
 }
 
-func (s *CompareExpContext) GetOp() antlr.Token { return s.op }
-
-func (s *CompareExpContext) SetOp(v antlr.Token) { s.op = v }
-
-func (s *CompareExpContext) GetRuleContext() antlr.RuleContext {
+func (s *QuantifierExpContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *CompareExpContext) AttrPath() IAttrPathContext {
+func (s *QuantifierExpContext) ATTRNAME() antlr.TerminalNode {
+	return s.GetToken(JsonQueryParserATTRNAME, 0)
+}
+
+func (s *QuantifierExpContext) AttrPath() IAttrPathContext {
 	var t antlr.RuleContext
 	for _, ctx := range s.GetChildren() {
 		if _, ok := ctx.(IAttrPathContext); ok {
@@ -307,20 +510,98 @@ func (s *CompareExpContext) AttrPath() IAttrPathContext {
 	return t.(IAttrPathContext)
 }
 
-func (s *CompareExpContext) AllSP() []antlr.TerminalNode {
+func (s *QuantifierExpContext) COMMA() antlr.TerminalNode {
+	return s.GetToken(JsonQueryParserCOMMA, 0)
+}
+
+func (s *QuantifierExpContext) Query() IQueryContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IQueryContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IQueryContext)
+}
+
+func (s *QuantifierExpContext) AllSP() []antlr.TerminalNode {
 	return s.GetTokens(JsonQueryParserSP)
 }
 
-func (s *CompareExpContext) SP(i int) antlr.TerminalNode {
+func (s *QuantifierExpContext) SP(i int) antlr.TerminalNode {
 	return s.GetToken(JsonQueryParserSP, i)
 }
 
-func (s *CompareExpContext) Value() IValueContext {
+func (s *QuantifierExpContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case JsonQueryVisitor:
+		return t.VisitQuantifierExp(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+type CompareExpContext struct {
+	QueryContext
+	op antlr.Token
+}
+
+func NewCompareExpContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *CompareExpContext {
+	var p = new(CompareExpContext)
+
+	InitEmptyQueryContext(&p.QueryContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*QueryContext))
+
+	return p
+}
+
+func (s *CompareExpContext) GetOp() antlr.Token { return s.op }
+
+func (s *CompareExpContext) SetOp(v antlr.Token) { s.op = v }
+
+func (s *CompareExpContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *CompareExpContext) AllArith() []IArithContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IArithContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IArithContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IArithContext); ok {
+			tst[i] = t.(IArithContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *CompareExpContext) Arith(i int) IArithContext {
 	var t antlr.RuleContext
+	j := 0
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IValueContext); ok {
-			t = ctx.(antlr.RuleContext)
-			break
+		if _, ok := ctx.(IArithContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
 		}
 	}
 
@@ -328,7 +609,15 @@ func (s *CompareExpContext) Value() IValueContext {
 		return nil
 	}
 
-	return t.(IValueContext)
+	return t.(IArithContext)
+}
+
+func (s *CompareExpContext) AllSP() []antlr.TerminalNode {
+	return s.GetTokens(JsonQueryParserSP)
+}
+
+func (s *CompareExpContext) SP(i int) antlr.TerminalNode {
+	return s.GetToken(JsonQueryParserSP, i)
 }
 
 func (s *CompareExpContext) EQ() antlr.TerminalNode {
@@ -371,6 +660,10 @@ func (s *CompareExpContext) IN() antlr.TerminalNode {
 	return s.GetToken(JsonQueryParserIN, 0)
 }
 
+func (s *CompareExpContext) NOT() antlr.TerminalNode {
+	return s.GetToken(JsonQueryParserNOT, 0)
+}
+
 func (s *CompareExpContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 	switch t := visitor.(type) {
 	case JsonQueryVisitor:
@@ -404,10 +697,10 @@ func (s *RegexExpContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *RegexExpContext) AttrPath() IAttrPathContext {
+func (s *RegexExpContext) Arith() IArithContext {
 	var t antlr.RuleContext
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IAttrPathContext); ok {
+		if _, ok := ctx.(IArithContext); ok {
 			t = ctx.(antlr.RuleContext)
 			break
 		}
@@ -417,7 +710,7 @@ func (s *RegexExpContext) AttrPath() IAttrPathContext {
 		return nil
 	}
 
-	return t.(IAttrPathContext)
+	return t.(IArithContext)
 }
 
 func (s *RegexExpContext) AllSP() []antlr.TerminalNode {
@@ -448,6 +741,10 @@ func (s *RegexExpContext) MT() antlr.TerminalNode {
 	return s.GetToken(JsonQueryParserMT, 0)
 }
 
+func (s *RegexExpContext) NOT() antlr.TerminalNode {
+	return s.GetToken(JsonQueryParserNOT, 0)
+}
+
 func (s *RegexExpContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 	switch t := visitor.(type) {
 	case JsonQueryVisitor:
@@ -458,6 +755,87 @@ func (s *RegexExpContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 	}
 }
 
+type IpClassExpContext struct {
+	QueryContext
+	op antlr.Token
+}
+
+func NewIpClassExpContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *IpClassExpContext {
+	var p = new(IpClassExpContext)
+
+	InitEmptyQueryContext(&p.QueryContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*QueryContext))
+
+	return p
+}
+
+func (s *IpClassExpContext) GetOp() antlr.Token { return s.op }
+
+func (s *IpClassExpContext) SetOp(v antlr.Token) { s.op = v }
+
+func (s *IpClassExpContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *IpClassExpContext) Arith() IArithContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IArithContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IArithContext)
+}
+
+func (s *IpClassExpContext) AllSP() []antlr.TerminalNode {
+	return s.GetTokens(JsonQueryParserSP)
+}
+
+func (s *IpClassExpContext) SP(i int) antlr.TerminalNode {
+	return s.GetToken(JsonQueryParserSP, i)
+}
+
+func (s *IpClassExpContext) IpClass() IIpClassContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IIpClassContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IIpClassContext)
+}
+
+func (s *IpClassExpContext) IS() antlr.TerminalNode {
+	return s.GetToken(JsonQueryParserIS, 0)
+}
+
+func (s *IpClassExpContext) NOT() antlr.TerminalNode {
+	return s.GetToken(JsonQueryParserNOT, 0)
+}
+
+func (s *IpClassExpContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case JsonQueryVisitor:
+		return t.VisitIpClassExp(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
 type ParenExpContext struct {
 	QueryContext
 }
@@ -492,10 +870,6 @@ func (s *ParenExpContext) Query() IQueryContext {
 	return t.(IQueryContext)
 }
 
-func (s *ParenExpContext) NOT() antlr.TerminalNode {
-	return s.GetToken(JsonQueryParserNOT, 0)
-}
-
 func (s *ParenExpContext) AllSP() []antlr.TerminalNode {
 	return s.GetTokens(JsonQueryParserSP)
 }
@@ -585,10 +959,10 @@ func (s *IpCompareExpContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *IpCompareExpContext) AttrPath() IAttrPathContext {
+func (s *IpCompareExpContext) Arith() IArithContext {
 	var t antlr.RuleContext
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IAttrPathContext); ok {
+		if _, ok := ctx.(IArithContext); ok {
 			t = ctx.(antlr.RuleContext)
 			break
 		}
@@ -598,7 +972,7 @@ func (s *IpCompareExpContext) AttrPath() IAttrPathContext {
 		return nil
 	}
 
-	return t.(IAttrPathContext)
+	return t.(IArithContext)
 }
 
 func (s *IpCompareExpContext) AllSP() []antlr.TerminalNode {
@@ -609,6 +983,34 @@ func (s *IpCompareExpContext) SP(i int) antlr.TerminalNode {
 	return s.GetToken(JsonQueryParserSP, i)
 }
 
+func (s *IpCompareExpContext) EQ() antlr.TerminalNode {
+	return s.GetToken(JsonQueryParserEQ, 0)
+}
+
+func (s *IpCompareExpContext) NE() antlr.TerminalNode {
+	return s.GetToken(JsonQueryParserNE, 0)
+}
+
+func (s *IpCompareExpContext) GT() antlr.TerminalNode {
+	return s.GetToken(JsonQueryParserGT, 0)
+}
+
+func (s *IpCompareExpContext) LT() antlr.TerminalNode {
+	return s.GetToken(JsonQueryParserLT, 0)
+}
+
+func (s *IpCompareExpContext) GE() antlr.TerminalNode {
+	return s.GetToken(JsonQueryParserGE, 0)
+}
+
+func (s *IpCompareExpContext) LE() antlr.TerminalNode {
+	return s.GetToken(JsonQueryParserLE, 0)
+}
+
+func (s *IpCompareExpContext) IN() antlr.TerminalNode {
+	return s.GetToken(JsonQueryParserIN, 0)
+}
+
 func (s *IpCompareExpContext) IpValue() IIpValueContext {
 	var t antlr.RuleContext
 	for _, ctx := range s.GetChildren() {
@@ -625,16 +1027,24 @@ func (s *IpCompareExpContext) IpValue() IIpValueContext {
 	return t.(IIpValueContext)
 }
 
-func (s *IpCompareExpContext) EQ() antlr.TerminalNode {
-	return s.GetToken(JsonQueryParserEQ, 0)
-}
+func (s *IpCompareExpContext) ListIPs() IListIPsContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IListIPsContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
 
-func (s *IpCompareExpContext) NE() antlr.TerminalNode {
-	return s.GetToken(JsonQueryParserNE, 0)
+	if t == nil {
+		return nil
+	}
+
+	return t.(IListIPsContext)
 }
 
-func (s *IpCompareExpContext) IN() antlr.TerminalNode {
-	return s.GetToken(JsonQueryParserIN, 0)
+func (s *IpCompareExpContext) NOT() antlr.TerminalNode {
+	return s.GetToken(JsonQueryParserNOT, 0)
 }
 
 func (s *IpCompareExpContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
@@ -649,6 +1059,7 @@ func (s *IpCompareExpContext) Accept(visitor antlr.ParseTreeVisitor) interface{}
 
 type LogicalExpContext struct {
 	QueryContext
+	op antlr.Token
 }
 
 func NewLogicalExpContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *LogicalExpContext {
@@ -661,6 +1072,10 @@ func NewLogicalExpContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *Log
 	return p
 }
 
+func (s *LogicalExpContext) GetOp() antlr.Token { return s.op }
+
+func (s *LogicalExpContext) SetOp(v antlr.Token) { s.op = v }
+
 func (s *LogicalExpContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -714,8 +1129,16 @@ func (s *LogicalExpContext) SP(i int) antlr.TerminalNode {
 	return s.GetToken(JsonQueryParserSP, i)
 }
 
-func (s *LogicalExpContext) LOGICAL_OPERATOR() antlr.TerminalNode {
-	return s.GetToken(JsonQueryParserLOGICAL_OPERATOR, 0)
+func (s *LogicalExpContext) AND() antlr.TerminalNode {
+	return s.GetToken(JsonQueryParserAND, 0)
+}
+
+func (s *LogicalExpContext) XOR() antlr.TerminalNode {
+	return s.GetToken(JsonQueryParserXOR, 0)
+}
+
+func (s *LogicalExpContext) OR() antlr.TerminalNode {
+	return s.GetToken(JsonQueryParserOR, 0)
 }
 
 func (s *LogicalExpContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
@@ -728,6 +1151,179 @@ func (s *LogicalExpContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 	}
 }
 
+type CountExpContext struct {
+	QueryContext
+	op antlr.Token
+}
+
+func NewCountExpContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *CountExpContext {
+	var p = new(CountExpContext)
+
+	InitEmptyQueryContext(&p.QueryContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*QueryContext))
+
+	return p
+}
+
+func (s *CountExpContext) GetOp() antlr.Token { return s.op }
+
+func (s *CountExpContext) SetOp(v antlr.Token) { s.op = v }
+
+func (s *CountExpContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *CountExpContext) ATTRNAME() antlr.TerminalNode {
+	return s.GetToken(JsonQueryParserATTRNAME, 0)
+}
+
+func (s *CountExpContext) AttrPath() IAttrPathContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IAttrPathContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IAttrPathContext)
+}
+
+func (s *CountExpContext) COMMA() antlr.TerminalNode {
+	return s.GetToken(JsonQueryParserCOMMA, 0)
+}
+
+func (s *CountExpContext) Query() IQueryContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IQueryContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IQueryContext)
+}
+
+func (s *CountExpContext) AllSP() []antlr.TerminalNode {
+	return s.GetTokens(JsonQueryParserSP)
+}
+
+func (s *CountExpContext) SP(i int) antlr.TerminalNode {
+	return s.GetToken(JsonQueryParserSP, i)
+}
+
+func (s *CountExpContext) Value() IValueContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IValueContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IValueContext)
+}
+
+func (s *CountExpContext) EQ() antlr.TerminalNode {
+	return s.GetToken(JsonQueryParserEQ, 0)
+}
+
+func (s *CountExpContext) NE() antlr.TerminalNode {
+	return s.GetToken(JsonQueryParserNE, 0)
+}
+
+func (s *CountExpContext) GT() antlr.TerminalNode {
+	return s.GetToken(JsonQueryParserGT, 0)
+}
+
+func (s *CountExpContext) LT() antlr.TerminalNode {
+	return s.GetToken(JsonQueryParserLT, 0)
+}
+
+func (s *CountExpContext) GE() antlr.TerminalNode {
+	return s.GetToken(JsonQueryParserGE, 0)
+}
+
+func (s *CountExpContext) LE() antlr.TerminalNode {
+	return s.GetToken(JsonQueryParserLE, 0)
+}
+
+func (s *CountExpContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case JsonQueryVisitor:
+		return t.VisitCountExp(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+type NotExpContext struct {
+	QueryContext
+}
+
+func NewNotExpContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *NotExpContext {
+	var p = new(NotExpContext)
+
+	InitEmptyQueryContext(&p.QueryContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*QueryContext))
+
+	return p
+}
+
+func (s *NotExpContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *NotExpContext) NOT() antlr.TerminalNode {
+	return s.GetToken(JsonQueryParserNOT, 0)
+}
+
+func (s *NotExpContext) Query() IQueryContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IQueryContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IQueryContext)
+}
+
+func (s *NotExpContext) SP() antlr.TerminalNode {
+	return s.GetToken(JsonQueryParserSP, 0)
+}
+
+func (s *NotExpContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case JsonQueryVisitor:
+		return t.VisitNotExp(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
 func (p *JsonQueryParser) Query() (localctx IQueryContext) {
 	return p.query(0)
 }
@@ -739,44 +1335,26 @@ func (p *JsonQueryParser) query(_p int) (localctx IQueryContext) {
 	localctx = NewQueryContext(p, p.GetParserRuleContext(), _parentState)
 	var _prevctx IQueryContext = localctx
 	var _ antlr.ParserRuleContext = _prevctx // TODO: To prevent unused variable warning.
-	_startState := 0
-	p.EnterRecursionRule(localctx, 0, JsonQueryParserRULE_query, _p)
+	_startState := 2
+	p.EnterRecursionRule(localctx, 2, JsonQueryParserRULE_query, _p)
 	var _la int
 
 	var _alt int
 
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(71)
+	p.SetState(146)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
 	}
 
-	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 4, p.GetParserRuleContext()) {
+	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 17, p.GetParserRuleContext()) {
 	case 1:
 		localctx = NewParenExpContext(p, localctx)
 		p.SetParserRuleContext(localctx)
 		_prevctx = localctx
 
-		p.SetState(34)
-		p.GetErrorHandler().Sync(p)
-		if p.HasError() {
-			goto errorExit
-		}
-		_la = p.GetTokenStream().LA(1)
-
-		if _la == JsonQueryParserNOT {
-			{
-				p.SetState(33)
-				p.Match(JsonQueryParserNOT)
-				if p.HasError() {
-					// Recognition error - abort rule
-					goto errorExit
-				}
-			}
-
-		}
-		p.SetState(37)
+		p.SetState(41)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -785,7 +1363,7 @@ func (p *JsonQueryParser) query(_p int) (localctx IQueryContext) {
 
 		if _la == JsonQueryParserSP {
 			{
-				p.SetState(36)
+				p.SetState(40)
 				p.Match(JsonQueryParserSP)
 				if p.HasError() {
 					// Recognition error - abort rule
@@ -795,19 +1373,19 @@ func (p *JsonQueryParser) query(_p int) (localctx IQueryContext) {
 
 		}
 		{
-			p.SetState(39)
+			p.SetState(43)
 			p.Match(JsonQueryParserT__0)
 			if p.HasError() {
 				// Recognition error - abort rule
 				goto errorExit
 			}
 		}
-		p.SetState(41)
+		p.SetState(45)
 		p.GetErrorHandler().Sync(p)
 
-		if p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 2, p.GetParserRuleContext()) == 1 {
+		if p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 1, p.GetParserRuleContext()) == 1 {
 			{
-				p.SetState(40)
+				p.SetState(44)
 				p.Match(JsonQueryParserSP)
 				if p.HasError() {
 					// Recognition error - abort rule
@@ -819,10 +1397,10 @@ func (p *JsonQueryParser) query(_p int) (localctx IQueryContext) {
 			goto errorExit
 		}
 		{
-			p.SetState(43)
+			p.SetState(47)
 			p.query(0)
 		}
-		p.SetState(45)
+		p.SetState(49)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -831,7 +1409,7 @@ func (p *JsonQueryParser) query(_p int) (localctx IQueryContext) {
 
 		if _la == JsonQueryParserSP {
 			{
-				p.SetState(44)
+				p.SetState(48)
 				p.Match(JsonQueryParserSP)
 				if p.HasError() {
 					// Recognition error - abort rule
@@ -841,7 +1419,7 @@ func (p *JsonQueryParser) query(_p int) (localctx IQueryContext) {
 
 		}
 		{
-			p.SetState(47)
+			p.SetState(51)
 			p.Match(JsonQueryParserT__1)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -850,66 +1428,295 @@ func (p *JsonQueryParser) query(_p int) (localctx IQueryContext) {
 		}
 
 	case 2:
-		localctx = NewPresentExpContext(p, localctx)
+		localctx = NewNotExpContext(p, localctx)
 		p.SetParserRuleContext(localctx)
 		_prevctx = localctx
 		{
-			p.SetState(49)
-			p.AttrPath()
-		}
-		{
-			p.SetState(50)
-			p.Match(JsonQueryParserSP)
+			p.SetState(53)
+			p.Match(JsonQueryParserNOT)
 			if p.HasError() {
 				// Recognition error - abort rule
 				goto errorExit
 			}
 		}
-		{
-			p.SetState(51)
-			p.Match(JsonQueryParserT__2)
-			if p.HasError() {
-				// Recognition error - abort rule
-				goto errorExit
+		p.SetState(55)
+		p.GetErrorHandler().Sync(p)
+
+		if p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 3, p.GetParserRuleContext()) == 1 {
+			{
+				p.SetState(54)
+				p.Match(JsonQueryParserSP)
+				if p.HasError() {
+					// Recognition error - abort rule
+					goto errorExit
+				}
 			}
+
+		} else if p.HasError() { // JIM
+			goto errorExit
+		}
+		{
+			p.SetState(57)
+			p.query(11)
 		}
 
 	case 3:
-		localctx = NewCompareExpContext(p, localctx)
+		localctx = NewQuantifierExpContext(p, localctx)
 		p.SetParserRuleContext(localctx)
 		_prevctx = localctx
 		{
-			p.SetState(53)
-			p.AttrPath()
-		}
-		{
-			p.SetState(54)
-			p.Match(JsonQueryParserSP)
+			p.SetState(58)
+			p.Match(JsonQueryParserATTRNAME)
 			if p.HasError() {
 				// Recognition error - abort rule
 				goto errorExit
 			}
 		}
 		{
-			p.SetState(55)
-
+			p.SetState(59)
+			p.Match(JsonQueryParserT__0)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		p.SetState(61)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
+
+		if _la == JsonQueryParserSP {
+			{
+				p.SetState(60)
+				p.Match(JsonQueryParserSP)
+				if p.HasError() {
+					// Recognition error - abort rule
+					goto errorExit
+				}
+			}
+
+		}
+		{
+			p.SetState(63)
+			p.AttrPath()
+		}
+		p.SetState(65)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
+
+		if _la == JsonQueryParserSP {
+			{
+				p.SetState(64)
+				p.Match(JsonQueryParserSP)
+				if p.HasError() {
+					// Recognition error - abort rule
+					goto errorExit
+				}
+			}
+
+		}
+		{
+			p.SetState(67)
+			p.Match(JsonQueryParserCOMMA)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		p.SetState(69)
+		p.GetErrorHandler().Sync(p)
+
+		if p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 6, p.GetParserRuleContext()) == 1 {
+			{
+				p.SetState(68)
+				p.Match(JsonQueryParserSP)
+				if p.HasError() {
+					// Recognition error - abort rule
+					goto errorExit
+				}
+			}
+
+		} else if p.HasError() { // JIM
+			goto errorExit
+		}
+		{
+			p.SetState(71)
+			p.query(0)
+		}
+		p.SetState(73)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
+
+		if _la == JsonQueryParserSP {
+			{
+				p.SetState(72)
+				p.Match(JsonQueryParserSP)
+				if p.HasError() {
+					// Recognition error - abort rule
+					goto errorExit
+				}
+			}
+
+		}
+		{
+			p.SetState(75)
+			p.Match(JsonQueryParserT__1)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+
+	case 4:
+		localctx = NewCountExpContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+		{
+			p.SetState(77)
+			p.Match(JsonQueryParserATTRNAME)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(78)
+			p.Match(JsonQueryParserT__0)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		p.SetState(80)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
+
+		if _la == JsonQueryParserSP {
+			{
+				p.SetState(79)
+				p.Match(JsonQueryParserSP)
+				if p.HasError() {
+					// Recognition error - abort rule
+					goto errorExit
+				}
+			}
+
+		}
+		{
+			p.SetState(82)
+			p.AttrPath()
+		}
+		p.SetState(84)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
+
+		if _la == JsonQueryParserSP {
+			{
+				p.SetState(83)
+				p.Match(JsonQueryParserSP)
+				if p.HasError() {
+					// Recognition error - abort rule
+					goto errorExit
+				}
+			}
+
+		}
+		{
+			p.SetState(86)
+			p.Match(JsonQueryParserCOMMA)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		p.SetState(88)
+		p.GetErrorHandler().Sync(p)
+
+		if p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 10, p.GetParserRuleContext()) == 1 {
+			{
+				p.SetState(87)
+				p.Match(JsonQueryParserSP)
+				if p.HasError() {
+					// Recognition error - abort rule
+					goto errorExit
+				}
+			}
+
+		} else if p.HasError() { // JIM
+			goto errorExit
+		}
+		{
+			p.SetState(90)
+			p.query(0)
+		}
+		p.SetState(92)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
+
+		if _la == JsonQueryParserSP {
+			{
+				p.SetState(91)
+				p.Match(JsonQueryParserSP)
+				if p.HasError() {
+					// Recognition error - abort rule
+					goto errorExit
+				}
+			}
+
+		}
+		{
+			p.SetState(94)
+			p.Match(JsonQueryParserT__1)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(95)
+			p.Match(JsonQueryParserSP)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(96)
+
 			var _lt = p.GetTokenStream().LT(1)
 
-			localctx.(*CompareExpContext).op = _lt
+			localctx.(*CountExpContext).op = _lt
 
 			_la = p.GetTokenStream().LA(1)
 
-			if !((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&4190208) != 0) {
+			if !((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&1032192) != 0) {
 				var _ri = p.GetErrorHandler().RecoverInline(p)
 
-				localctx.(*CompareExpContext).op = _ri
+				localctx.(*CountExpContext).op = _ri
 			} else {
 				p.GetErrorHandler().ReportMatch(p)
 				p.Consume()
 			}
 		}
 		{
-			p.SetState(56)
+			p.SetState(97)
 			p.Match(JsonQueryParserSP)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -917,20 +1724,20 @@ func (p *JsonQueryParser) query(_p int) (localctx IQueryContext) {
 			}
 		}
 		{
-			p.SetState(57)
+			p.SetState(98)
 			p.Value()
 		}
 
-	case 4:
-		localctx = NewRegexExpContext(p, localctx)
+	case 5:
+		localctx = NewPresentExpContext(p, localctx)
 		p.SetParserRuleContext(localctx)
 		_prevctx = localctx
 		{
-			p.SetState(59)
+			p.SetState(100)
 			p.AttrPath()
 		}
 		{
-			p.SetState(60)
+			p.SetState(101)
 			p.Match(JsonQueryParserSP)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -938,86 +1745,1205 @@ func (p *JsonQueryParser) query(_p int) (localctx IQueryContext) {
 			}
 		}
 		{
-			p.SetState(61)
+			p.SetState(102)
+			p.Match(JsonQueryParserT__2)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
 
-			var _m = p.Match(JsonQueryParserMT)
+	case 6:
+		localctx = NewIpCompareExpContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+		{
+			p.SetState(104)
+			p.arith(0)
+		}
+		{
+			p.SetState(105)
+			p.Match(JsonQueryParserSP)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		p.SetState(108)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
+
+		if _la == JsonQueryParserNOT {
+			{
+				p.SetState(106)
+				p.Match(JsonQueryParserNOT)
+				if p.HasError() {
+					// Recognition error - abort rule
+					goto errorExit
+				}
+			}
+			{
+				p.SetState(107)
+				p.Match(JsonQueryParserSP)
+				if p.HasError() {
+					// Recognition error - abort rule
+					goto errorExit
+				}
+			}
+
+		}
+		{
+			p.SetState(110)
+
+			var _lt = p.GetTokenStream().LT(1)
+
+			localctx.(*IpCompareExpContext).op = _lt
+
+			_la = p.GetTokenStream().LA(1)
+
+			if !((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&1036288) != 0) {
+				var _ri = p.GetErrorHandler().RecoverInline(p)
+
+				localctx.(*IpCompareExpContext).op = _ri
+			} else {
+				p.GetErrorHandler().ReportMatch(p)
+				p.Consume()
+			}
+		}
+		{
+			p.SetState(111)
+			p.Match(JsonQueryParserSP)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		p.SetState(114)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+
+		switch p.GetTokenStream().LA(1) {
+		case JsonQueryParserIP_ADDRESS, JsonQueryParserIP_CIDR, JsonQueryParserIP_RANGE:
+			{
+				p.SetState(112)
+				p.IpValue()
+			}
+
+		case JsonQueryParserT__3:
+			{
+				p.SetState(113)
+				p.ListIPs()
+			}
+
+		default:
+			p.SetError(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+			goto errorExit
+		}
+
+	case 7:
+		localctx = NewCompareExpContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+		{
+			p.SetState(116)
+			p.arith(0)
+		}
+		{
+			p.SetState(117)
+			p.Match(JsonQueryParserSP)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		p.SetState(120)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
+
+		if _la == JsonQueryParserNOT {
+			{
+				p.SetState(118)
+				p.Match(JsonQueryParserNOT)
+				if p.HasError() {
+					// Recognition error - abort rule
+					goto errorExit
+				}
+			}
+			{
+				p.SetState(119)
+				p.Match(JsonQueryParserSP)
+				if p.HasError() {
+					// Recognition error - abort rule
+					goto errorExit
+				}
+			}
+
+		}
+		{
+			p.SetState(122)
+
+			var _lt = p.GetTokenStream().LT(1)
+
+			localctx.(*CompareExpContext).op = _lt
+
+			_la = p.GetTokenStream().LA(1)
+
+			if !((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&8376320) != 0) {
+				var _ri = p.GetErrorHandler().RecoverInline(p)
+
+				localctx.(*CompareExpContext).op = _ri
+			} else {
+				p.GetErrorHandler().ReportMatch(p)
+				p.Consume()
+			}
+		}
+		{
+			p.SetState(123)
+			p.Match(JsonQueryParserSP)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(124)
+			p.arith(0)
+		}
+
+	case 8:
+		localctx = NewRegexExpContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+		{
+			p.SetState(126)
+			p.arith(0)
+		}
+		{
+			p.SetState(127)
+			p.Match(JsonQueryParserSP)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		p.SetState(130)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
+
+		if _la == JsonQueryParserNOT {
+			{
+				p.SetState(128)
+				p.Match(JsonQueryParserNOT)
+				if p.HasError() {
+					// Recognition error - abort rule
+					goto errorExit
+				}
+			}
+			{
+				p.SetState(129)
+				p.Match(JsonQueryParserSP)
+				if p.HasError() {
+					// Recognition error - abort rule
+					goto errorExit
+				}
+			}
+
+		}
+		{
+			p.SetState(132)
+
+			var _m = p.Match(JsonQueryParserMT)
+
+			localctx.(*RegexExpContext).op = _m
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(133)
+			p.Match(JsonQueryParserSP)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(134)
+			p.RegexValue()
+		}
+
+	case 9:
+		localctx = NewIpClassExpContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+		{
+			p.SetState(136)
+			p.arith(0)
+		}
+		{
+			p.SetState(137)
+			p.Match(JsonQueryParserSP)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(138)
+
+			var _m = p.Match(JsonQueryParserIS)
+
+			localctx.(*IpClassExpContext).op = _m
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(139)
+			p.Match(JsonQueryParserSP)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		p.SetState(142)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
+
+		if _la == JsonQueryParserNOT {
+			{
+				p.SetState(140)
+				p.Match(JsonQueryParserNOT)
+				if p.HasError() {
+					// Recognition error - abort rule
+					goto errorExit
+				}
+			}
+			{
+				p.SetState(141)
+				p.Match(JsonQueryParserSP)
+				if p.HasError() {
+					// Recognition error - abort rule
+					goto errorExit
+				}
+			}
+
+		}
+		{
+			p.SetState(144)
+			p.IpClass()
+		}
+
+	case antlr.ATNInvalidAltNumber:
+		goto errorExit
+	}
+	p.GetParserRuleContext().SetStop(p.GetTokenStream().LT(-1))
+	p.SetState(165)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+	_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 19, p.GetParserRuleContext())
+	if p.HasError() {
+		goto errorExit
+	}
+	for _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
+		if _alt == 1 {
+			if p.GetParseListeners() != nil {
+				p.TriggerExitRuleEvent()
+			}
+			_prevctx = localctx
+			p.SetState(163)
+			p.GetErrorHandler().Sync(p)
+			if p.HasError() {
+				goto errorExit
+			}
+
+			switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 18, p.GetParserRuleContext()) {
+			case 1:
+				localctx = NewLogicalExpContext(p, NewQueryContext(p, _parentctx, _parentState))
+				p.PushNewRecursionContext(localctx, _startState, JsonQueryParserRULE_query)
+				p.SetState(148)
+
+				if !(p.Precpred(p.GetParserRuleContext(), 10)) {
+					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 10)", ""))
+					goto errorExit
+				}
+				{
+					p.SetState(149)
+					p.Match(JsonQueryParserSP)
+					if p.HasError() {
+						// Recognition error - abort rule
+						goto errorExit
+					}
+				}
+				{
+					p.SetState(150)
+
+					var _m = p.Match(JsonQueryParserAND)
+
+					localctx.(*LogicalExpContext).op = _m
+					if p.HasError() {
+						// Recognition error - abort rule
+						goto errorExit
+					}
+				}
+				{
+					p.SetState(151)
+					p.Match(JsonQueryParserSP)
+					if p.HasError() {
+						// Recognition error - abort rule
+						goto errorExit
+					}
+				}
+				{
+					p.SetState(152)
+					p.query(11)
+				}
+
+			case 2:
+				localctx = NewLogicalExpContext(p, NewQueryContext(p, _parentctx, _parentState))
+				p.PushNewRecursionContext(localctx, _startState, JsonQueryParserRULE_query)
+				p.SetState(153)
+
+				if !(p.Precpred(p.GetParserRuleContext(), 9)) {
+					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 9)", ""))
+					goto errorExit
+				}
+				{
+					p.SetState(154)
+					p.Match(JsonQueryParserSP)
+					if p.HasError() {
+						// Recognition error - abort rule
+						goto errorExit
+					}
+				}
+				{
+					p.SetState(155)
+
+					var _m = p.Match(JsonQueryParserXOR)
+
+					localctx.(*LogicalExpContext).op = _m
+					if p.HasError() {
+						// Recognition error - abort rule
+						goto errorExit
+					}
+				}
+				{
+					p.SetState(156)
+					p.Match(JsonQueryParserSP)
+					if p.HasError() {
+						// Recognition error - abort rule
+						goto errorExit
+					}
+				}
+				{
+					p.SetState(157)
+					p.query(10)
+				}
+
+			case 3:
+				localctx = NewLogicalExpContext(p, NewQueryContext(p, _parentctx, _parentState))
+				p.PushNewRecursionContext(localctx, _startState, JsonQueryParserRULE_query)
+				p.SetState(158)
+
+				if !(p.Precpred(p.GetParserRuleContext(), 8)) {
+					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 8)", ""))
+					goto errorExit
+				}
+				{
+					p.SetState(159)
+					p.Match(JsonQueryParserSP)
+					if p.HasError() {
+						// Recognition error - abort rule
+						goto errorExit
+					}
+				}
+				{
+					p.SetState(160)
+
+					var _m = p.Match(JsonQueryParserOR)
+
+					localctx.(*LogicalExpContext).op = _m
+					if p.HasError() {
+						// Recognition error - abort rule
+						goto errorExit
+					}
+				}
+				{
+					p.SetState(161)
+					p.Match(JsonQueryParserSP)
+					if p.HasError() {
+						// Recognition error - abort rule
+						goto errorExit
+					}
+				}
+				{
+					p.SetState(162)
+					p.query(9)
+				}
+
+			case antlr.ATNInvalidAltNumber:
+				goto errorExit
+			}
+
+		}
+		p.SetState(167)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 19, p.GetParserRuleContext())
+		if p.HasError() {
+			goto errorExit
+		}
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.UnrollRecursionContexts(_parentctx)
+	return localctx
+	goto errorExit // Trick to prevent compiler error if the label is not used
+}
+
+// IArithContext is an interface to support dynamic dispatch.
+type IArithContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+	// IsArithContext differentiates from other interfaces.
+	IsArithContext()
+}
+
+type ArithContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyArithContext() *ArithContext {
+	var p = new(ArithContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = JsonQueryParserRULE_arith
+	return p
+}
+
+func InitEmptyArithContext(p *ArithContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = JsonQueryParserRULE_arith
+}
+
+func (*ArithContext) IsArithContext() {}
+
+func NewArithContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ArithContext {
+	var p = new(ArithContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = JsonQueryParserRULE_arith
+
+	return p
+}
+
+func (s *ArithContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *ArithContext) CopyAll(ctx *ArithContext) {
+	s.CopyFrom(&ctx.BaseParserRuleContext)
+}
+
+func (s *ArithContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ArithContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+type CallArithContext struct {
+	ArithContext
+}
+
+func NewCallArithContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *CallArithContext {
+	var p = new(CallArithContext)
+
+	InitEmptyArithContext(&p.ArithContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*ArithContext))
+
+	return p
+}
+
+func (s *CallArithContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *CallArithContext) ATTRNAME() antlr.TerminalNode {
+	return s.GetToken(JsonQueryParserATTRNAME, 0)
+}
+
+func (s *CallArithContext) AllSP() []antlr.TerminalNode {
+	return s.GetTokens(JsonQueryParserSP)
+}
+
+func (s *CallArithContext) SP(i int) antlr.TerminalNode {
+	return s.GetToken(JsonQueryParserSP, i)
+}
+
+func (s *CallArithContext) AllArith() []IArithContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IArithContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IArithContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IArithContext); ok {
+			tst[i] = t.(IArithContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *CallArithContext) Arith(i int) IArithContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IArithContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IArithContext)
+}
+
+func (s *CallArithContext) AllCOMMA() []antlr.TerminalNode {
+	return s.GetTokens(JsonQueryParserCOMMA)
+}
+
+func (s *CallArithContext) COMMA(i int) antlr.TerminalNode {
+	return s.GetToken(JsonQueryParserCOMMA, i)
+}
+
+func (s *CallArithContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case JsonQueryVisitor:
+		return t.VisitCallArith(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+type AddArithContext struct {
+	ArithContext
+	op antlr.Token
+}
+
+func NewAddArithContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *AddArithContext {
+	var p = new(AddArithContext)
+
+	InitEmptyArithContext(&p.ArithContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*ArithContext))
+
+	return p
+}
+
+func (s *AddArithContext) GetOp() antlr.Token { return s.op }
+
+func (s *AddArithContext) SetOp(v antlr.Token) { s.op = v }
+
+func (s *AddArithContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *AddArithContext) AllArith() []IArithContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IArithContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IArithContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IArithContext); ok {
+			tst[i] = t.(IArithContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *AddArithContext) Arith(i int) IArithContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IArithContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IArithContext)
+}
+
+func (s *AddArithContext) AllSP() []antlr.TerminalNode {
+	return s.GetTokens(JsonQueryParserSP)
+}
+
+func (s *AddArithContext) SP(i int) antlr.TerminalNode {
+	return s.GetToken(JsonQueryParserSP, i)
+}
+
+func (s *AddArithContext) PLUS() antlr.TerminalNode {
+	return s.GetToken(JsonQueryParserPLUS, 0)
+}
+
+func (s *AddArithContext) MINUS() antlr.TerminalNode {
+	return s.GetToken(JsonQueryParserMINUS, 0)
+}
+
+func (s *AddArithContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case JsonQueryVisitor:
+		return t.VisitAddArith(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+type MulArithContext struct {
+	ArithContext
+	op antlr.Token
+}
+
+func NewMulArithContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *MulArithContext {
+	var p = new(MulArithContext)
+
+	InitEmptyArithContext(&p.ArithContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*ArithContext))
+
+	return p
+}
+
+func (s *MulArithContext) GetOp() antlr.Token { return s.op }
+
+func (s *MulArithContext) SetOp(v antlr.Token) { s.op = v }
+
+func (s *MulArithContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *MulArithContext) AllArith() []IArithContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IArithContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IArithContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IArithContext); ok {
+			tst[i] = t.(IArithContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *MulArithContext) Arith(i int) IArithContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IArithContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IArithContext)
+}
+
+func (s *MulArithContext) AllSP() []antlr.TerminalNode {
+	return s.GetTokens(JsonQueryParserSP)
+}
+
+func (s *MulArithContext) SP(i int) antlr.TerminalNode {
+	return s.GetToken(JsonQueryParserSP, i)
+}
+
+func (s *MulArithContext) STAR() antlr.TerminalNode {
+	return s.GetToken(JsonQueryParserSTAR, 0)
+}
+
+func (s *MulArithContext) SLASH() antlr.TerminalNode {
+	return s.GetToken(JsonQueryParserSLASH, 0)
+}
+
+func (s *MulArithContext) PERCENT() antlr.TerminalNode {
+	return s.GetToken(JsonQueryParserPERCENT, 0)
+}
+
+func (s *MulArithContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case JsonQueryVisitor:
+		return t.VisitMulArith(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+type ValueArithContext struct {
+	ArithContext
+}
+
+func NewValueArithContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *ValueArithContext {
+	var p = new(ValueArithContext)
+
+	InitEmptyArithContext(&p.ArithContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*ArithContext))
+
+	return p
+}
+
+func (s *ValueArithContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ValueArithContext) Value() IValueContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IValueContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IValueContext)
+}
+
+func (s *ValueArithContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case JsonQueryVisitor:
+		return t.VisitValueArith(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+type ParenArithContext struct {
+	ArithContext
+}
+
+func NewParenArithContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *ParenArithContext {
+	var p = new(ParenArithContext)
+
+	InitEmptyArithContext(&p.ArithContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*ArithContext))
+
+	return p
+}
+
+func (s *ParenArithContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ParenArithContext) Arith() IArithContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IArithContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IArithContext)
+}
+
+func (s *ParenArithContext) AllSP() []antlr.TerminalNode {
+	return s.GetTokens(JsonQueryParserSP)
+}
+
+func (s *ParenArithContext) SP(i int) antlr.TerminalNode {
+	return s.GetToken(JsonQueryParserSP, i)
+}
+
+func (s *ParenArithContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case JsonQueryVisitor:
+		return t.VisitParenArith(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *JsonQueryParser) Arith() (localctx IArithContext) {
+	return p.arith(0)
+}
+
+func (p *JsonQueryParser) arith(_p int) (localctx IArithContext) {
+	var _parentctx antlr.ParserRuleContext = p.GetParserRuleContext()
+
+	_parentState := p.GetState()
+	localctx = NewArithContext(p, p.GetParserRuleContext(), _parentState)
+	var _prevctx IArithContext = localctx
+	var _ antlr.ParserRuleContext = _prevctx // TODO: To prevent unused variable warning.
+	_startState := 4
+	p.EnterRecursionRule(localctx, 4, JsonQueryParserRULE_arith, _p)
+	var _la int
+
+	var _alt int
+
+	p.EnterOuterAlt(localctx, 1)
+	p.SetState(210)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+
+	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 28, p.GetParserRuleContext()) {
+	case 1:
+		localctx = NewParenArithContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+
+		{
+			p.SetState(169)
+			p.Match(JsonQueryParserT__0)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		p.SetState(171)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
+
+		if _la == JsonQueryParserSP {
+			{
+				p.SetState(170)
+				p.Match(JsonQueryParserSP)
+				if p.HasError() {
+					// Recognition error - abort rule
+					goto errorExit
+				}
+			}
+
+		}
+		{
+			p.SetState(173)
+			p.arith(0)
+		}
+		p.SetState(175)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
+
+		if _la == JsonQueryParserSP {
+			{
+				p.SetState(174)
+				p.Match(JsonQueryParserSP)
+				if p.HasError() {
+					// Recognition error - abort rule
+					goto errorExit
+				}
+			}
+
+		}
+		{
+			p.SetState(177)
+			p.Match(JsonQueryParserT__1)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+
+	case 2:
+		localctx = NewCallArithContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+		{
+			p.SetState(179)
+			p.Match(JsonQueryParserATTRNAME)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(180)
+			p.Match(JsonQueryParserT__0)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		p.SetState(182)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
+
+		if _la == JsonQueryParserSP {
+			{
+				p.SetState(181)
+				p.Match(JsonQueryParserSP)
+				if p.HasError() {
+					// Recognition error - abort rule
+					goto errorExit
+				}
+			}
+
+		}
+		{
+			p.SetState(184)
+			p.Match(JsonQueryParserT__1)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+
+	case 3:
+		localctx = NewCallArithContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+		{
+			p.SetState(185)
+			p.Match(JsonQueryParserATTRNAME)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(186)
+			p.Match(JsonQueryParserT__0)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		p.SetState(188)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
+
+		if _la == JsonQueryParserSP {
+			{
+				p.SetState(187)
+				p.Match(JsonQueryParserSP)
+				if p.HasError() {
+					// Recognition error - abort rule
+					goto errorExit
+				}
+			}
+
+		}
+		{
+			p.SetState(190)
+			p.arith(0)
+		}
+		p.SetState(201)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 26, p.GetParserRuleContext())
+		if p.HasError() {
+			goto errorExit
+		}
+		for _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
+			if _alt == 1 {
+				p.SetState(192)
+				p.GetErrorHandler().Sync(p)
+				if p.HasError() {
+					goto errorExit
+				}
+				_la = p.GetTokenStream().LA(1)
+
+				if _la == JsonQueryParserSP {
+					{
+						p.SetState(191)
+						p.Match(JsonQueryParserSP)
+						if p.HasError() {
+							// Recognition error - abort rule
+							goto errorExit
+						}
+					}
+
+				}
+				{
+					p.SetState(194)
+					p.Match(JsonQueryParserCOMMA)
+					if p.HasError() {
+						// Recognition error - abort rule
+						goto errorExit
+					}
+				}
+				p.SetState(196)
+				p.GetErrorHandler().Sync(p)
+				if p.HasError() {
+					goto errorExit
+				}
+				_la = p.GetTokenStream().LA(1)
+
+				if _la == JsonQueryParserSP {
+					{
+						p.SetState(195)
+						p.Match(JsonQueryParserSP)
+						if p.HasError() {
+							// Recognition error - abort rule
+							goto errorExit
+						}
+					}
+
+				}
+				{
+					p.SetState(198)
+					p.arith(0)
+				}
 
-			localctx.(*RegexExpContext).op = _m
+			}
+			p.SetState(203)
+			p.GetErrorHandler().Sync(p)
 			if p.HasError() {
-				// Recognition error - abort rule
 				goto errorExit
 			}
-		}
-		{
-			p.SetState(62)
-			p.Match(JsonQueryParserSP)
+			_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 26, p.GetParserRuleContext())
 			if p.HasError() {
-				// Recognition error - abort rule
 				goto errorExit
 			}
 		}
-		{
-			p.SetState(63)
-			p.RegexValue()
+		p.SetState(205)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
 		}
+		_la = p.GetTokenStream().LA(1)
 
-	case 5:
-		localctx = NewIpCompareExpContext(p, localctx)
-		p.SetParserRuleContext(localctx)
-		_prevctx = localctx
-		{
-			p.SetState(65)
-			p.AttrPath()
-		}
-		{
-			p.SetState(66)
-			p.Match(JsonQueryParserSP)
-			if p.HasError() {
-				// Recognition error - abort rule
-				goto errorExit
+		if _la == JsonQueryParserSP {
+			{
+				p.SetState(204)
+				p.Match(JsonQueryParserSP)
+				if p.HasError() {
+					// Recognition error - abort rule
+					goto errorExit
+				}
 			}
-		}
-		{
-			p.SetState(67)
-
-			var _lt = p.GetTokenStream().LT(1)
-
-			localctx.(*IpCompareExpContext).op = _lt
 
-			_la = p.GetTokenStream().LA(1)
-
-			if !((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&28672) != 0) {
-				var _ri = p.GetErrorHandler().RecoverInline(p)
-
-				localctx.(*IpCompareExpContext).op = _ri
-			} else {
-				p.GetErrorHandler().ReportMatch(p)
-				p.Consume()
-			}
 		}
 		{
-			p.SetState(68)
-			p.Match(JsonQueryParserSP)
+			p.SetState(207)
+			p.Match(JsonQueryParserT__1)
 			if p.HasError() {
 				// Recognition error - abort rule
 				goto errorExit
 			}
 		}
+
+	case 4:
+		localctx = NewValueArithContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
 		{
-			p.SetState(69)
-			p.IpValue()
+			p.SetState(209)
+			p.Value()
 		}
 
 	case antlr.ATNInvalidAltNumber:
 		goto errorExit
 	}
 	p.GetParserRuleContext().SetStop(p.GetTokenStream().LT(-1))
-	p.SetState(80)
+	p.SetState(224)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
 	}
-	_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 5, p.GetParserRuleContext())
+	_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 30, p.GetParserRuleContext())
 	if p.HasError() {
 		goto errorExit
 	}
@@ -1027,50 +2953,120 @@ func (p *JsonQueryParser) query(_p int) (localctx IQueryContext) {
 				p.TriggerExitRuleEvent()
 			}
 			_prevctx = localctx
-			localctx = NewLogicalExpContext(p, NewQueryContext(p, _parentctx, _parentState))
-			p.PushNewRecursionContext(localctx, _startState, JsonQueryParserRULE_query)
-			p.SetState(73)
-
-			if !(p.Precpred(p.GetParserRuleContext(), 5)) {
-				p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 5)", ""))
+			p.SetState(222)
+			p.GetErrorHandler().Sync(p)
+			if p.HasError() {
 				goto errorExit
 			}
-			{
-				p.SetState(74)
-				p.Match(JsonQueryParserSP)
-				if p.HasError() {
-					// Recognition error - abort rule
+
+			switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 29, p.GetParserRuleContext()) {
+			case 1:
+				localctx = NewMulArithContext(p, NewArithContext(p, _parentctx, _parentState))
+				p.PushNewRecursionContext(localctx, _startState, JsonQueryParserRULE_arith)
+				p.SetState(212)
+
+				if !(p.Precpred(p.GetParserRuleContext(), 3)) {
+					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 3)", ""))
 					goto errorExit
 				}
-			}
-			{
-				p.SetState(75)
-				p.Match(JsonQueryParserLOGICAL_OPERATOR)
-				if p.HasError() {
-					// Recognition error - abort rule
-					goto errorExit
+				{
+					p.SetState(213)
+					p.Match(JsonQueryParserSP)
+					if p.HasError() {
+						// Recognition error - abort rule
+						goto errorExit
+					}
 				}
-			}
-			{
-				p.SetState(76)
-				p.Match(JsonQueryParserSP)
-				if p.HasError() {
-					// Recognition error - abort rule
+				{
+					p.SetState(214)
+
+					var _lt = p.GetTokenStream().LT(1)
+
+					localctx.(*MulArithContext).op = _lt
+
+					_la = p.GetTokenStream().LA(1)
+
+					if !((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&469762048) != 0) {
+						var _ri = p.GetErrorHandler().RecoverInline(p)
+
+						localctx.(*MulArithContext).op = _ri
+					} else {
+						p.GetErrorHandler().ReportMatch(p)
+						p.Consume()
+					}
+				}
+				{
+					p.SetState(215)
+					p.Match(JsonQueryParserSP)
+					if p.HasError() {
+						// Recognition error - abort rule
+						goto errorExit
+					}
+				}
+				{
+					p.SetState(216)
+					p.arith(4)
+				}
+
+			case 2:
+				localctx = NewAddArithContext(p, NewArithContext(p, _parentctx, _parentState))
+				p.PushNewRecursionContext(localctx, _startState, JsonQueryParserRULE_arith)
+				p.SetState(217)
+
+				if !(p.Precpred(p.GetParserRuleContext(), 2)) {
+					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 2)", ""))
 					goto errorExit
 				}
-			}
-			{
-				p.SetState(77)
-				p.query(6)
+				{
+					p.SetState(218)
+					p.Match(JsonQueryParserSP)
+					if p.HasError() {
+						// Recognition error - abort rule
+						goto errorExit
+					}
+				}
+				{
+					p.SetState(219)
+
+					var _lt = p.GetTokenStream().LT(1)
+
+					localctx.(*AddArithContext).op = _lt
+
+					_la = p.GetTokenStream().LA(1)
+
+					if !(_la == JsonQueryParserPLUS || _la == JsonQueryParserMINUS) {
+						var _ri = p.GetErrorHandler().RecoverInline(p)
+
+						localctx.(*AddArithContext).op = _ri
+					} else {
+						p.GetErrorHandler().ReportMatch(p)
+						p.Consume()
+					}
+				}
+				{
+					p.SetState(220)
+					p.Match(JsonQueryParserSP)
+					if p.HasError() {
+						// Recognition error - abort rule
+						goto errorExit
+					}
+				}
+				{
+					p.SetState(221)
+					p.arith(3)
+				}
+
+			case antlr.ATNInvalidAltNumber:
+				goto errorExit
 			}
 
 		}
-		p.SetState(82)
+		p.SetState(226)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
 		}
-		_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 5, p.GetParserRuleContext())
+		_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 30, p.GetParserRuleContext())
 		if p.HasError() {
 			goto errorExit
 		}
@@ -1098,7 +3094,8 @@ type IAttrPathContext interface {
 
 	// Getter signatures
 	ATTRNAME() antlr.TerminalNode
-	SubAttr() ISubAttrContext
+	AllSubAttr() []ISubAttrContext
+	SubAttr(i int) ISubAttrContext
 
 	// IsAttrPathContext differentiates from other interfaces.
 	IsAttrPathContext()
@@ -1140,12 +3137,37 @@ func (s *AttrPathContext) ATTRNAME() antlr.TerminalNode {
 	return s.GetToken(JsonQueryParserATTRNAME, 0)
 }
 
-func (s *AttrPathContext) SubAttr() ISubAttrContext {
+func (s *AttrPathContext) AllSubAttr() []ISubAttrContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(ISubAttrContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]ISubAttrContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(ISubAttrContext); ok {
+			tst[i] = t.(ISubAttrContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *AttrPathContext) SubAttr(i int) ISubAttrContext {
 	var t antlr.RuleContext
+	j := 0
 	for _, ctx := range s.GetChildren() {
 		if _, ok := ctx.(ISubAttrContext); ok {
-			t = ctx.(antlr.RuleContext)
-			break
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
 		}
 	}
 
@@ -1176,31 +3198,36 @@ func (s *AttrPathContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 
 func (p *JsonQueryParser) AttrPath() (localctx IAttrPathContext) {
 	localctx = NewAttrPathContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 2, JsonQueryParserRULE_attrPath)
+	p.EnterRule(localctx, 6, JsonQueryParserRULE_attrPath)
 	var _la int
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(83)
+		p.SetState(227)
 		p.Match(JsonQueryParserATTRNAME)
 		if p.HasError() {
 			// Recognition error - abort rule
 			goto errorExit
 		}
 	}
-	p.SetState(85)
+	p.SetState(231)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
 	}
 	_la = p.GetTokenStream().LA(1)
 
-	if _la == JsonQueryParserT__3 {
+	for _la == JsonQueryParserT__3 || _la == JsonQueryParserJSON_SEP {
 		{
-			p.SetState(84)
+			p.SetState(228)
 			p.SubAttr()
 		}
-
+		p.SetState(233)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
 	}
 
 errorExit:
@@ -1225,7 +3252,8 @@ type IValueAttrPathContext interface {
 
 	// Getter signatures
 	ATTRNAME() antlr.TerminalNode
-	ValueSubAttr() IValueSubAttrContext
+	AllSubAttr() []ISubAttrContext
+	SubAttr(i int) ISubAttrContext
 
 	// IsValueAttrPathContext differentiates from other interfaces.
 	IsValueAttrPathContext()
@@ -1267,12 +3295,37 @@ func (s *ValueAttrPathContext) ATTRNAME() antlr.TerminalNode {
 	return s.GetToken(JsonQueryParserATTRNAME, 0)
 }
 
-func (s *ValueAttrPathContext) ValueSubAttr() IValueSubAttrContext {
+func (s *ValueAttrPathContext) AllSubAttr() []ISubAttrContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(ISubAttrContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]ISubAttrContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(ISubAttrContext); ok {
+			tst[i] = t.(ISubAttrContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *ValueAttrPathContext) SubAttr(i int) ISubAttrContext {
 	var t antlr.RuleContext
+	j := 0
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IValueSubAttrContext); ok {
-			t = ctx.(antlr.RuleContext)
-			break
+		if _, ok := ctx.(ISubAttrContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
 		}
 	}
 
@@ -1280,7 +3333,7 @@ func (s *ValueAttrPathContext) ValueSubAttr() IValueSubAttrContext {
 		return nil
 	}
 
-	return t.(IValueSubAttrContext)
+	return t.(ISubAttrContext)
 }
 
 func (s *ValueAttrPathContext) GetRuleContext() antlr.RuleContext {
@@ -1303,27 +3356,44 @@ func (s *ValueAttrPathContext) Accept(visitor antlr.ParseTreeVisitor) interface{
 
 func (p *JsonQueryParser) ValueAttrPath() (localctx IValueAttrPathContext) {
 	localctx = NewValueAttrPathContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 4, JsonQueryParserRULE_valueAttrPath)
+	p.EnterRule(localctx, 8, JsonQueryParserRULE_valueAttrPath)
+	var _alt int
+
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(87)
+		p.SetState(234)
 		p.Match(JsonQueryParserATTRNAME)
 		if p.HasError() {
 			// Recognition error - abort rule
 			goto errorExit
 		}
 	}
-	p.SetState(89)
+	p.SetState(238)
 	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+	_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 32, p.GetParserRuleContext())
+	if p.HasError() {
+		goto errorExit
+	}
+	for _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
+		if _alt == 1 {
+			{
+				p.SetState(235)
+				p.SubAttr()
+			}
 
-	if p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 7, p.GetParserRuleContext()) == 1 {
-		{
-			p.SetState(88)
-			p.ValueSubAttr()
 		}
-
-	} else if p.HasError() { // JIM
-		goto errorExit
+		p.SetState(240)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 32, p.GetParserRuleContext())
+		if p.HasError() {
+			goto errorExit
+		}
 	}
 
 errorExit:
@@ -1345,10 +3415,6 @@ type ISubAttrContext interface {
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
-
-	// Getter signatures
-	AttrPath() IAttrPathContext
-
 	// IsSubAttrContext differentiates from other interfaces.
 	IsSubAttrContext()
 }
@@ -1385,20 +3451,8 @@ func NewSubAttrContext(parser antlr.Parser, parent antlr.ParserRuleContext, invo
 
 func (s *SubAttrContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *SubAttrContext) AttrPath() IAttrPathContext {
-	var t antlr.RuleContext
-	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IAttrPathContext); ok {
-			t = ctx.(antlr.RuleContext)
-			break
-		}
-	}
-
-	if t == nil {
-		return nil
-	}
-
-	return t.(IAttrPathContext)
+func (s *SubAttrContext) CopyAll(ctx *SubAttrContext) {
+	s.CopyFrom(&ctx.BaseParserRuleContext)
 }
 
 func (s *SubAttrContext) GetRuleContext() antlr.RuleContext {
@@ -1409,141 +3463,278 @@ func (s *SubAttrContext) ToStringTree(ruleNames []string, recog antlr.Recognizer
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *SubAttrContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+type IndexAttrContext struct {
+	SubAttrContext
+}
+
+func NewIndexAttrContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *IndexAttrContext {
+	var p = new(IndexAttrContext)
+
+	InitEmptySubAttrContext(&p.SubAttrContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*SubAttrContext))
+
+	return p
+}
+
+func (s *IndexAttrContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *IndexAttrContext) INT() antlr.TerminalNode {
+	return s.GetToken(JsonQueryParserINT, 0)
+}
+
+func (s *IndexAttrContext) MINUS() antlr.TerminalNode {
+	return s.GetToken(JsonQueryParserMINUS, 0)
+}
+
+func (s *IndexAttrContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 	switch t := visitor.(type) {
 	case JsonQueryVisitor:
-		return t.VisitSubAttr(s)
+		return t.VisitIndexAttr(s)
 
 	default:
 		return t.VisitChildren(s)
 	}
 }
 
-func (p *JsonQueryParser) SubAttr() (localctx ISubAttrContext) {
-	localctx = NewSubAttrContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 6, JsonQueryParserRULE_subAttr)
-	p.EnterOuterAlt(localctx, 1)
-	{
-		p.SetState(91)
-		p.Match(JsonQueryParserT__3)
-		if p.HasError() {
-			// Recognition error - abort rule
-			goto errorExit
-		}
-	}
-	{
-		p.SetState(92)
-		p.AttrPath()
-	}
-
-errorExit:
-	if p.HasError() {
-		v := p.GetError()
-		localctx.SetException(v)
-		p.GetErrorHandler().ReportError(p, v)
-		p.GetErrorHandler().Recover(p, v)
-		p.SetError(nil)
-	}
-	p.ExitRule()
-	return localctx
-	goto errorExit // Trick to prevent compiler error if the label is not used
+type WildcardAttrContext struct {
+	SubAttrContext
 }
 
-// IValueSubAttrContext is an interface to support dynamic dispatch.
-type IValueSubAttrContext interface {
-	antlr.ParserRuleContext
+func NewWildcardAttrContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *WildcardAttrContext {
+	var p = new(WildcardAttrContext)
 
-	// GetParser returns the parser.
-	GetParser() antlr.Parser
+	InitEmptySubAttrContext(&p.SubAttrContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*SubAttrContext))
 
-	// Getter signatures
-	ValueAttrPath() IValueAttrPathContext
+	return p
+}
 
-	// IsValueSubAttrContext differentiates from other interfaces.
-	IsValueSubAttrContext()
+func (s *WildcardAttrContext) GetRuleContext() antlr.RuleContext {
+	return s
 }
 
-type ValueSubAttrContext struct {
-	antlr.BaseParserRuleContext
-	parser antlr.Parser
+func (s *WildcardAttrContext) STAR() antlr.TerminalNode {
+	return s.GetToken(JsonQueryParserSTAR, 0)
+}
+
+func (s *WildcardAttrContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case JsonQueryVisitor:
+		return t.VisitWildcardAttr(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+type FieldAttrContext struct {
+	SubAttrContext
 }
 
-func NewEmptyValueSubAttrContext() *ValueSubAttrContext {
-	var p = new(ValueSubAttrContext)
-	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
-	p.RuleIndex = JsonQueryParserRULE_valueSubAttr
+func NewFieldAttrContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *FieldAttrContext {
+	var p = new(FieldAttrContext)
+
+	InitEmptySubAttrContext(&p.SubAttrContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*SubAttrContext))
+
 	return p
 }
 
-func InitEmptyValueSubAttrContext(p *ValueSubAttrContext) {
-	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
-	p.RuleIndex = JsonQueryParserRULE_valueSubAttr
+func (s *FieldAttrContext) GetRuleContext() antlr.RuleContext {
+	return s
 }
 
-func (*ValueSubAttrContext) IsValueSubAttrContext() {}
-
-func NewValueSubAttrContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ValueSubAttrContext {
-	var p = new(ValueSubAttrContext)
+func (s *FieldAttrContext) JSON_SEP() antlr.TerminalNode {
+	return s.GetToken(JsonQueryParserJSON_SEP, 0)
+}
 
-	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+func (s *FieldAttrContext) ATTRNAME() antlr.TerminalNode {
+	return s.GetToken(JsonQueryParserATTRNAME, 0)
+}
 
-	p.parser = parser
-	p.RuleIndex = JsonQueryParserRULE_valueSubAttr
+func (s *FieldAttrContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case JsonQueryVisitor:
+		return t.VisitFieldAttr(s)
 
-	return p
+	default:
+		return t.VisitChildren(s)
+	}
 }
 
-func (s *ValueSubAttrContext) GetParser() antlr.Parser { return s.parser }
+type KeyAttrContext struct {
+	SubAttrContext
+}
 
-func (s *ValueSubAttrContext) ValueAttrPath() IValueAttrPathContext {
-	var t antlr.RuleContext
-	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IValueAttrPathContext); ok {
-			t = ctx.(antlr.RuleContext)
-			break
-		}
-	}
+func NewKeyAttrContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *KeyAttrContext {
+	var p = new(KeyAttrContext)
 
-	if t == nil {
-		return nil
-	}
+	InitEmptySubAttrContext(&p.SubAttrContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*SubAttrContext))
 
-	return t.(IValueAttrPathContext)
+	return p
 }
 
-func (s *ValueSubAttrContext) GetRuleContext() antlr.RuleContext {
+func (s *KeyAttrContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *ValueSubAttrContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
-	return antlr.TreesStringTree(s, ruleNames, recog)
+func (s *KeyAttrContext) STRING() antlr.TerminalNode {
+	return s.GetToken(JsonQueryParserSTRING, 0)
 }
 
-func (s *ValueSubAttrContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+func (s *KeyAttrContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 	switch t := visitor.(type) {
 	case JsonQueryVisitor:
-		return t.VisitValueSubAttr(s)
+		return t.VisitKeyAttr(s)
 
 	default:
 		return t.VisitChildren(s)
 	}
 }
 
-func (p *JsonQueryParser) ValueSubAttr() (localctx IValueSubAttrContext) {
-	localctx = NewValueSubAttrContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 8, JsonQueryParserRULE_valueSubAttr)
-	p.EnterOuterAlt(localctx, 1)
-	{
-		p.SetState(94)
-		p.Match(JsonQueryParserT__3)
+func (p *JsonQueryParser) SubAttr() (localctx ISubAttrContext) {
+	localctx = NewSubAttrContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 10, JsonQueryParserRULE_subAttr)
+	var _la int
+
+	p.SetState(255)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+
+	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 34, p.GetParserRuleContext()) {
+	case 1:
+		localctx = NewFieldAttrContext(p, localctx)
+		p.EnterOuterAlt(localctx, 1)
+		{
+			p.SetState(241)
+			p.Match(JsonQueryParserJSON_SEP)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(242)
+			p.Match(JsonQueryParserATTRNAME)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+
+	case 2:
+		localctx = NewIndexAttrContext(p, localctx)
+		p.EnterOuterAlt(localctx, 2)
+		{
+			p.SetState(243)
+			p.Match(JsonQueryParserT__3)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		p.SetState(245)
+		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
-			// Recognition error - abort rule
 			goto errorExit
 		}
-	}
-	{
-		p.SetState(95)
-		p.ValueAttrPath()
+		_la = p.GetTokenStream().LA(1)
+
+		if _la == JsonQueryParserMINUS {
+			{
+				p.SetState(244)
+				p.Match(JsonQueryParserMINUS)
+				if p.HasError() {
+					// Recognition error - abort rule
+					goto errorExit
+				}
+			}
+
+		}
+		{
+			p.SetState(247)
+			p.Match(JsonQueryParserINT)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(248)
+			p.Match(JsonQueryParserT__4)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+
+	case 3:
+		localctx = NewWildcardAttrContext(p, localctx)
+		p.EnterOuterAlt(localctx, 3)
+		{
+			p.SetState(249)
+			p.Match(JsonQueryParserT__3)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(250)
+			p.Match(JsonQueryParserSTAR)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(251)
+			p.Match(JsonQueryParserT__4)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+
+	case 4:
+		localctx = NewKeyAttrContext(p, localctx)
+		p.EnterOuterAlt(localctx, 4)
+		{
+			p.SetState(252)
+			p.Match(JsonQueryParserT__3)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(253)
+			p.Match(JsonQueryParserSTRING)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(254)
+			p.Match(JsonQueryParserT__4)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+
+	case antlr.ATNInvalidAltNumber:
+		goto errorExit
 	}
 
 errorExit:
@@ -1927,6 +4118,10 @@ func (s *LongContext) INT() antlr.TerminalNode {
 	return s.GetToken(JsonQueryParserINT, 0)
 }
 
+func (s *LongContext) MINUS() antlr.TerminalNode {
+	return s.GetToken(JsonQueryParserMINUS, 0)
+}
+
 func (s *LongContext) EXP() antlr.TerminalNode {
 	return s.GetToken(JsonQueryParserEXP, 0)
 }
@@ -1987,21 +4182,21 @@ func (s *ListOfIntsContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 
 func (p *JsonQueryParser) Value() (localctx IValueContext) {
 	localctx = NewValueContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 10, JsonQueryParserRULE_value)
+	p.EnterRule(localctx, 12, JsonQueryParserRULE_value)
 	var _la int
 
-	p.SetState(113)
+	p.SetState(273)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
 	}
 
-	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 10, p.GetParserRuleContext()) {
+	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 37, p.GetParserRuleContext()) {
 	case 1:
 		localctx = NewBooleanContext(p, localctx)
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(97)
+			p.SetState(257)
 			p.Match(JsonQueryParserBOOLEAN)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -2013,7 +4208,7 @@ func (p *JsonQueryParser) Value() (localctx IValueContext) {
 		localctx = NewNullContext(p, localctx)
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(98)
+			p.SetState(258)
 			p.Match(JsonQueryParserNULL)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -2025,7 +4220,7 @@ func (p *JsonQueryParser) Value() (localctx IValueContext) {
 		localctx = NewVersionContext(p, localctx)
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(99)
+			p.SetState(259)
 			p.Match(JsonQueryParserVERSION)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -2037,7 +4232,7 @@ func (p *JsonQueryParser) Value() (localctx IValueContext) {
 		localctx = NewStringContext(p, localctx)
 		p.EnterOuterAlt(localctx, 4)
 		{
-			p.SetState(100)
+			p.SetState(260)
 			p.Match(JsonQueryParserSTRING)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -2049,7 +4244,7 @@ func (p *JsonQueryParser) Value() (localctx IValueContext) {
 		localctx = NewDoubleContext(p, localctx)
 		p.EnterOuterAlt(localctx, 5)
 		{
-			p.SetState(101)
+			p.SetState(261)
 			p.Match(JsonQueryParserDOUBLE)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -2060,17 +4255,17 @@ func (p *JsonQueryParser) Value() (localctx IValueContext) {
 	case 6:
 		localctx = NewLongContext(p, localctx)
 		p.EnterOuterAlt(localctx, 6)
-		p.SetState(103)
+		p.SetState(263)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
 		}
 		_la = p.GetTokenStream().LA(1)
 
-		if _la == JsonQueryParserT__4 {
+		if _la == JsonQueryParserMINUS {
 			{
-				p.SetState(102)
-				p.Match(JsonQueryParserT__4)
+				p.SetState(262)
+				p.Match(JsonQueryParserMINUS)
 				if p.HasError() {
 					// Recognition error - abort rule
 					goto errorExit
@@ -2079,19 +4274,19 @@ func (p *JsonQueryParser) Value() (localctx IValueContext) {
 
 		}
 		{
-			p.SetState(105)
+			p.SetState(265)
 			p.Match(JsonQueryParserINT)
 			if p.HasError() {
 				// Recognition error - abort rule
 				goto errorExit
 			}
 		}
-		p.SetState(107)
+		p.SetState(267)
 		p.GetErrorHandler().Sync(p)
 
-		if p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 9, p.GetParserRuleContext()) == 1 {
+		if p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 36, p.GetParserRuleContext()) == 1 {
 			{
-				p.SetState(106)
+				p.SetState(266)
 				p.Match(JsonQueryParserEXP)
 				if p.HasError() {
 					// Recognition error - abort rule
@@ -2107,7 +4302,7 @@ func (p *JsonQueryParser) Value() (localctx IValueContext) {
 		localctx = NewListOfIntsContext(p, localctx)
 		p.EnterOuterAlt(localctx, 7)
 		{
-			p.SetState(109)
+			p.SetState(269)
 			p.ListInts()
 		}
 
@@ -2115,7 +4310,7 @@ func (p *JsonQueryParser) Value() (localctx IValueContext) {
 		localctx = NewListOfDoublesContext(p, localctx)
 		p.EnterOuterAlt(localctx, 8)
 		{
-			p.SetState(110)
+			p.SetState(270)
 			p.ListDoubles()
 		}
 
@@ -2123,7 +4318,7 @@ func (p *JsonQueryParser) Value() (localctx IValueContext) {
 		localctx = NewListOfStringsContext(p, localctx)
 		p.EnterOuterAlt(localctx, 9)
 		{
-			p.SetState(111)
+			p.SetState(271)
 			p.ListStrings()
 		}
 
@@ -2131,7 +4326,7 @@ func (p *JsonQueryParser) Value() (localctx IValueContext) {
 		localctx = NewVariableContext(p, localctx)
 		p.EnterOuterAlt(localctx, 10)
 		{
-			p.SetState(112)
+			p.SetState(272)
 			p.ValueAttrPath()
 		}
 
@@ -2239,8 +4434,8 @@ func (s *RegexValueContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 
 func (p *JsonQueryParser) RegexValue() (localctx IRegexValueContext) {
 	localctx = NewRegexValueContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 12, JsonQueryParserRULE_regexValue)
-	p.SetState(117)
+	p.EnterRule(localctx, 14, JsonQueryParserRULE_regexValue)
+	p.SetState(277)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -2250,7 +4445,7 @@ func (p *JsonQueryParser) RegexValue() (localctx IRegexValueContext) {
 	case JsonQueryParserREGEX:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(115)
+			p.SetState(275)
 			p.Match(JsonQueryParserREGEX)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -2261,7 +4456,7 @@ func (p *JsonQueryParser) RegexValue() (localctx IRegexValueContext) {
 	case JsonQueryParserATTRNAME:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(116)
+			p.SetState(276)
 			p.ValueAttrPath()
 		}
 
@@ -2283,6 +4478,100 @@ errorExit:
 	goto errorExit // Trick to prevent compiler error if the label is not used
 }
 
+// IIpClassContext is an interface to support dynamic dispatch.
+type IIpClassContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	ATTRNAME() antlr.TerminalNode
+
+	// IsIpClassContext differentiates from other interfaces.
+	IsIpClassContext()
+}
+
+type IpClassContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyIpClassContext() *IpClassContext {
+	var p = new(IpClassContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = JsonQueryParserRULE_ipClass
+	return p
+}
+
+func InitEmptyIpClassContext(p *IpClassContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = JsonQueryParserRULE_ipClass
+}
+
+func (*IpClassContext) IsIpClassContext() {}
+
+func NewIpClassContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *IpClassContext {
+	var p = new(IpClassContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = JsonQueryParserRULE_ipClass
+
+	return p
+}
+
+func (s *IpClassContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *IpClassContext) ATTRNAME() antlr.TerminalNode {
+	return s.GetToken(JsonQueryParserATTRNAME, 0)
+}
+
+func (s *IpClassContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *IpClassContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *IpClassContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case JsonQueryVisitor:
+		return t.VisitIpClass(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *JsonQueryParser) IpClass() (localctx IIpClassContext) {
+	localctx = NewIpClassContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 16, JsonQueryParserRULE_ipClass)
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(279)
+		p.Match(JsonQueryParserATTRNAME)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit // Trick to prevent compiler error if the label is not used
+}
+
 // IIpValueContext is an interface to support dynamic dispatch.
 type IIpValueContext interface {
 	antlr.ParserRuleContext
@@ -2293,6 +4582,7 @@ type IIpValueContext interface {
 	// Getter signatures
 	IP_ADDRESS() antlr.TerminalNode
 	IP_CIDR() antlr.TerminalNode
+	IP_RANGE() antlr.TerminalNode
 
 	// IsIpValueContext differentiates from other interfaces.
 	IsIpValueContext()
@@ -2338,6 +4628,10 @@ func (s *IpValueContext) IP_CIDR() antlr.TerminalNode {
 	return s.GetToken(JsonQueryParserIP_CIDR, 0)
 }
 
+func (s *IpValueContext) IP_RANGE() antlr.TerminalNode {
+	return s.GetToken(JsonQueryParserIP_RANGE, 0)
+}
+
 func (s *IpValueContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -2358,15 +4652,15 @@ func (s *IpValueContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 
 func (p *JsonQueryParser) IpValue() (localctx IIpValueContext) {
 	localctx = NewIpValueContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 14, JsonQueryParserRULE_ipValue)
+	p.EnterRule(localctx, 18, JsonQueryParserRULE_ipValue)
 	var _la int
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(119)
+		p.SetState(281)
 		_la = p.GetTokenStream().LA(1)
 
-		if !(_la == JsonQueryParserIP_ADDRESS || _la == JsonQueryParserIP_CIDR) {
+		if !((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&120259084288) != 0) {
 			p.GetErrorHandler().RecoverInline(p)
 		} else {
 			p.GetErrorHandler().ReportMatch(p)
@@ -2469,18 +4763,18 @@ func (s *ListIPsContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 
 func (p *JsonQueryParser) ListIPs() (localctx IListIPsContext) {
 	localctx = NewListIPsContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 16, JsonQueryParserRULE_listIPs)
+	p.EnterRule(localctx, 20, JsonQueryParserRULE_listIPs)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(121)
-		p.Match(JsonQueryParserT__5)
+		p.SetState(283)
+		p.Match(JsonQueryParserT__3)
 		if p.HasError() {
 			// Recognition error - abort rule
 			goto errorExit
 		}
 	}
 	{
-		p.SetState(122)
+		p.SetState(284)
 		p.SubListOfIPs()
 	}
 
@@ -2601,22 +4895,22 @@ func (s *SubListOfIPsContext) Accept(visitor antlr.ParseTreeVisitor) interface{}
 
 func (p *JsonQueryParser) SubListOfIPs() (localctx ISubListOfIPsContext) {
 	localctx = NewSubListOfIPsContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 18, JsonQueryParserRULE_subListOfIPs)
-	p.SetState(131)
+	p.EnterRule(localctx, 22, JsonQueryParserRULE_subListOfIPs)
+	p.SetState(293)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
 	}
 
-	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 12, p.GetParserRuleContext()) {
+	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 39, p.GetParserRuleContext()) {
 	case 1:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(124)
+			p.SetState(286)
 			p.IpValue()
 		}
 		{
-			p.SetState(125)
+			p.SetState(287)
 			p.Match(JsonQueryParserCOMMA)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -2624,19 +4918,19 @@ func (p *JsonQueryParser) SubListOfIPs() (localctx ISubListOfIPsContext) {
 			}
 		}
 		{
-			p.SetState(126)
+			p.SetState(288)
 			p.SubListOfIPs()
 		}
 
 	case 2:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(128)
+			p.SetState(290)
 			p.IpValue()
 		}
 		{
-			p.SetState(129)
-			p.Match(JsonQueryParserT__6)
+			p.SetState(291)
+			p.Match(JsonQueryParserT__4)
 			if p.HasError() {
 				// Recognition error - abort rule
 				goto errorExit
@@ -2742,18 +5036,18 @@ func (s *ListStringsContext) Accept(visitor antlr.ParseTreeVisitor) interface{}
 
 func (p *JsonQueryParser) ListStrings() (localctx IListStringsContext) {
 	localctx = NewListStringsContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 20, JsonQueryParserRULE_listStrings)
+	p.EnterRule(localctx, 24, JsonQueryParserRULE_listStrings)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(133)
-		p.Match(JsonQueryParserT__5)
+		p.SetState(295)
+		p.Match(JsonQueryParserT__3)
 		if p.HasError() {
 			// Recognition error - abort rule
 			goto errorExit
 		}
 	}
 	{
-		p.SetState(134)
+		p.SetState(296)
 		p.SubListOfStrings()
 	}
 
@@ -2862,18 +5156,18 @@ func (s *SubListOfStringsContext) Accept(visitor antlr.ParseTreeVisitor) interfa
 
 func (p *JsonQueryParser) SubListOfStrings() (localctx ISubListOfStringsContext) {
 	localctx = NewSubListOfStringsContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 22, JsonQueryParserRULE_subListOfStrings)
-	p.SetState(141)
+	p.EnterRule(localctx, 26, JsonQueryParserRULE_subListOfStrings)
+	p.SetState(303)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
 	}
 
-	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 13, p.GetParserRuleContext()) {
+	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 40, p.GetParserRuleContext()) {
 	case 1:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(136)
+			p.SetState(298)
 			p.Match(JsonQueryParserSTRING)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -2881,7 +5175,7 @@ func (p *JsonQueryParser) SubListOfStrings() (localctx ISubListOfStringsContext)
 			}
 		}
 		{
-			p.SetState(137)
+			p.SetState(299)
 			p.Match(JsonQueryParserCOMMA)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -2889,14 +5183,14 @@ func (p *JsonQueryParser) SubListOfStrings() (localctx ISubListOfStringsContext)
 			}
 		}
 		{
-			p.SetState(138)
+			p.SetState(300)
 			p.SubListOfStrings()
 		}
 
 	case 2:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(139)
+			p.SetState(301)
 			p.Match(JsonQueryParserSTRING)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -2904,8 +5198,8 @@ func (p *JsonQueryParser) SubListOfStrings() (localctx ISubListOfStringsContext)
 			}
 		}
 		{
-			p.SetState(140)
-			p.Match(JsonQueryParserT__6)
+			p.SetState(302)
+			p.Match(JsonQueryParserT__4)
 			if p.HasError() {
 				// Recognition error - abort rule
 				goto errorExit
@@ -3011,18 +5305,18 @@ func (s *ListDoublesContext) Accept(visitor antlr.ParseTreeVisitor) interface{}
 
 func (p *JsonQueryParser) ListDoubles() (localctx IListDoublesContext) {
 	localctx = NewListDoublesContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 24, JsonQueryParserRULE_listDoubles)
+	p.EnterRule(localctx, 28, JsonQueryParserRULE_listDoubles)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(143)
-		p.Match(JsonQueryParserT__5)
+		p.SetState(305)
+		p.Match(JsonQueryParserT__3)
 		if p.HasError() {
 			// Recognition error - abort rule
 			goto errorExit
 		}
 	}
 	{
-		p.SetState(144)
+		p.SetState(306)
 		p.SubListOfDoubles()
 	}
 
@@ -3131,18 +5425,18 @@ func (s *SubListOfDoublesContext) Accept(visitor antlr.ParseTreeVisitor) interfa
 
 func (p *JsonQueryParser) SubListOfDoubles() (localctx ISubListOfDoublesContext) {
 	localctx = NewSubListOfDoublesContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 26, JsonQueryParserRULE_subListOfDoubles)
-	p.SetState(151)
+	p.EnterRule(localctx, 30, JsonQueryParserRULE_subListOfDoubles)
+	p.SetState(313)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
 	}
 
-	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 14, p.GetParserRuleContext()) {
+	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 41, p.GetParserRuleContext()) {
 	case 1:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(146)
+			p.SetState(308)
 			p.Match(JsonQueryParserDOUBLE)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -3150,7 +5444,7 @@ func (p *JsonQueryParser) SubListOfDoubles() (localctx ISubListOfDoublesContext)
 			}
 		}
 		{
-			p.SetState(147)
+			p.SetState(309)
 			p.Match(JsonQueryParserCOMMA)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -3158,14 +5452,14 @@ func (p *JsonQueryParser) SubListOfDoubles() (localctx ISubListOfDoublesContext)
 			}
 		}
 		{
-			p.SetState(148)
+			p.SetState(310)
 			p.SubListOfDoubles()
 		}
 
 	case 2:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(149)
+			p.SetState(311)
 			p.Match(JsonQueryParserDOUBLE)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -3173,8 +5467,8 @@ func (p *JsonQueryParser) SubListOfDoubles() (localctx ISubListOfDoublesContext)
 			}
 		}
 		{
-			p.SetState(150)
-			p.Match(JsonQueryParserT__6)
+			p.SetState(312)
+			p.Match(JsonQueryParserT__4)
 			if p.HasError() {
 				// Recognition error - abort rule
 				goto errorExit
@@ -3280,18 +5574,18 @@ func (s *ListIntsContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 
 func (p *JsonQueryParser) ListInts() (localctx IListIntsContext) {
 	localctx = NewListIntsContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 28, JsonQueryParserRULE_listInts)
+	p.EnterRule(localctx, 32, JsonQueryParserRULE_listInts)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(153)
-		p.Match(JsonQueryParserT__5)
+		p.SetState(315)
+		p.Match(JsonQueryParserT__3)
 		if p.HasError() {
 			// Recognition error - abort rule
 			goto errorExit
 		}
 	}
 	{
-		p.SetState(154)
+		p.SetState(316)
 		p.SubListOfInts()
 	}
 
@@ -3400,18 +5694,18 @@ func (s *SubListOfIntsContext) Accept(visitor antlr.ParseTreeVisitor) interface{
 
 func (p *JsonQueryParser) SubListOfInts() (localctx ISubListOfIntsContext) {
 	localctx = NewSubListOfIntsContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 30, JsonQueryParserRULE_subListOfInts)
-	p.SetState(161)
+	p.EnterRule(localctx, 34, JsonQueryParserRULE_subListOfInts)
+	p.SetState(323)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
 	}
 
-	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 15, p.GetParserRuleContext()) {
+	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 42, p.GetParserRuleContext()) {
 	case 1:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(156)
+			p.SetState(318)
 			p.Match(JsonQueryParserINT)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -3419,7 +5713,7 @@ func (p *JsonQueryParser) SubListOfInts() (localctx ISubListOfIntsContext) {
 			}
 		}
 		{
-			p.SetState(157)
+			p.SetState(319)
 			p.Match(JsonQueryParserCOMMA)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -3427,14 +5721,14 @@ func (p *JsonQueryParser) SubListOfInts() (localctx ISubListOfIntsContext) {
 			}
 		}
 		{
-			p.SetState(158)
+			p.SetState(320)
 			p.SubListOfInts()
 		}
 
 	case 2:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(159)
+			p.SetState(321)
 			p.Match(JsonQueryParserINT)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -3442,8 +5736,8 @@ func (p *JsonQueryParser) SubListOfInts() (localctx ISubListOfIntsContext) {
 			}
 		}
 		{
-			p.SetState(160)
-			p.Match(JsonQueryParserT__6)
+			p.SetState(322)
+			p.Match(JsonQueryParserT__4)
 			if p.HasError() {
 				// Recognition error - abort rule
 				goto errorExit
@@ -3469,13 +5763,20 @@ errorExit:
 
 func (p *JsonQueryParser) Sempred(localctx antlr.RuleContext, ruleIndex, predIndex int) bool {
 	switch ruleIndex {
-	case 0:
+	case 1:
 		var t *QueryContext = nil
 		if localctx != nil {
 			t = localctx.(*QueryContext)
 		}
 		return p.Query_Sempred(t, predIndex)
 
+	case 2:
+		var t *ArithContext = nil
+		if localctx != nil {
+			t = localctx.(*ArithContext)
+		}
+		return p.Arith_Sempred(t, predIndex)
+
 	default:
 		panic("No predicate with index: " + fmt.Sprint(ruleIndex))
 	}
@@ -3484,7 +5785,26 @@ func (p *JsonQueryParser) Sempred(localctx antlr.RuleContext, ruleIndex, predInd
 func (p *JsonQueryParser) Query_Sempred(localctx antlr.RuleContext, predIndex int) bool {
 	switch predIndex {
 	case 0:
-		return p.Precpred(p.GetParserRuleContext(), 5)
+		return p.Precpred(p.GetParserRuleContext(), 10)
+
+	case 1:
+		return p.Precpred(p.GetParserRuleContext(), 9)
+
+	case 2:
+		return p.Precpred(p.GetParserRuleContext(), 8)
+
+	default:
+		panic("No predicate with index: " + fmt.Sprint(predIndex))
+	}
+}
+
+func (p *JsonQueryParser) Arith_Sempred(localctx antlr.RuleContext, predIndex int) bool {
+	switch predIndex {
+	case 3:
+		return p.Precpred(p.GetParserRuleContext(), 3)
+
+	case 4:
+		return p.Precpred(p.GetParserRuleContext(), 2)
 
 	default:
 		panic("No predicate with index: " + fmt.Sprint(predIndex))
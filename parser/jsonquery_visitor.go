@@ -8,12 +8,21 @@ import "github.com/antlr4-go/antlr/v4"
 type JsonQueryVisitor interface {
 	antlr.ParseTreeVisitor
 
+	// Visit a parse tree produced by JsonQueryParser#root.
+	VisitRoot(ctx *RootContext) interface{}
+
+	// Visit a parse tree produced by JsonQueryParser#quantifierExp.
+	VisitQuantifierExp(ctx *QuantifierExpContext) interface{}
+
 	// Visit a parse tree produced by JsonQueryParser#compareExp.
 	VisitCompareExp(ctx *CompareExpContext) interface{}
 
 	// Visit a parse tree produced by JsonQueryParser#regexExp.
 	VisitRegexExp(ctx *RegexExpContext) interface{}
 
+	// Visit a parse tree produced by JsonQueryParser#ipClassExp.
+	VisitIpClassExp(ctx *IpClassExpContext) interface{}
+
 	// Visit a parse tree produced by JsonQueryParser#parenExp.
 	VisitParenExp(ctx *ParenExpContext) interface{}
 
@@ -26,17 +35,44 @@ type JsonQueryVisitor interface {
 	// Visit a parse tree produced by JsonQueryParser#logicalExp.
 	VisitLogicalExp(ctx *LogicalExpContext) interface{}
 
+	// Visit a parse tree produced by JsonQueryParser#countExp.
+	VisitCountExp(ctx *CountExpContext) interface{}
+
+	// Visit a parse tree produced by JsonQueryParser#notExp.
+	VisitNotExp(ctx *NotExpContext) interface{}
+
+	// Visit a parse tree produced by JsonQueryParser#callArith.
+	VisitCallArith(ctx *CallArithContext) interface{}
+
+	// Visit a parse tree produced by JsonQueryParser#addArith.
+	VisitAddArith(ctx *AddArithContext) interface{}
+
+	// Visit a parse tree produced by JsonQueryParser#mulArith.
+	VisitMulArith(ctx *MulArithContext) interface{}
+
+	// Visit a parse tree produced by JsonQueryParser#valueArith.
+	VisitValueArith(ctx *ValueArithContext) interface{}
+
+	// Visit a parse tree produced by JsonQueryParser#parenArith.
+	VisitParenArith(ctx *ParenArithContext) interface{}
+
 	// Visit a parse tree produced by JsonQueryParser#attrPath.
 	VisitAttrPath(ctx *AttrPathContext) interface{}
 
 	// Visit a parse tree produced by JsonQueryParser#valueAttrPath.
 	VisitValueAttrPath(ctx *ValueAttrPathContext) interface{}
 
-	// Visit a parse tree produced by JsonQueryParser#subAttr.
-	VisitSubAttr(ctx *SubAttrContext) interface{}
+	// Visit a parse tree produced by JsonQueryParser#fieldAttr.
+	VisitFieldAttr(ctx *FieldAttrContext) interface{}
 
-	// Visit a parse tree produced by JsonQueryParser#valueSubAttr.
-	VisitValueSubAttr(ctx *ValueSubAttrContext) interface{}
+	// Visit a parse tree produced by JsonQueryParser#indexAttr.
+	VisitIndexAttr(ctx *IndexAttrContext) interface{}
+
+	// Visit a parse tree produced by JsonQueryParser#wildcardAttr.
+	VisitWildcardAttr(ctx *WildcardAttrContext) interface{}
+
+	// Visit a parse tree produced by JsonQueryParser#keyAttr.
+	VisitKeyAttr(ctx *KeyAttrContext) interface{}
 
 	// Visit a parse tree produced by JsonQueryParser#boolean.
 	VisitBoolean(ctx *BooleanContext) interface{}
@@ -71,6 +107,9 @@ type JsonQueryVisitor interface {
 	// Visit a parse tree produced by JsonQueryParser#regexValue.
 	VisitRegexValue(ctx *RegexValueContext) interface{}
 
+	// Visit a parse tree produced by JsonQueryParser#ipClass.
+	VisitIpClass(ctx *IpClassContext) interface{}
+
 	// Visit a parse tree produced by JsonQueryParser#ipValue.
 	VisitIpValue(ctx *IpValueContext) interface{}
 
@@ -1,223 +1,265 @@
 package parser
 
 import (
-	"errors"
+	"encoding/json"
 	"fmt"
-	regexp "github.com/wasilibs/go-re2"
 	"net"
+	"net/netip"
 	"reflect"
 	"strconv"
 	"strings"
 
 	"github.com/antlr4-go/antlr/v4"
+	"github.com/blang/semver"
+	regexp "github.com/wasilibs/go-re2"
 )
 
-type objStack struct {
-	items []interface{}
+// JsonQueryVisitorImpl lowers the ANTLR parse tree of a rule into the Expr
+// tree that Evaluator runs. Every literal is parsed and checked here, once, so
+// evaluation never has to go back to the rule text.
+type JsonQueryVisitorImpl struct {
+	antlr.ParseTreeVisitor
+
+	env  *Env
+	errs []*SyntaxError
 }
 
-func (o *objStack) empty() bool {
-	return len(o.items) == 0
+func NewJsonQueryVisitorImpl() *JsonQueryVisitorImpl {
+	return &JsonQueryVisitorImpl{env: defaultEnv}
 }
 
-func (o *objStack) peek() interface{} {
-	return o.items[len(o.items)-1]
+func (j *JsonQueryVisitorImpl) errorAt(tok antlr.Token, format string, args ...interface{}) {
+	j.errs = append(j.errs, &SyntaxError{
+		Offset: tok.GetStart(),
+		Line:   tok.GetLine(),
+		Column: tok.GetColumn(),
+		Token:  tok.GetText(),
+		Msg:    fmt.Sprintf(format, args...),
+	})
 }
 
-func (o *objStack) pop() interface{} {
-	val := o.peek()
-	o.items = o.items[:len(o.items)-1]
-	return val
+func (j *JsonQueryVisitorImpl) Visit(tree antlr.ParseTree) interface{} {
+	return tree.Accept(j)
 }
 
-func (o *objStack) push(item interface{}) {
-	o.items = append(o.items, item)
+func spanOf(ctx antlr.ParserRuleContext) Span {
+	return Span{Start: ctx.GetStart().GetStart(), End: ctx.GetStop().GetStop() + 1}
 }
 
-func (o *objStack) clear() {
-	o.items = nil
+func (j *JsonQueryVisitorImpl) visitExpr(tree antlr.ParseTree) Expr {
+	expr, _ := tree.Accept(j).(Expr)
+	return expr
 }
 
-type JsonQueryVisitorImpl struct {
-	antlr.ParseTreeVisitor
+func (j *JsonQueryVisitorImpl) visitValue(tree antlr.ParseTree) Value {
+	val, _ := tree.Accept(j).(Value)
+	return val
+}
 
-	item map[string]interface{}
+func (j *JsonQueryVisitorImpl) VisitRoot(ctx *RootContext) interface{} {
+	return j.visitExpr(ctx.Query())
+}
 
-	stack *objStack
+func (j *JsonQueryVisitorImpl) VisitParenExp(ctx *ParenExpContext) interface{} {
+	return j.visitExpr(ctx.Query())
+}
 
-	currentOperation Operation
-	leftOp           Operand
-	rightOp          Operand
+func (j *JsonQueryVisitorImpl) VisitNotExp(ctx *NotExpContext) interface{} {
+	return &NotExpr{Span: spanOf(ctx), Expr: j.visitExpr(ctx.Query())}
+}
 
-	err      error
-	debugErr error
+func (j *JsonQueryVisitorImpl) VisitLogicalExp(ctx *LogicalExpContext) interface{} {
+	var op LogicalOp
+	switch ctx.op.GetTokenType() {
+	case JsonQueryParserAND:
+		op = LogicalAnd
+	case JsonQueryParserXOR:
+		op = LogicalXor
+	case JsonQueryParserOR:
+		op = LogicalOr
+	default:
+		j.errorAt(ctx.op, "unknown logical operator %s", ctx.op.GetText())
+	}
+	return &LogicalExpr{
+		Span:  spanOf(ctx),
+		Op:    op,
+		Left:  j.visitExpr(ctx.Query(0)),
+		Right: j.visitExpr(ctx.Query(1)),
+	}
+}
 
-	doPanic bool
+func (j *JsonQueryVisitorImpl) VisitPresentExp(ctx *PresentExpContext) interface{} {
+	return &PresentExpr{Span: spanOf(ctx), Path: ctx.AttrPath().Accept(j).(*Path)}
 }
 
-func NewJsonQueryVisitorImpl(item map[string]interface{}) *JsonQueryVisitorImpl {
-	return &JsonQueryVisitorImpl{
-		stack: &objStack{},
-		item:  item,
-	}
+var quantifiers = map[string]Quantifier{
+	"any":  QuantifierAny,
+	"ANY":  QuantifierAny,
+	"all":  QuantifierAll,
+	"ALL":  QuantifierAll,
+	"none": QuantifierNone,
+	"NONE": QuantifierNone,
 }
 
-func (j *JsonQueryVisitorImpl) setDebugErr(err error) {
-	if j.doPanic {
-		panic(err)
+func (j *JsonQueryVisitorImpl) VisitQuantifierExp(ctx *QuantifierExpContext) interface{} {
+	name := ctx.ATTRNAME().GetSymbol()
+	quantifier, ok := quantifiers[name.GetText()]
+	if !ok {
+		j.errorAt(name, "unknown quantifier %s, expected any, all or none", name.GetText())
+	}
+	return &QuantifierExpr{
+		Span:       spanOf(ctx),
+		Quantifier: quantifier,
+		Path:       ctx.AttrPath().Accept(j).(*Path),
+		Expr:       j.visitExpr(ctx.Query()),
 	}
-	j.debugErr = err
 }
 
-func (j *JsonQueryVisitorImpl) setErr(err error) {
-	if j.doPanic {
-		panic(err)
+func (j *JsonQueryVisitorImpl) VisitCountExp(ctx *CountExpContext) interface{} {
+	name := ctx.ATTRNAME().GetSymbol()
+	if name.GetText() != "count" && name.GetText() != "COUNT" {
+		j.errorAt(name, "unknown function %s, only count can be compared", name.GetText())
+	}
+	compareOp, ok := compareOps[ctx.op.GetTokenType()]
+	if !ok {
+		j.errorAt(ctx.op, "unknown operation %s", ctx.op.GetText())
+	}
+	return &CountExpr{
+		Span:  spanOf(ctx),
+		Op:    compareOp,
+		Path:  ctx.AttrPath().Accept(j).(*Path),
+		Expr:  j.visitExpr(ctx.Query()),
+		Right: j.visitValue(ctx.Value()),
 	}
-	j.err = err
 }
 
-func (j *JsonQueryVisitorImpl) hasErr() bool {
-	return j.err != nil
+var compareOps = map[int]CompareOp{
+	JsonQueryParserEQ: CompareEQ,
+	JsonQueryParserNE: CompareNE,
+	JsonQueryParserGT: CompareGT,
+	JsonQueryParserLT: CompareLT,
+	JsonQueryParserGE: CompareGE,
+	JsonQueryParserLE: CompareLE,
+	JsonQueryParserCO: CompareCO,
+	JsonQueryParserSW: CompareSW,
+	JsonQueryParserEW: CompareEW,
+	JsonQueryParserIN: CompareIN,
+	JsonQueryParserMT: CompareMT,
+	JsonQueryParserIS: CompareIS,
 }
 
-func (j *JsonQueryVisitorImpl) Visit(tree antlr.ParseTree) interface{} {
-	if j.hasErr() {
-		return false
-	}
-
-	switch val := tree.(type) {
-	case *LogicalExpContext:
-		return val.Accept(j).(bool)
-	case *CompareExpContext:
-		return val.Accept(j).(bool)
-	case *ParenExpContext:
-		return val.Accept(j).(bool)
-	case *PresentExpContext:
-		return val.Accept(j).(bool)
-	case *RegexExpContext:
-		return val.Accept(j).(bool)
-	case *IpCompareExpContext:
-		return val.Accept(j).(bool)
-	default:
-		j.setErr(errors.New("Invalid rule"))
-		return false
+func (j *JsonQueryVisitorImpl) compare(ctx antlr.ParserRuleContext, not antlr.TerminalNode, op antlr.Token, left IArithContext, right antlr.ParseTree) Expr {
+	compareOp, ok := compareOps[op.GetTokenType()]
+	if !ok {
+		j.errorAt(op, "unknown operation %s", op.GetText())
+	}
+	if not != nil && !compareOp.Negatable() {
+		j.errorAt(not.GetSymbol(), "not can only be used with in, co, sw, ew and mt, not with %s", op.GetText())
+	}
+	return &CompareExpr{
+		Span:  spanOf(ctx),
+		Op:    compareOp,
+		Not:   not != nil,
+		Left:  j.visitValue(left),
+		Right: j.visitValue(right),
 	}
 }
 
-func (j *JsonQueryVisitorImpl) VisitParenExp(ctx *ParenExpContext) interface{} {
-	result := ctx.Query().Accept(j).(bool)
-	if ctx.NOT() != nil {
-		return !result
+func (j *JsonQueryVisitorImpl) VisitCompareExp(ctx *CompareExpContext) interface{} {
+	return j.compare(ctx, ctx.NOT(), ctx.op, ctx.Arith(0), ctx.Arith(1))
+}
+
+func (j *JsonQueryVisitorImpl) VisitRegexExp(ctx *RegexExpContext) interface{} {
+	return j.compare(ctx, ctx.NOT(), ctx.op, ctx.Arith(), ctx.RegexValue())
+}
+
+func (j *JsonQueryVisitorImpl) VisitIpClassExp(ctx *IpClassExpContext) interface{} {
+	return j.compare(ctx, ctx.NOT(), ctx.op, ctx.Arith(), ctx.IpClass())
+}
+
+func (j *JsonQueryVisitorImpl) VisitIpClass(ctx *IpClassContext) interface{} {
+	class, ok := ParseIPClass(ctx.GetText())
+	if !ok {
+		j.errorAt(ctx.GetStart(), "unknown IP class %s, expected one of %s", ctx.GetText(), ipClassList())
 	}
-	return result
+	return newLiteral(LiteralIPClass, class, ctx.GetText())
 }
 
-func (j *JsonQueryVisitorImpl) VisitLogicalExp(ctx *LogicalExpContext) interface{} {
-	left := ctx.Query(0).Accept(j).(bool)
-	op := ctx.LOGICAL_OPERATOR().GetText()
-	if op == "or" {
-		if left {
-			return left
+func (j *JsonQueryVisitorImpl) VisitIpCompareExp(ctx *IpCompareExpContext) interface{} {
+	if ctx.ListIPs() != nil {
+		if ctx.IN() == nil {
+			j.errorAt(ctx.op, "an IP list can only be used with in, not %s", ctx.op.GetText())
 		}
-		return ctx.Query(1).Accept(j).(bool)
+		return j.compare(ctx, ctx.NOT(), ctx.op, ctx.Arith(), ctx.ListIPs())
 	}
-	// means it is and
-	if !left {
-		return left
+	ordering := ctx.EQ() == nil && ctx.NE() == nil && ctx.IN() == nil
+	if ip := ctx.IpValue(); ip.IP_RANGE() != nil && ctx.IN() == nil {
+		j.errorAt(ctx.op, "an IP range can only be used with in, not %s", ctx.op.GetText())
+	} else if ip.IP_CIDR() != nil && ordering {
+		j.errorAt(ctx.op, "a CIDR can only be used with eq, ne and in, not %s", ctx.op.GetText())
 	}
-	return ctx.Query(1).Accept(j).(bool)
+	return j.compare(ctx, ctx.NOT(), ctx.op, ctx.Arith(), ctx.IpValue())
 }
 
-func (j *JsonQueryVisitorImpl) VisitPresentExp(ctx *PresentExpContext) interface{} {
-	ctx.AttrPath().Accept(j)
-	return j.leftOp != nil
+func (j *JsonQueryVisitorImpl) VisitParenArith(ctx *ParenArithContext) interface{} {
+	return ctx.Arith().Accept(j)
 }
 
-func (j *JsonQueryVisitorImpl) VisitCompareExp(ctx *CompareExpContext) interface{} {
-	ctx.AttrPath().Accept(j)
-	ctx.Value().Accept(j)
-	if j.hasErr() {
-		return false
+func (j *JsonQueryVisitorImpl) VisitCallArith(ctx *CallArithContext) interface{} {
+	name := ctx.ATTRNAME().GetSymbol()
+	call := &CallExpr{Name: name.GetText()}
+	for _, arg := range ctx.AllArith() {
+		call.Args = append(call.Args, j.visitValue(arg))
 	}
-	var apply func(Operand, Operand) (bool, error)
-	currentOp := j.currentOperation
 
-	switch ctx.op.GetTokenType() {
-	case JsonQueryParserEQ:
-		apply = currentOp.EQ
-	case JsonQueryParserNE:
-		apply = currentOp.NE
-	case JsonQueryParserGT:
-		apply = currentOp.GT
-	case JsonQueryParserLT:
-		apply = currentOp.LT
-	case JsonQueryParserLE:
-		apply = currentOp.LE
-	case JsonQueryParserGE:
-		apply = currentOp.GE
-	case JsonQueryParserCO:
-		apply = currentOp.CO
-	case JsonQueryParserSW:
-		apply = currentOp.SW
-	case JsonQueryParserEW:
-		apply = currentOp.EW
-	case JsonQueryParserIN:
-		apply = currentOp.IN
-	default:
-		j.setErr(fmt.Errorf("Unknown operation %s", ctx.op.GetText()))
-		return false
+	fn := j.env.lookup(call.Name)
+	if fn == nil {
+		j.errorAt(name, "unknown function %s", call.Name)
+		return call
 	}
-	defer func() { j.rightOp = nil }()
-	ret, err := apply(j.leftOp, j.rightOp)
-	if err != nil {
-		switch err {
-		case ErrInvalidOperation:
-			// in case of invalid operation lets rather
-			// be conservative and return false because the rule doesn't even make
-			// sense. It can be argued that it would be false positive if we were
-			// to return true
-			j.setErr(err)
-			j.setDebugErr(
-				newNestedError(err, "Not a valid operation for datatypes").Set(ErrVals{
-					"operation":           ctx.op.GetTokenType(),
-					"object_path_operand": j.leftOp,
-					"rule_operand":        j.rightOp,
-				}),
-			)
-		case ErrEvalOperandMissing:
-			j.setDebugErr(
-				newNestedError(err, "Eval operand missing in input object").Set(ErrVals{
-					"attr_path":           ctx.AttrPath().GetText(),
-					"value":               ctx.Value().GetText(),
-					"object_path_operand": j.leftOp,
-					"rule_operand":        j.rightOp,
-				}),
-			)
-		default:
-			switch err.(type) {
-			case *ErrInvalidOperand:
-				j.setDebugErr(
-					newNestedError(err, "operands are not the right value type").Set(ErrVals{
-						"attr_path":           ctx.AttrPath().GetText(),
-						"object_path_operand": j.leftOp,
-						"rule_operand":        j.rightOp,
-					}),
-				)
-			default:
-				j.setDebugErr(
-					newNestedError(err, "unknown error").Set(ErrVals{
-						"attr_path":           ctx.AttrPath().GetText(),
-						"object_path_operand": j.leftOp,
-						"rule_operand":        j.rightOp,
-					}),
-				)
-
-			}
+	if err := fn.checkArgs(len(call.Args)); err != nil {
+		j.errorAt(name, "%s %v", call.Name, err)
+		return call
+	}
+	for i, arg := range call.Args {
+		if argType := staticType(arg); !fn.paramType(i).accepts(argType) {
+			j.errorAt(ctx.Arith(i).GetStart(), "argument %d of %s has to be %s, not %s", i+1, call.Name, fn.paramType(i), argType)
 		}
+	}
+	call.fn = fn
+	return call
+}
+
+var arithOps = map[int]ArithOp{
+	JsonQueryParserPLUS:    ArithAdd,
+	JsonQueryParserMINUS:   ArithSub,
+	JsonQueryParserSTAR:    ArithMul,
+	JsonQueryParserSLASH:   ArithDiv,
+	JsonQueryParserPERCENT: ArithMod,
+}
 
-		return false
+func (j *JsonQueryVisitorImpl) arith(op antlr.Token, left, right IArithContext) *ArithExpr {
+	arithOp, ok := arithOps[op.GetTokenType()]
+	if !ok {
+		j.errorAt(op, "unknown operation %s", op.GetText())
+	}
+	return &ArithExpr{
+		Op:    arithOp,
+		Left:  j.visitValue(left),
+		Right: j.visitValue(right),
 	}
-	return ret
+}
+
+func (j *JsonQueryVisitorImpl) VisitMulArith(ctx *MulArithContext) interface{} {
+	return j.arith(ctx.op, ctx.Arith(0), ctx.Arith(1))
+}
+
+func (j *JsonQueryVisitorImpl) VisitAddArith(ctx *AddArithContext) interface{} {
+	return j.arith(ctx.op, ctx.Arith(0), ctx.Arith(1))
+}
+
+func (j *JsonQueryVisitorImpl) VisitValueArith(ctx *ValueArithContext) interface{} {
+	return ctx.Value().Accept(j)
 }
 
 func GetSubAttr(source interface{}, index string) interface{} {
@@ -246,61 +288,103 @@ func GetSubAttr(source interface{}, index string) interface{} {
 	return nil
 }
 
-func (j *JsonQueryVisitorImpl) VisitAttrPath(ctx *AttrPathContext) interface{} {
-	var item interface{}
-	index := ctx.ATTRNAME().GetText()
-
-	if ctx.SubAttr() == nil || ctx.SubAttr().IsEmpty() {
-		if !j.stack.empty() {
-			item = j.stack.pop()
-		} else {
-			item = j.item
-		}
-		if item == nil {
-			return nil
+func (j *JsonQueryVisitorImpl) attrPath(name antlr.TerminalNode, subAttrs []ISubAttrContext) *Path {
+	path := &Path{
+		Segments: []PathSegment{{Kind: SegmentField, Name: name.GetText()}},
+	}
+	for _, sub := range subAttrs {
+		if seg, ok := sub.Accept(j).(PathSegment); ok {
+			path.Segments = append(path.Segments, seg)
 		}
-		j.leftOp = GetSubAttr(item, index)
+	}
+	return path
+}
 
-		if j.currentOperation == nil {
-			j.currentOperation = GetCurrentOperationByRight(j.leftOp)
-		}
-		//fmt.Println("j.currentOperation", reflect.TypeOf(j.currentOperation).String())
+// GetIndex returns the element at index of a slice or array, counting from
+// the end for negative indexes
+func GetIndex(source interface{}, index int) interface{} {
+	if source == nil {
+		return nil
+	}
 
-		//fmt.Println("j.leftOp (after)", j.leftOp)
-		//fmt.Println("j.item[index]", j.item[index])
-		//fmt.Println("item.(type)", reflect.TypeOf(item))
+	if list, ok := source.([]interface{}); ok {
+		if index < 0 {
+			index += len(list)
+		}
+		if index < 0 || index >= len(list) {
+			return nil
+		}
+		return list[index]
+	}
 
-		j.stack.clear()
+	val := reflect.ValueOf(source)
+	if val.Kind() != reflect.Slice && val.Kind() != reflect.Array {
 		return nil
 	}
-
-	if !j.stack.empty() {
-		item = j.stack.peek()
-	} else {
-		item = j.item
+	if index < 0 {
+		index += val.Len()
 	}
-	if item == nil {
+	if index < 0 || index >= val.Len() {
 		return nil
 	}
-	j.stack.push(GetSubAttr(item, index))
-	return ctx.SubAttr().Accept(j)
+	return val.Index(index).Interface()
 }
 
-func (j *JsonQueryVisitorImpl) VisitSubAttr(ctx *SubAttrContext) interface{} {
-	return ctx.AttrPath().Accept(j)
+func (j *JsonQueryVisitorImpl) VisitAttrPath(ctx *AttrPathContext) interface{} {
+	return j.attrPath(ctx.ATTRNAME(), ctx.AllSubAttr())
+}
+
+func (j *JsonQueryVisitorImpl) VisitFieldAttr(ctx *FieldAttrContext) interface{} {
+	return PathSegment{Kind: SegmentField, Name: ctx.ATTRNAME().GetText()}
+}
+
+func (j *JsonQueryVisitorImpl) VisitIndexAttr(ctx *IndexAttrContext) interface{} {
+	text := ctx.GetText()
+	index, err := parseInt(text[1 : len(text)-1])
+	if err != nil {
+		j.errorAt(ctx.INT().GetSymbol(), "invalid index %s: %v", ctx.INT().GetText(), err)
+	}
+	return PathSegment{Kind: SegmentIndex, Index: index}
+}
+
+func (j *JsonQueryVisitorImpl) VisitWildcardAttr(ctx *WildcardAttrContext) interface{} {
+	return PathSegment{Kind: SegmentWildcard}
+}
+
+func (j *JsonQueryVisitorImpl) VisitKeyAttr(ctx *KeyAttrContext) interface{} {
+	return PathSegment{Kind: SegmentField, Name: getString(ctx.STRING().GetText())}
 }
 
 func GetCurrentOperationByRight(right interface{}) Operation {
+	if op := customOperation(right); op != nil {
+		return op
+	}
 	if right == nil {
 		return &NullOperation{}
 	} else if _, ok := right.(net.IP); ok {
 		return &IPCompareOperation{}
+	} else if _, ok := right.(netip.Addr); ok {
+		return &IPCompareOperation{}
+	} else if _, ok := right.(netip.AddrPort); ok {
+		return &IPCompareOperation{}
+	} else if _, ok := right.(netip.Prefix); ok {
+		return &IPCompareOperation{}
 	} else if _, ok := right.(*net.IPNet); ok {
 		return &IPCompareOperation{}
 	} else if _, ok := right.([]net.IP); ok {
 		return &IPCompareOperation{}
 	} else if _, ok := right.([]*net.IPNet); ok {
 		return &IPCompareOperation{}
+	} else if _, ok := right.([]netip.Addr); ok {
+		return &IPCompareOperation{}
+	} else if _, ok := right.([]netip.Prefix); ok {
+		return &IPCompareOperation{}
+	} else if _, ok := right.(*IPList); ok {
+		return &IPCompareOperation{}
+	} else if _, ok := right.(*IPSet); ok {
+		return &IPCompareOperation{}
+	} else if _, ok := right.(IPRange); ok {
+		return &IPCompareOperation{}
 	} else if _, ok := right.(*regexp.Regexp); ok {
 		return &RegexOperation{}
 	}
@@ -338,64 +422,28 @@ func GetCurrentOperationByRight(right interface{}) Operation {
 }
 
 func (j *JsonQueryVisitorImpl) VisitValueAttrPath(ctx *ValueAttrPathContext) interface{} {
-	var item interface{}
-	index := ctx.ATTRNAME().GetText()
-	if ctx.ValueSubAttr() == nil || ctx.ValueSubAttr().IsEmpty() {
-		if !j.stack.empty() {
-			item = j.stack.pop()
-		} else {
-			item = j.item
-		}
-		if item == nil {
-			return nil
-		}
-
-		j.rightOp = GetSubAttr(item, index)
-		j.stack.clear()
-
-		if j.currentOperation == nil {
-			j.currentOperation = GetCurrentOperationByRight(j.rightOp)
-		}
-
-		return nil
-	}
-	if !j.stack.empty() {
-		item = j.stack.peek()
-	} else {
-		item = j.item
-	}
-	if item == nil {
-		return nil
-	}
-
-	j.stack.push(GetSubAttr(item, index))
-	return ctx.ValueSubAttr().Accept(j)
-}
-
-func (j *JsonQueryVisitorImpl) VisitValueSubAttr(ctx *ValueSubAttrContext) interface{} {
-	return ctx.ValueAttrPath().Accept(j)
+	return j.attrPath(ctx.ATTRNAME(), ctx.AllSubAttr())
 }
 
 func (j *JsonQueryVisitorImpl) VisitBoolean(ctx *BooleanContext) interface{} {
-	j.currentOperation = &BoolOperation{}
-
 	val, err := strconv.ParseBool(ctx.GetText())
 	if err != nil {
-		j.rightOp = nil
-		j.setErr(newNestedError(err, "Error converting boolean"))
-		return nil
+		j.errorAt(ctx.GetStart(), "invalid boolean %s", ctx.GetText())
 	}
-	j.rightOp = val
-	return nil
+	return newLiteral(LiteralBool, val, ctx.GetText())
 }
 
 func (j *JsonQueryVisitorImpl) VisitNull(ctx *NullContext) interface{} {
-	j.currentOperation = &NullOperation{}
-	j.rightOp = nil
-	return nil
+	return newLiteral(LiteralNull, nil, ctx.GetText())
 }
 
+// getString turns a STRING token into its value. The token follows the JSON
+// string syntax, so escapes are resolved the same way JSON does.
 func getString(s string) string {
+	var val string
+	if err := json.Unmarshal([]byte(s), &val); err == nil {
+		return val
+	}
 	if len(s) > 2 {
 		return s[1 : len(s)-1]
 	}
@@ -403,363 +451,188 @@ func getString(s string) string {
 }
 
 func (j *JsonQueryVisitorImpl) VisitString(ctx *StringContext) interface{} {
-	j.currentOperation = &StringOperation{}
-	j.rightOp = getString(ctx.GetText())
-	return nil
+	return newLiteral(LiteralString, getString(ctx.GetText()), ctx.GetText())
 }
 
 func (j *JsonQueryVisitorImpl) VisitDouble(ctx *DoubleContext) interface{} {
-	j.currentOperation = &FloatOperation{}
-	val, err := strconv.ParseFloat(ctx.GetText(), 10)
+	val, err := strconv.ParseFloat(ctx.GetText(), 64)
 	if err != nil {
-		// TODO set err somewhere
-		j.rightOp = nil
-		return false
+		j.errorAt(ctx.GetStart(), "invalid number %s: %v", ctx.GetText(), err)
 	}
-	j.rightOp = val
-	return nil
+	return newLiteral(LiteralFloat, val, ctx.GetText())
 }
 
 func (j *JsonQueryVisitorImpl) VisitVersion(ctx *VersionContext) interface{} {
-	j.currentOperation = &VersionOperation{}
-	j.rightOp = ctx.VERSION().GetText()
-	return nil
+	val, err := semver.Make(ctx.VERSION().GetText())
+	if err != nil {
+		j.errorAt(ctx.GetStart(), "invalid version %s: %v", ctx.GetText(), err)
+	}
+	return newLiteral(LiteralVersion, val, ctx.GetText())
+}
+
+func parseInt(text string) (int, error) {
+	val, err := strconv.ParseInt(text, 10, 64)
+	return int(val), err
 }
 
 func (j *JsonQueryVisitorImpl) VisitLong(ctx *LongContext) interface{} {
-	j.currentOperation = &IntOperation{}
-	val, err := strconv.ParseInt(ctx.GetText(), 10, 64)
+	val, err := parseInt(ctx.GetText())
 	if err != nil {
-		j.rightOp = nil
-		j.setErr(err)
-		return nil
+		j.errorAt(ctx.GetStart(), "invalid integer %s: %v", ctx.GetText(), err)
 	}
-	j.rightOp = int(val)
-	return nil
+	return newLiteral(LiteralInt, val, ctx.GetText())
 }
 
 func (j *JsonQueryVisitorImpl) VisitListOfInts(ctx *ListOfIntsContext) interface{} {
-	j.currentOperation = &IntOperation{}
 	return ctx.ListInts().Accept(j)
 }
 
 func (j *JsonQueryVisitorImpl) VisitListInts(ctx *ListIntsContext) interface{} {
-	return ctx.SubListOfInts().Accept(j)
+	list := make([]int, 0)
+	for sub := ctx.SubListOfInts(); sub != nil; sub = sub.SubListOfInts() {
+		val, err := parseInt(sub.INT().GetText())
+		if err != nil {
+			j.errorAt(sub.INT().GetSymbol(), "invalid integer %s: %v", sub.INT().GetText(), err)
+		}
+		list = append(list, val)
+	}
+	return newLiteral(LiteralIntList, list, ctx.GetText())
 }
 
 func (j *JsonQueryVisitorImpl) VisitSubListOfInts(ctx *SubListOfIntsContext) interface{} {
-	if j.rightOp == nil {
-		j.rightOp = make([]int, 0)
-	}
-	list := j.rightOp.([]int)
-	val, err := strconv.ParseInt(ctx.INT().GetText(), 10, 64)
-	if err != nil {
-		j.setErr(err)
-		return nil
-	}
-	j.rightOp = append(list, int(val))
-	if ctx.SubListOfInts() == nil || ctx.SubListOfInts().IsEmpty() {
-		return nil
-	}
-	return ctx.SubListOfInts().Accept(j)
+	return j.VisitChildren(ctx)
 }
 
 func (j *JsonQueryVisitorImpl) VisitListOfDoubles(ctx *ListOfDoublesContext) interface{} {
-	j.currentOperation = &FloatOperation{}
 	return ctx.ListDoubles().Accept(j)
 }
 
 func (j *JsonQueryVisitorImpl) VisitListDoubles(ctx *ListDoublesContext) interface{} {
-	return ctx.SubListOfDoubles().Accept(j)
+	list := make([]float64, 0)
+	for sub := ctx.SubListOfDoubles(); sub != nil; sub = sub.SubListOfDoubles() {
+		val, err := strconv.ParseFloat(sub.DOUBLE().GetText(), 64)
+		if err != nil {
+			j.errorAt(sub.DOUBLE().GetSymbol(), "invalid number %s: %v", sub.DOUBLE().GetText(), err)
+		}
+		list = append(list, val)
+	}
+	return newLiteral(LiteralFloatList, list, ctx.GetText())
 }
 
 func (j *JsonQueryVisitorImpl) VisitSubListOfDoubles(ctx *SubListOfDoublesContext) interface{} {
-	if j.rightOp == nil {
-		j.rightOp = make([]float64, 0)
-	}
-	list := j.rightOp.([]float64)
-	val, err := strconv.ParseFloat(ctx.DOUBLE().GetText(), 10)
-	if err != nil {
-		j.setErr(err)
-		return nil
-	}
-	j.rightOp = append(list, val)
-	if ctx.SubListOfDoubles() == nil || ctx.SubListOfDoubles().IsEmpty() {
-		return nil
-	}
-	return ctx.SubListOfDoubles().Accept(j)
+	return j.VisitChildren(ctx)
 }
 
 func (j *JsonQueryVisitorImpl) VisitListOfStrings(ctx *ListOfStringsContext) interface{} {
-	j.currentOperation = &StringOperation{}
 	return ctx.ListStrings().Accept(j)
 }
 
 func (j *JsonQueryVisitorImpl) VisitListStrings(ctx *ListStringsContext) interface{} {
-	return ctx.SubListOfStrings().Accept(j)
+	list := make([]string, 0)
+	for sub := ctx.SubListOfStrings(); sub != nil; sub = sub.SubListOfStrings() {
+		list = append(list, getString(sub.STRING().GetText()))
+	}
+	return newLiteral(LiteralStringList, list, ctx.GetText())
 }
 
 func (j *JsonQueryVisitorImpl) VisitSubListOfStrings(ctx *SubListOfStringsContext) interface{} {
-	if j.rightOp == nil {
-		j.rightOp = make([]string, 0)
-	}
-	val := getString(ctx.STRING().GetText())
-	list := j.rightOp.([]string)
-	j.rightOp = append(list, val)
-	if ctx.SubListOfStrings() == nil || ctx.SubListOfStrings().IsEmpty() {
-		return nil
-	}
-	return ctx.SubListOfStrings().Accept(j)
+	return j.VisitChildren(ctx)
 }
 
-/* NEEEEEEEEEEEEEEEEEEEEEW STUFFFFFFFFFFFFFFFFFFFF */
-
 func (j *JsonQueryVisitorImpl) VisitListIPs(ctx *ListIPsContext) interface{} {
-	return ctx.SubListOfIPs().Accept(j)
+	ranges := make([]IPRange, 0)
+	for sub := ctx.SubListOfIPs(); sub != nil; sub = sub.SubListOfIPs() {
+		// an invalid entry is reported by VisitIpValue
+		switch val := sub.IpValue().Accept(j).(*Literal).Val.(type) {
+		case netip.Addr:
+			if val.IsValid() {
+				ranges = append(ranges, IPRange{First: val, Last: val})
+			}
+		case netip.Prefix:
+			if val.IsValid() {
+				ranges = append(ranges, prefixRange(val))
+			}
+		case IPRange:
+			if val.First.IsValid() {
+				ranges = append(ranges, val)
+			}
+		}
+	}
+	return newLiteral(LiteralIPList, newIPList(ranges), ctx.GetText())
 }
 
 func (j *JsonQueryVisitorImpl) VisitSubListOfIPs(ctx *SubListOfIPsContext) interface{} {
-	if j.rightOp == nil {
-		j.rightOp = make([]any, 0)
-	}
-	rawVal := ctx.IpValue()
-	list := j.rightOp.([]any)
-	var val any = nil
-	if rawVal != nil {
-		if rawVal.IP_ADDRESS() != nil {
-			val = net.ParseIP(rawVal.IP_ADDRESS().GetText())
-		} else if rawVal.IP_CIDR() != nil {
-			val = net.ParseIP(rawVal.IP_CIDR().GetText())
-		}
-	}
-	j.rightOp = append(list, val)
-	if ctx.SubListOfIPs() == nil || ctx.SubListOfIPs().IsEmpty() {
-		return nil
-	}
-	return ctx.SubListOfIPs().Accept(j)
+	return j.VisitChildren(ctx)
 }
 
 func (j *JsonQueryVisitorImpl) VisitIpValue(ctx *IpValueContext) interface{} {
-	j.currentOperation = &IPCompareOperation{}
-
-	isCIDR := false
-	txt := ""
-	var err error
-
-	if ctx.IP_ADDRESS() != nil {
-		txt = ctx.IP_ADDRESS().GetText()
-	} else if ctx.IP_CIDR() != nil {
-		txt = ctx.IP_CIDR().GetText()
-		isCIDR = true
-	} else {
-		j.setErr(errors.New("Invalid IP value"))
-		return nil
-	}
-
-	if j.rightOp == "" {
-		j.setErr(errors.New("Invalid IP value"))
-		return nil
-	}
-
-	if isCIDR {
-		var val *net.IPNet
-		_, val, err = net.ParseCIDR(txt)
+	if ctx.IP_RANGE() != nil {
+		val, err := ParseIPRange(ctx.GetText())
 		if err != nil {
-			j.setErr(err)
-			return nil
+			j.errorAt(ctx.GetStart(), "%v", err)
 		}
-
-		j.rightOp = val
-	} else {
-		j.rightOp = net.ParseIP(txt)
-
+		return newLiteral(LiteralIPRange, val, ctx.GetText())
 	}
-
-	return nil
-}
-func (j *JsonQueryVisitorImpl) VisitRegexValue(ctx *RegexValueContext) interface{} {
-	j.currentOperation = &RegexOperation{}
-	rawRegex := ctx.REGEX().GetText()
-
-	// check if regex starts with /
-	if !strings.HasPrefix(rawRegex, "/") {
-		j.setErr(errors.New("Invalid regex"))
-		return nil
+	if ctx.IP_CIDR() != nil {
+		val, err := netip.ParsePrefix(ctx.GetText())
+		if err != nil {
+			j.errorAt(ctx.GetStart(), "invalid CIDR %s: %v", ctx.GetText(), err)
+		}
+		val, _ = normalizePrefix(val)
+		return newLiteral(LiteralCIDR, val, ctx.GetText())
 	}
 
-	// get last index of / from the regex
-	lastIndex := strings.LastIndex(rawRegex, "/")
-	if lastIndex == -1 {
-		j.setErr(errors.New("Invalid regex"))
-		return nil
+	val, ok := toAddr(ctx.GetText())
+	if !ok {
+		j.errorAt(ctx.GetStart(), "invalid IP address %s", ctx.GetText())
 	}
+	return newLiteral(LiteralIP, val, ctx.GetText())
+}
 
-	// get the regex without the / at the start and end
-	regex := rawRegex[1:lastIndex]
-	flags := rawRegex[lastIndex+1:]
-
-	if flags != "" {
-		// check if the flags are valid
-		if !strings.ContainsAny(flags, "gim") {
-			j.setErr(errors.New("Invalid regex flags"))
-			return nil
+// compileRegex turns a panic inside the re2 module into an error so a bad
+// pattern is reported like any other invalid literal
+func compileRegex(expr string) (re *regexp.Regexp, err error) {
+	defer func() {
+		if info := recover(); info != nil {
+			err = fmt.Errorf("%v", info)
 		}
-
-		for _, flag := range flags {
-			switch flag {
-			case 'i':
-				regex = fmt.Sprintf("(?i)%s", regex)
-				break
-			case 'm':
-				regex = fmt.Sprintf("(?m)%s", regex)
-				break
-			}
+	}()
+	return regexp.Compile(expr)
+}
+
+// compileRegexFlags compiles the regex of a /regex/flags literal with its flags
+func compileRegexFlags(regex, flags string) (*regexp.Regexp, error) {
+	// the g flag is accepted but has no effect, a match is a match
+	for _, flag := range flags {
+		switch flag {
+		case 'i':
+			regex = fmt.Sprintf("(?i)%s", regex)
+		case 'm':
+			regex = fmt.Sprintf("(?m)%s", regex)
 		}
 	}
-
-	// build regex
-	compiledRegex, err := regexp.Compile(regex)
-	if err != nil {
-		j.setErr(err)
-		return nil
-	}
-
-	j.rightOp = compiledRegex
-	return nil
+	return compileRegex(regex)
 }
 
-func (j *JsonQueryVisitorImpl) VisitRegexExp(ctx *RegexExpContext) interface{} {
-	ctx.AttrPath().Accept(j)
-	ctx.RegexValue().Accept(j)
-	if j.hasErr() {
-		return false
-	}
-	var apply func(Operand, Operand) (bool, error)
-	currentOp := j.currentOperation
-	switch ctx.op.GetTokenType() {
-	case JsonQueryParserMT:
-		apply = currentOp.MT
-	default:
-		j.setErr(fmt.Errorf("Unknown operation %s", ctx.op.GetText()))
-		return false
-	}
-	defer func() { j.rightOp = nil }()
-	ret, err := apply(j.leftOp, j.rightOp)
-	if err != nil {
-		switch err {
-		case ErrInvalidOperation:
-			// in case of invalid operation lets rather
-			// be conservative and return false because the rule doesn't even make
-			// sense. It can be argued that it would be false positive if we were
-			// to return true
-			j.setErr(err)
-			j.setDebugErr(
-				newNestedError(err, "Not a valid operation for datatypes").Set(ErrVals{
-					"operation":           ctx.op.GetTokenType(),
-					"object_path_operand": j.leftOp,
-					"rule_operand":        j.rightOp,
-				}),
-			)
-		case ErrEvalOperandMissing:
-			j.setDebugErr(
-				newNestedError(err, "Eval operand missing in input object").Set(ErrVals{
-					"attr_path": ctx.AttrPath().GetText(),
-				}),
-			)
-		default:
-			switch err.(type) {
-			case *ErrInvalidOperand:
-				j.setDebugErr(
-					newNestedError(err, "operands are not the right value type").Set(ErrVals{
-						"attr_path":           ctx.AttrPath().GetText(),
-						"object_path_operand": j.leftOp,
-						"rule_operand":        j.rightOp,
-					}),
-				)
-			default:
-				j.setDebugErr(
-					newNestedError(err, "unknown error").Set(ErrVals{
-						"attr_path":           ctx.AttrPath().GetText(),
-						"object_path_operand": j.leftOp,
-						"rule_operand":        j.rightOp,
-					}),
-				)
-
-			}
-		}
+// splitRegex splits a /regex/flags literal into the regex and its flags
+func splitRegex(rawRegex string) (regex, flags string) {
+	// get last index of / from the regex
+	lastIndex := strings.LastIndex(rawRegex, "/")
 
-		return false
-	}
-	return ret
+	// get the regex without the / at the start and end
+	return rawRegex[1:lastIndex], rawRegex[lastIndex+1:]
 }
 
-func (j *JsonQueryVisitorImpl) VisitIpCompareExp(ctx *IpCompareExpContext) interface{} {
-	ctx.AttrPath().Accept(j)
-	ctx.IpValue().Accept(j)
-	if j.hasErr() {
-		return false
-	}
-	var apply func(Operand, Operand) (bool, error)
-	currentOp := j.currentOperation
-	switch ctx.op.GetTokenType() {
-	case JsonQueryParserEQ:
-		apply = currentOp.EQ
-	case JsonQueryParserNE:
-		apply = currentOp.NE
-	case JsonQueryParserIN:
-		apply = currentOp.IN
-	default:
-		j.setErr(fmt.Errorf("Unknown operation %s", ctx.op.GetText()))
-		return false
+func (j *JsonQueryVisitorImpl) VisitRegexValue(ctx *RegexValueContext) interface{} {
+	if ctx.REGEX() == nil {
+		return ctx.ValueAttrPath().Accept(j)
 	}
-	defer func() { j.rightOp = nil }()
-	ret, err := apply(j.leftOp, j.rightOp)
+	rawRegex := ctx.REGEX().GetText()
+	compiledRegex, err := compileRegexFlags(splitRegex(rawRegex))
 	if err != nil {
-		switch err {
-		case ErrInvalidOperation:
-			// in case of invalid operation lets rather
-			// be conservative and return false because the rule doesn't even make
-			// sense. It can be argued that it would be false positive if we were
-			// to return true
-			j.setErr(err)
-			j.setDebugErr(
-				newNestedError(err, "Not a valid operation for datatypes").Set(ErrVals{
-					"operation":           ctx.op.GetTokenType(),
-					"object_path_operand": j.leftOp,
-					"rule_operand":        j.rightOp,
-				}),
-			)
-		case ErrEvalOperandMissing:
-			j.setDebugErr(
-				newNestedError(err, "Eval operand missing in input object").Set(ErrVals{
-					"attr_path": ctx.AttrPath().GetText(),
-				}),
-			)
-		default:
-			switch err.(type) {
-			case *ErrInvalidOperand:
-				j.setDebugErr(
-					newNestedError(err, "operands are not the right value type").Set(ErrVals{
-						"attr_path":           ctx.AttrPath().GetText(),
-						"object_path_operand": j.leftOp,
-						"rule_operand":        j.rightOp,
-					}),
-				)
-			default:
-				j.setDebugErr(
-					newNestedError(err, "unknown error").Set(ErrVals{
-						"attr_path":           ctx.AttrPath().GetText(),
-						"object_path_operand": j.leftOp,
-						"rule_operand":        j.rightOp,
-					}),
-				)
-
-			}
-		}
-
-		return false
+		j.errorAt(ctx.GetStart(), "invalid regex %s: %v", rawRegex, err)
 	}
-	return ret
+	return newLiteral(LiteralRegex, compiledRegex, rawRegex)
 }
 
 func (j *JsonQueryVisitorImpl) VisitVariable(ctx *VariableContext) interface{} {
@@ -0,0 +1,32 @@
+package parser
+
+import "strings"
+
+// matchOps are the spellings of the MT token, see JsonQuery.g4
+var matchOps = []string{"mt", "MT", "matches", "MATCHES", "~="}
+
+// regexAllowed reports whether the / the lexer is at starts a regular
+// expression rather than being a division: whether it follows a match
+// operator, so that both `x mt / foo/` and `a / b / c` lex as written.
+func (l *JsonQueryLexer) regexAllowed() bool {
+	start := l.TokenStartCharIndex
+	if start <= 0 {
+		return false
+	}
+	before := strings.TrimRight(l.GetInputStream().GetText(0, start-1), " \t\r\n")
+	for _, op := range matchOps {
+		if !strings.HasSuffix(before, op) {
+			continue
+		}
+		rest := strings.TrimSuffix(before, op)
+		// the operator and not the end of an attribute name such as format
+		if op == "~=" || rest == "" || !isAttrNameChar(rest[len(rest)-1]) {
+			return true
+		}
+	}
+	return false
+}
+
+func isAttrNameChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_'
+}
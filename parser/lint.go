@@ -0,0 +1,465 @@
+package parser
+
+import (
+	"fmt"
+	"net/netip"
+	"regexp/syntax"
+	"strings"
+)
+
+// Lint compiles rule and reports the parts of it that are most likely
+// mistakes: conditions that are always true or always false, duplicate
+// conditions and list entries, addresses and CIDRs covered by another CIDR,
+// regular expressions that can never match and flags that have no effect.
+// The error is a *ParseError for an invalid rule.
+func Lint(rule string, opts ...EvaluatorOption) ([]*Issue, error) {
+	ev, err := NewEvaluator(rule, opts...)
+	if err != nil {
+		return nil, err
+	}
+	l := &linter{checker: checker{rule: []rune(rule)}}
+	l.expr(ev.Expr())
+	return l.issues, nil
+}
+
+type linter struct {
+	checker
+}
+
+func (l *linter) expr(e Expr) {
+	switch e := e.(type) {
+	case *LogicalExpr:
+		terms := chain(e.Op, e, nil)
+		for _, term := range terms {
+			l.expr(term)
+		}
+		if e.Op != LogicalXor {
+			l.chain(e.Op, terms)
+		}
+	case *NotExpr:
+		l.expr(e.Expr)
+	case *QuantifierExpr:
+		l.expr(e.Expr)
+	case *CountExpr:
+		l.expr(e.Expr)
+	case *CompareExpr:
+		l.compare(e)
+	}
+}
+
+// chain flattens `a and b and c` whatever the grouping into its terms
+func chain(op LogicalOp, e Expr, terms []Expr) []Expr {
+	if logical, ok := e.(*LogicalExpr); ok && logical.Op == op {
+		terms = chain(op, logical.Left, terms)
+		return chain(op, logical.Right, terms)
+	}
+	return append(terms, e)
+}
+
+func (l *linter) chain(op LogicalOp, terms []Expr) {
+	seen := map[string]bool{}
+	var constraints []*constraint
+	for _, term := range terms {
+		key := l.key(term)
+		if seen[key] {
+			l.report(exprSpan(term), nil, "duplicate condition")
+			continue
+		}
+		seen[key] = true
+		if c := newConstraint(term); c != nil {
+			constraints = append(constraints, c)
+		}
+	}
+
+	for i, a := range constraints {
+		for _, b := range constraints[i+1:] {
+			if a.path != b.path || a.kind != b.kind {
+				continue
+			}
+			span := Span{Start: a.span.Start, End: b.span.End}
+			switch {
+			case op == LogicalAnd && !satisfiable(a, b):
+				l.report(span, nil, "always false")
+			case op == LogicalOr && a.kind == constraintIP:
+				if covers(a.ips, b.ips) {
+					l.report(b.span, nil, "%s is covered by %s", b.text, a.text)
+				} else if covers(b.ips, a.ips) {
+					l.report(a.span, nil, "%s is covered by %s", a.text, b.text)
+				} else if coverAll(a.ips, b.ips) {
+					l.report(span, nil, "always true")
+				}
+			case op == LogicalOr && !satisfiable(a.negate(), b.negate()):
+				l.report(span, nil, "always true")
+			}
+		}
+	}
+}
+
+// key identifies a condition whatever the spacing and aliases it is written
+// with
+func (l *linter) key(e Expr) string {
+	switch e := e.(type) {
+	case *CompareExpr:
+		return fmt.Sprintf("%s %t %s %s", e.Left, e.Not, e.Op, e.Right)
+	case *PresentExpr:
+		return e.Path.String() + " pr"
+	case *LogicalExpr:
+		return fmt.Sprintf("(%s %s %s)", l.key(e.Left), e.Op, l.key(e.Right))
+	case *NotExpr:
+		return fmt.Sprintf("not %s", l.key(e.Expr))
+	}
+	span := exprSpan(e)
+	return string(l.rule[span.Start:span.End])
+}
+
+func exprSpan(e Expr) Span {
+	return e.(interface{ span() Span }).span()
+}
+
+func (l *linter) compare(e *CompareExpr) {
+	lit, ok := e.Right.(*Literal)
+	if !ok {
+		return
+	}
+	seen := map[interface{}]bool{}
+	var elems []interface{}
+	switch list := lit.Val.(type) {
+	case []int:
+		for _, v := range list {
+			elems = append(elems, v)
+		}
+	case []float64:
+		for _, v := range list {
+			elems = append(elems, v)
+		}
+	case []string:
+		for _, v := range list {
+			elems = append(elems, quoteString(v))
+		}
+	case *IPList:
+		ranges := list.Ranges()
+		for i, r := range ranges {
+			elems = append(elems, ipEntryString(r))
+			for _, other := range ranges[:i] {
+				switch {
+				case r == other:
+				case covers(other, r):
+					l.report(e.Span, nil, "%s is covered by %s in %s", ipEntryString(r), ipEntryString(other), lit)
+				case covers(r, other):
+					l.report(e.Span, nil, "%s is covered by %s in %s", ipEntryString(other), ipEntryString(r), lit)
+				}
+			}
+		}
+	}
+	for _, elem := range elems {
+		if seen[elem] {
+			l.report(e.Span, nil, "duplicate entry %v in %s", elem, lit)
+		}
+		seen[elem] = true
+	}
+
+	if lit.Kind == LiteralRegex {
+		l.regex(e, lit)
+	}
+}
+
+func (l *linter) regex(e *CompareExpr, lit *Literal) {
+	regex, flags := splitRegex(lit.Text)
+	if strings.ContainsRune(flags, 'g') {
+		l.report(e.Span, nil, "the g flag of %s has no effect", lit)
+	}
+	for _, flag := range flags {
+		switch flag {
+		case 'i':
+			regex = "(?i)" + regex
+		case 'm':
+			regex = "(?m)" + regex
+		}
+	}
+	re, err := syntax.Parse(regex, syntax.Perl)
+	if err == nil && neverMatches(re) {
+		l.report(e.Span, nil, "%s can never match", lit)
+	}
+}
+
+// neverMatches reports whether no string matches re, e.g. because text is
+// expected after its end or before its start
+func neverMatches(re *syntax.Regexp) bool {
+	switch re.Op {
+	case syntax.OpNoMatch:
+		return true
+	case syntax.OpCapture, syntax.OpPlus:
+		return neverMatches(re.Sub[0])
+	case syntax.OpRepeat:
+		return re.Min > 0 && neverMatches(re.Sub[0])
+	case syntax.OpAlternate:
+		for _, sub := range re.Sub {
+			if !neverMatches(sub) {
+				return false
+			}
+		}
+		return true
+	case syntax.OpConcat:
+		consumed, ended := false, false
+		for _, sub := range re.Sub {
+			if neverMatches(sub) {
+				return true
+			}
+			length := minLength(sub)
+			if (sub.Op == syntax.OpBeginText && consumed) || (ended && length > 0) {
+				return true
+			}
+			ended = ended || sub.Op == syntax.OpEndText
+			consumed = consumed || length > 0
+		}
+	}
+	return false
+}
+
+// minLength is the length of the shortest string matching re
+func minLength(re *syntax.Regexp) int {
+	switch re.Op {
+	case syntax.OpLiteral:
+		return len(re.Rune)
+	case syntax.OpCharClass, syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		return 1
+	case syntax.OpCapture, syntax.OpPlus:
+		return minLength(re.Sub[0])
+	case syntax.OpRepeat:
+		return re.Min * minLength(re.Sub[0])
+	case syntax.OpConcat:
+		length := 0
+		for _, sub := range re.Sub {
+			length += minLength(sub)
+		}
+		return length
+	case syntax.OpAlternate:
+		length := -1
+		for _, sub := range re.Sub {
+			if l := minLength(sub); length < 0 || l < length {
+				length = l
+			}
+		}
+		return length
+	}
+	return 0
+}
+
+type constraintKind int
+
+const (
+	constraintNumber constraintKind = iota
+	constraintString
+	constraintBool
+	constraintIP
+)
+
+// constraint is a comparison of an attribute with a literal, which the linter
+// can reason about
+type constraint struct {
+	path string
+	op   CompareOp
+	kind constraintKind
+	num  float64
+	str  string
+	b    bool
+	// ips may span both families for an ordering comparison, e.g. gt
+	// 10.0.0.5 is every address after it up to the last IPv6 one
+	ips  IPRange
+	span Span
+	text string
+}
+
+func newConstraint(e Expr) *constraint {
+	cmp, ok := e.(*CompareExpr)
+	if !ok || cmp.Not {
+		return nil
+	}
+	path, ok := cmp.Left.(*Path)
+	if !ok {
+		return nil
+	}
+	lit, ok := cmp.Right.(*Literal)
+	if !ok {
+		return nil
+	}
+	c := &constraint{path: path.String(), op: cmp.Op, span: cmp.Span, text: lit.Text}
+	ordered := cmp.Op == CompareEQ || cmp.Op == CompareNE || cmp.Op == CompareGT ||
+		cmp.Op == CompareLT || cmp.Op == CompareGE || cmp.Op == CompareLE
+	equality := cmp.Op == CompareEQ || cmp.Op == CompareNE
+	switch val := lit.Val.(type) {
+	case int:
+		c.kind, c.num = constraintNumber, float64(val)
+		return onlyIf(ordered, c)
+	case float64:
+		c.kind, c.num = constraintNumber, val
+		return onlyIf(ordered, c)
+	case string:
+		c.kind, c.str = constraintString, val
+		return onlyIf(equality, c)
+	case bool:
+		c.kind, c.b = constraintBool, val
+		return onlyIf(equality, c)
+	case netip.Addr:
+		ips, ok := orderedRange(cmp.Op, val)
+		if cmp.Op != CompareEQ {
+			c.text = cmp.Op.String() + " " + lit.Text
+		}
+		c.kind, c.ips = constraintIP, ips
+		return onlyIf(ok, c)
+	case netip.Prefix:
+		c.kind, c.ips = constraintIP, prefixRange(val)
+		return onlyIf(cmp.Op == CompareIN, c)
+	case IPRange:
+		c.kind, c.ips = constraintIP, val
+		return onlyIf(cmp.Op == CompareIN, c)
+	}
+	return nil
+}
+
+func onlyIf(ok bool, c *constraint) *constraint {
+	if !ok {
+		return nil
+	}
+	return c
+}
+
+var negatedOps = map[CompareOp]CompareOp{
+	CompareEQ: CompareNE,
+	CompareNE: CompareEQ,
+	CompareGT: CompareLE,
+	CompareLE: CompareGT,
+	CompareLT: CompareGE,
+	CompareGE: CompareLT,
+}
+
+func (c *constraint) negate() *constraint {
+	neg := *c
+	neg.op = negatedOps[c.op]
+	return &neg
+}
+
+// holds reports whether the attribute having the value v, of the kind of the
+// constraint, satisfies it
+func (c *constraint) holds(v interface{}) bool {
+	var cmp int
+	switch c.kind {
+	case constraintNumber:
+		switch n := v.(float64); {
+		case n < c.num:
+			cmp = -1
+		case n > c.num:
+			cmp = 1
+		}
+	case constraintString:
+		cmp = strings.Compare(v.(string), c.str)
+	case constraintBool:
+		if v.(bool) != c.b {
+			cmp = 1
+		}
+	}
+	switch c.op {
+	case CompareEQ:
+		return cmp == 0
+	case CompareNE:
+		return cmp != 0
+	case CompareGT:
+		return cmp > 0
+	case CompareLT:
+		return cmp < 0
+	case CompareGE:
+		return cmp >= 0
+	}
+	return cmp <= 0
+}
+
+// satisfiable reports whether a value can satisfy both constraints. Only the
+// values of the two literals, one between them and one on either side need to
+// be tried.
+func satisfiable(a, b *constraint) bool {
+	var candidates []interface{}
+	switch a.kind {
+	case constraintNumber:
+		lo, hi := a.num, b.num
+		if lo > hi {
+			lo, hi = hi, lo
+		}
+		candidates = []interface{}{lo - 1, lo, (lo + hi) / 2, hi, hi + 1}
+	case constraintString:
+		candidates = []interface{}{a.str, b.str, a.str + b.str + "\x00"}
+	case constraintBool:
+		candidates = []interface{}{true, false}
+	case constraintIP:
+		return !a.ips.Last.Less(b.ips.First) && !b.ips.Last.Less(a.ips.First)
+	}
+	for _, v := range candidates {
+		if a.holds(v) && b.holds(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// covers reports whether every address of b is in a
+func covers(a, b IPRange) bool {
+	return !b.First.Less(a.First) && !a.Last.Less(b.Last)
+}
+
+// allAddrs is every address, the IPv4 ones coming first
+var allAddrs = IPRange{First: netip.IPv4Unspecified(), Last: netip.AddrFrom16([16]byte{
+	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+})}
+
+// coverAll reports whether every address is in a or b
+func coverAll(a, b IPRange) bool {
+	if b.First.Less(a.First) {
+		a, b = b, a
+	}
+	if a.First != allAddrs.First {
+		return false
+	}
+	if a.Last == allAddrs.Last {
+		return true
+	}
+	return !addrAfter(a.Last).Less(b.First) && b.Last == allAddrs.Last
+}
+
+// orderedRange is the addresses that compare to addr with op, false if it
+// isn't a range or is empty
+func orderedRange(op CompareOp, addr netip.Addr) (IPRange, bool) {
+	switch op {
+	case CompareEQ:
+		return IPRange{First: addr, Last: addr}, true
+	case CompareGE:
+		return IPRange{First: addr, Last: allAddrs.Last}, true
+	case CompareLE:
+		return IPRange{First: allAddrs.First, Last: addr}, true
+	case CompareGT:
+		if addr == allAddrs.Last {
+			return IPRange{}, false
+		}
+		return IPRange{First: addrAfter(addr), Last: allAddrs.Last}, true
+	case CompareLT:
+		if addr == allAddrs.First {
+			return IPRange{}, false
+		}
+		return IPRange{First: allAddrs.First, Last: addrBefore(addr)}, true
+	}
+	return IPRange{}, false
+}
+
+// addrAfter is the address after addr, :: after 255.255.255.255
+func addrAfter(addr netip.Addr) netip.Addr {
+	if next := addr.Next(); next.IsValid() {
+		return next
+	}
+	return netip.IPv6Unspecified()
+}
+
+// addrBefore is the address before addr, 255.255.255.255 before ::
+func addrBefore(addr netip.Addr) netip.Addr {
+	if prev := addr.Prev(); prev.IsValid() {
+		return prev
+	}
+	return netip.AddrFrom4([4]byte{0xff, 0xff, 0xff, 0xff})
+}
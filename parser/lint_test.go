@@ -0,0 +1,92 @@
+package parser
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLint(t *testing.T) {
+	tests := []struct {
+		rule     string
+		messages []string
+	}{
+		{`x eq 1 and y eq 2`, nil},
+		{`x gt 1 and x lt 3`, nil},
+		{`x gt 5 or x lt 5`, nil},
+		{`x eq "a" or x eq "b"`, nil},
+		{`x in [1, 2, 3] and name mt /^a.*z$/i`, nil},
+		{`ip in 10.0.0.0/8 or ip in 192.168.0.0/16`, nil},
+		{`ip in [10.0.0.0/8, 192.168.0.0/16, ::1]`, nil},
+		{`x eq 1 xor x eq 2`, nil},
+
+		{`x eq 1 and x eq 2`, []string{`x eq 1 and x eq 2: always false`}},
+		{`x gt 5 and x le 5`, []string{`x gt 5 and x le 5: always false`}},
+		{`x gt 1.5 and x lt 1.5`, []string{`x gt 1.5 and x lt 1.5: always false`}},
+		{`x eq "a" and x ne "a"`, []string{`x eq "a" and x ne "a": always false`}},
+		{`x.a == true && x.a == false`, []string{`x.a == true && x.a == false: always false`}},
+		{`x gt 5 or x le 5`, []string{`x gt 5 or x le 5: always true`}},
+		{`x ne 1 or x ne 2`, []string{`x ne 1 or x ne 2: always true`}},
+		{`x eq true or (y pr or x eq false)`, []string{`x eq true or (y pr or x eq false: always true`}},
+		{`x eq 1 or y eq 2 or x == 1`, []string{`x == 1: duplicate condition`}},
+		{`(x eq 1 and y pr) or (y pr and x eq 1) or (x eq 1 and y pr)`, []string{`x eq 1 and y pr: duplicate condition`}},
+		{`z eq 1 and (x eq 1 and x eq 2 or y pr)`, []string{`x eq 1 and x eq 2: always false`}},
+		{`any(items, qty gt 5 and qty lt 2)`, []string{`qty gt 5 and qty lt 2: always false`}},
+		{`x in [1, 2, 1]`, []string{`x in [1, 2, 1]: duplicate entry 1 in [1, 2, 1]`}},
+		{`x in ["a", "b", "b"]`, []string{`x in ["a", "b", "b"]: duplicate entry "b" in ["a", "b", "b"]`}},
+		{`ip in 10.0.0.0/8 or ip in 10.1.0.0/16`, []string{`ip in 10.1.0.0/16: 10.1.0.0/16 is covered by 10.0.0.0/8`}},
+		{`ip eq 192.168.1.1 or ip in 192.168.0.0/16`, []string{`ip eq 192.168.1.1: 192.168.1.1 is covered by 192.168.0.0/16`}},
+		{`ip in [10.0.0.0/8, 192.168.1.1, 10.0.0.0/8]`, []string{`ip in [10.0.0.0/8, 192.168.1.1, 10.0.0.0/8]: duplicate entry 10.0.0.0/8 in [10.0.0.0/8, 192.168.1.1, 10.0.0.0/8]`}},
+		{`ip in [10.1.0.0/16, fd00::1, 10.0.0.0/8, fd00::/8]`, []string{
+			`ip in [10.1.0.0/16, fd00::1, 10.0.0.0/8, fd00::/8]: 10.1.0.0/16 is covered by 10.0.0.0/8 in [10.1.0.0/16, fd00::1, 10.0.0.0/8, fd00::/8]`,
+			`ip in [10.1.0.0/16, fd00::1, 10.0.0.0/8, fd00::/8]: fd00::1 is covered by fd00::/8 in [10.1.0.0/16, fd00::1, 10.0.0.0/8, fd00::/8]`,
+		}},
+		{`ip in 10.0.0.0/8 and ip in 192.168.0.0/16`, []string{`ip in 10.0.0.0/8 and ip in 192.168.0.0/16: always false`}},
+		{`ip in 10.0.0.5-10.0.0.99 and ip in 10.0.0.64/26`, nil},
+		{`ip in 10.0.0.5-10.0.0.99 and ip in 10.0.1.0/24`, []string{`ip in 10.0.0.5-10.0.0.99 and ip in 10.0.1.0/24: always false`}},
+		{`ip in 10.0.0.0/24 or ip in 10.0.0.5-10.0.0.99`, []string{`ip in 10.0.0.5-10.0.0.99: 10.0.0.5-10.0.0.99 is covered by 10.0.0.0/24`}},
+		{`ip gt 10.0.0.1 and ip lt 10.0.0.5`, nil},
+		{`ip ge 10.0.0.5 and ip le 10.0.0.5`, nil},
+		{`ip gt 10.0.0.5 and ip lt 10.0.0.1`, []string{`ip gt 10.0.0.5 and ip lt 10.0.0.1: always false`}},
+		{`ip gt 10.0.0.5 and ip le 10.0.0.5`, []string{`ip gt 10.0.0.5 and ip le 10.0.0.5: always false`}},
+		{`ip lt 10.0.0.0 and ip in 10.0.0.0/8`, []string{`ip lt 10.0.0.0 and ip in 10.0.0.0/8: always false`}},
+		{`ip gt 10.0.0.5 and ip eq ::1`, nil},
+		{`ip lt ::1 and ip gt 255.255.255.255`, nil},
+		{`ip lt :: and ip gt 255.255.255.255`, []string{`ip lt :: and ip gt 255.255.255.255: always false`}},
+		{`ip gt 10.0.0.5 or ip le 10.0.0.5`, []string{`ip gt 10.0.0.5 or ip le 10.0.0.5: always true`}},
+		{`ip ge :: or ip le 255.255.255.255`, []string{`ip ge :: or ip le 255.255.255.255: always true`}},
+		{`ip gt 10.0.0.5 or ip lt 10.0.0.5`, nil},
+		{`ip ge 10.0.0.0 or ip in 10.1.0.0/16`, []string{`ip in 10.1.0.0/16: 10.1.0.0/16 is covered by ge 10.0.0.0`}},
+		{`ip in [10.0.0.5-10.0.0.99, 10.0.0.7]`, []string{`ip in [10.0.0.5-10.0.0.99, 10.0.0.7]: 10.0.0.7 is covered by 10.0.0.5-10.0.0.99 in [10.0.0.5-10.0.0.99, 10.0.0.7]`}},
+		{`x mt /a$b/`, []string{`x mt /a$b/: /a$b/ can never match`}},
+		{`x mt /a^b/`, []string{`x mt /a^b/: /a^b/ can never match`}},
+		{`x mt /(a$b|c^d)+/`, []string{`x mt /(a$b|c^d)+/: /(a$b|c^d)+/ can never match`}},
+		{`x mt /foo/g`, []string{`x mt /foo/g: the g flag of /foo/g has no effect`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			issues, err := Lint(tt.rule)
+			if !assert.NoError(t, err) {
+				return
+			}
+			var messages []string
+			for _, issue := range issues {
+				messages = append(messages, issue.String())
+			}
+			assert.Equal(t, tt.messages, messages)
+		})
+	}
+}
+
+func TestLintMultiline(t *testing.T) {
+	issues, err := Lint(`x mt /a$[^x]b/m`)
+	assert.NoError(t, err)
+	assert.Empty(t, issues)
+}
+
+func TestLintParseError(t *testing.T) {
+	_, err := Lint(`x eq`)
+	var parseErr *ParseError
+	assert.True(t, errors.As(err, &parseErr))
+}
@@ -4,12 +4,20 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sync"
+	"sync/atomic"
 )
 
 func toFloat(op Operand) (float64, error) {
 	switch val := op.(type) {
 	case int:
 		return float64(val), nil
+	case int32:
+		return float64(val), nil
+	case int64:
+		return float64(val), nil
+	case float32:
+		return float64(val), nil
 	case float64:
 		return val, nil
 	}
@@ -72,3 +80,42 @@ type Operation interface {
 	IN(left Operand, right Operand) (bool, error)
 	MT(left Operand, right Operand) (bool, error)
 }
+
+// Operable is implemented by types that compare with their own Operation, e.g.
+// a Severity enum for `severity ge "high"`. The operands are passed to the
+// Operation in the order of the rule, so the Operable value can be either of
+// them.
+type Operable interface {
+	Operation() Operation
+}
+
+var (
+	customOperations    sync.Map
+	hasCustomOperations atomic.Bool
+)
+
+// RegisterOperation makes values of type t compare with op, for types that
+// can't implement Operable such as time.Time. It takes precedence over the
+// built-in operations and is meant to be called from init functions.
+func RegisterOperation(t reflect.Type, op Operation) {
+	customOperations.Store(t, op)
+	hasCustomOperations.Store(true)
+}
+
+// customOperation returns the Operation an Operable or a registered type
+// compares with, nil for any other value
+func customOperation(v Operand) Operation {
+	if v == nil {
+		return nil
+	}
+	if o, ok := v.(Operable); ok {
+		return o.Operation()
+	}
+	if !hasCustomOperations.Load() {
+		return nil
+	}
+	if op, ok := customOperations.Load(reflect.TypeOf(v)); ok {
+		return op.(Operation)
+	}
+	return nil
+}
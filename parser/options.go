@@ -0,0 +1,36 @@
+package parser
+
+// EvaluatorOption configures how NewEvaluator compiles a rule
+type EvaluatorOption func(*evaluatorOptions)
+
+type evaluatorOptions struct {
+	env     *Env
+	unknown bool
+	strict  bool
+}
+
+// WithEnv makes the functions of env available to the rule
+func WithEnv(env *Env) EvaluatorOption {
+	return func(o *evaluatorOptions) {
+		o.env = env
+	}
+}
+
+// WithUnknown makes comparisons of attributes missing from the input Unknown
+// rather than false, and the logical operators follow three-valued logic, so
+// that `not (x eq 1)` is Unknown and not true when there is no x. Use
+// ProcessTruth or Eval to get the Unknown, Process reports it as no match.
+func WithUnknown() EvaluatorOption {
+	return func(o *evaluatorOptions) {
+		o.unknown = true
+	}
+}
+
+// WithStrict makes Process and Eval fail with an *EvalError when an attribute
+// is missing from the input or has the wrong type, instead of the comparison
+// being false, so that a typo in an attribute name doesn't go unnoticed.
+func WithStrict() EvaluatorOption {
+	return func(o *evaluatorOptions) {
+		o.strict = true
+	}
+}
@@ -0,0 +1,153 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArithmetic(t *testing.T) {
+	input := obj{
+		"bytes_in":  100,
+		"bytes_out": 1050,
+		"json_in":   100.0,
+		"json_out":  1050.0,
+		"ratio":     2.5,
+		"zero":      0,
+		"neg":       -7,
+		"path":      "/api/v1/users",
+		"name":      "  Alice ",
+		"tags":      []interface{}{"a", "b", "c"},
+		"headers":   obj{"a": 1, "b": 2},
+		"hops":      []interface{}{obj{"ttl": 3}, obj{"ttl": 7}},
+		"uni":       "héllo",
+	}
+
+	tests := []testCase{
+		{`bytes_out / bytes_in gt 10`, input, true, false},
+		{`bytes_out / bytes_in eq 10`, input, false, false},
+		{`bytes_out / bytes_in eq 10.5`, input, true, false},
+		{`7 / 2 eq 3.5`, input, true, false},
+		{`bytes_in / 50 eq 2`, input, true, false},
+		{`bytes_out / bytes_in gt 9.9`, input, true, false},
+		{`json_out / json_in gt 10`, input, true, false},
+		{`json_out / json_in lt 11`, input, true, false},
+		{`bytes_out / 100.0 gt 10`, input, true, false},
+		{`bytes_out / 100.0 eq 10.5`, input, true, false},
+		{`bytes_out % bytes_in eq 50`, input, true, false},
+		{`bytes_in + bytes_out eq 1150`, input, true, false},
+		{`bytes_in - bytes_out lt 0`, input, true, false},
+		{`bytes_in + 2 * 10 eq 120`, input, true, false},
+		{`(bytes_in + 2) * 10 eq 1020`, input, true, false},
+		{`bytes_in - 50 - 25 eq 25`, input, true, false},
+		{`bytes_in * ratio eq 250`, input, true, false},
+		{`ratio gt 2`, input, true, false},
+		{`bytes_in eq bytes_out - 950`, input, true, false},
+		{`bytes_in * 2 lt bytes_out`, input, true, false},
+		{`100 eq bytes_in`, input, true, false},
+		{`bytes_in / zero eq 1`, input, false, false},
+		{`bytes_in + missing eq 1`, input, false, false},
+		{`path + 1 eq 1`, input, false, false},
+		{`len(path) eq 13`, input, true, false},
+		{`len(path) gt 256`, input, false, false},
+		{`len(uni) eq 5`, input, true, false},
+		{`len(tags) eq 3`, input, true, false},
+		{`len(headers) eq 2`, input, true, false},
+		{`len(hops[*].ttl) eq 2`, input, true, false},
+		{`len(missing) eq 0`, input, false, false},
+		{`lower(trim(name)) eq "alice"`, input, true, false},
+		{`upper(path) sw "/API"`, input, true, false},
+		{`trim(name) eq "Alice"`, input, true, false},
+		{`abs(neg) eq 7`, input, true, false},
+		{`abs(0 - ratio) eq 2.5`, input, true, false},
+		{`min(bytes_in, bytes_out, 500) eq 100`, input, true, false},
+		{`max(bytes_in, ratio) eq 100`, input, true, false},
+		{`max(hops[0].ttl, hops[1].ttl) eq 7`, input, true, false},
+		{`substr(path, 0, 4) eq "/api"`, input, true, false},
+		{`substr(path, 5) eq "v1/users"`, input, true, false},
+		{`substr(path, -5) eq "users"`, input, true, false},
+		{`substr(path, 10, 100) eq "ers"`, input, true, false},
+		{`substr(uni, 1, 1) eq "é"`, input, true, false},
+		{`len(substr(path, 0, 4)) eq 4`, input, true, false},
+		{`lower(path) in ["/api/v1/users", "/"]`, input, true, false},
+		{`lower(path) mt /users$/`, input, true, false},
+		{`len(path) gt 3 and not (abs(neg) lt 7)`, input, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			result, err := eval(t, tt.rule, tt.input)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.result, result, tt.rule)
+			assert.Equal(t, tt.result, Evaluate(fmt.Sprintf("(%s)", tt.rule), tt.input), tt.rule)
+		})
+	}
+}
+
+func TestRegexOrDivision(t *testing.T) {
+	input := obj{"a": 12, "b": 3, "c": 2, "amt": 4, "x": "a foo"}
+	tests := []testCase{
+		{`x mt / foo/`, input, true, false},
+		{`x matches / foo/`, obj{"x": "foo"}, false, false},
+		{`x not mt / bar/`, input, true, false},
+		{`x ~= /a /`, input, true, false},
+		{`a / b / c eq 2`, input, true, false},
+		{`a / b / c eq 2 and x MT / fo/`, input, true, false},
+		{`amt / c eq 2`, input, true, false},
+		{`x mt /a/ and a / b eq 4`, input, true, false},
+	}
+	for _, tt := range tests {
+		result, err := eval(t, tt.rule, tt.input)
+		assert.NoError(t, err, tt.rule)
+		assert.Equal(t, tt.result, result, tt.rule)
+	}
+}
+
+func TestArithmeticDebugErr(t *testing.T) {
+	ev, err := NewEvaluator(`a / b gt 1`)
+	assert.NoError(t, err)
+	res, err := ev.Eval(obj{"a": 1, "b": 0})
+	assert.NoError(t, err)
+	assert.False(t, res.Match)
+	var nestedErr *NestedError
+	assert.True(t, errors.As(res.DebugErr, &nestedErr))
+	assert.Equal(t, ErrDivisionByZero, nestedErr.Original())
+}
+
+func TestFunctionInvalid(t *testing.T) {
+	tests := []string{
+		`foo(x) eq 1`,
+		`len(x, y) eq 1`,
+		`len() eq 1`,
+		`substr(x) eq "a"`,
+		`x+1 eq 2`,
+		`x +1 eq 2`,
+	}
+	for _, rule := range tests {
+		_, err := NewEvaluator(rule)
+		var parseErr *ParseError
+		assert.True(t, errors.As(err, &parseErr), rule)
+	}
+}
+
+func TestValueString(t *testing.T) {
+	tests := []string{
+		`a + b * c`,
+		`(a + b) * c`,
+		`a - (b - c)`,
+		`a - b - c`,
+		`a / (b * c)`,
+		`len(x) + 1`,
+		`substr(lower(x), 0, 3)`,
+		`max(a, 1.5, -2)`,
+	}
+	for _, val := range tests {
+		ev, err := NewEvaluator(val + " eq 1")
+		if !assert.NoError(t, err, val) {
+			continue
+		}
+		assert.Equal(t, val, ev.Expr().(*CompareExpr).Left.String())
+	}
+}
@@ -0,0 +1,132 @@
+package parser
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type severity int
+
+var severityNames = []string{"low", "medium", "high"}
+
+func (s severity) Operation() Operation {
+	return &severityOperation{}
+}
+
+func toSeverity(v Operand) (severity, error) {
+	switch val := v.(type) {
+	case severity:
+		return val, nil
+	case string:
+		for i, name := range severityNames {
+			if strings.EqualFold(name, val) {
+				return severity(i), nil
+			}
+		}
+	}
+	return 0, newErrInvalidOperand(v, severity(0))
+}
+
+type severityOperation struct {
+	NullOperation
+}
+
+func (o *severityOperation) get(left, right Operand) (severity, severity, error) {
+	l, err := toSeverity(left)
+	if err != nil {
+		return 0, 0, err
+	}
+	r, err := toSeverity(right)
+	return l, r, err
+}
+
+func (o *severityOperation) EQ(left, right Operand) (bool, error) {
+	l, r, err := o.get(left, right)
+	return err == nil && l == r, err
+}
+
+func (o *severityOperation) GE(left, right Operand) (bool, error) {
+	l, r, err := o.get(left, right)
+	return err == nil && l >= r, err
+}
+
+func (o *severityOperation) LT(left, right Operand) (bool, error) {
+	l, r, err := o.get(left, right)
+	return err == nil && l < r, err
+}
+
+type timeOperation struct {
+	NullOperation
+}
+
+func (o *timeOperation) get(left, right Operand) (time.Time, time.Time, error) {
+	l, ok := left.(time.Time)
+	if !ok {
+		return time.Time{}, time.Time{}, newErrInvalidOperand(left, time.Time{})
+	}
+	if r, ok := right.(time.Time); ok {
+		return l, r, nil
+	}
+	str, ok := right.(string)
+	if !ok {
+		return time.Time{}, time.Time{}, newErrInvalidOperand(right, "")
+	}
+	r, err := time.Parse(time.RFC3339, str)
+	return l, r, err
+}
+
+func (o *timeOperation) LT(left, right Operand) (bool, error) {
+	l, r, err := o.get(left, right)
+	return err == nil && l.Before(r), err
+}
+
+func (o *timeOperation) GT(left, right Operand) (bool, error) {
+	l, r, err := o.get(left, right)
+	return err == nil && l.After(r), err
+}
+
+func TestCustomOperations(t *testing.T) {
+	RegisterOperation(reflect.TypeOf(time.Time{}), &timeOperation{})
+
+	input := obj{
+		"severity":   severity(2),
+		"threshold":  severity(1),
+		"severities": []interface{}{severity(0), severity(2)},
+		"created":    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		"updated":    time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		"level":      "medium",
+	}
+
+	tests := []testCase{
+		{`severity ge "high"`, input, true, false},
+		{`severity ge "HIGH"`, input, true, false},
+		{`severity lt "medium"`, input, false, false},
+		{`severity eq "low"`, input, false, false},
+		{`severity ge threshold`, input, true, false},
+		{`level eq threshold`, input, true, false},
+		{`severity ge "critical"`, input, false, false},
+		{`severity co "high"`, input, false, true},
+		{`severities co "high"`, input, true, false},
+		{`severities co "medium"`, input, false, false},
+		{`created lt "2024-02-01T00:00:00Z"`, input, true, false},
+		{`created gt "2024-02-01T00:00:00Z"`, input, false, false},
+		{`created lt updated`, input, true, false},
+		{`updated lt created`, input, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			result, err := eval(t, tt.rule, tt.input)
+			if tt.hasError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.result, result, tt.rule)
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/antlr4-go/antlr/v4"
+)
+
+// SyntaxError is a single lexer or parser error. Line is 1-based, Column and
+// Offset are 0-based and counted in runes from the start of the line and the
+// rule respectively.
+type SyntaxError struct {
+	Offset   int
+	Line     int
+	Column   int
+	Token    string
+	Expected []string
+	Msg      string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("line %d:%d %s", e.Line, e.Column, e.Msg)
+}
+
+// ParseError is returned by NewEvaluator when the rule is not valid. It holds
+// every error reported while lexing and parsing, in the order they were found.
+type ParseError struct {
+	Rule   string
+	Errors []*SyntaxError
+}
+
+func (e *ParseError) Error() string {
+	if len(e.Errors) == 0 {
+		return fmt.Sprintf("invalid rule %q", e.Rule)
+	}
+	msg := fmt.Sprintf("invalid rule %q: %s", e.Rule, e.Errors[0].Error())
+	if len(e.Errors) > 1 {
+		msg = fmt.Sprintf("%s (and %d more errors)", msg, len(e.Errors)-1)
+	}
+	return msg
+}
+
+// Unwrap returns the first syntax error so errors.As can reach it
+func (e *ParseError) Unwrap() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+	return e.Errors[0]
+}
+
+type errorListener struct {
+	*antlr.DefaultErrorListener
+
+	errs []*SyntaxError
+}
+
+func newErrorListener() *errorListener {
+	return &errorListener{
+		DefaultErrorListener: antlr.NewDefaultErrorListener(),
+	}
+}
+
+func (l *errorListener) SyntaxError(recognizer antlr.Recognizer, offendingSymbol interface{}, line, column int, msg string, e antlr.RecognitionException) {
+	synErr := &SyntaxError{
+		Line:   line,
+		Column: column,
+		Msg:    msg,
+	}
+
+	switch r := recognizer.(type) {
+	case antlr.Parser:
+		if tok, ok := offendingSymbol.(antlr.Token); ok {
+			synErr.Offset = tok.GetStart()
+			synErr.Token = tok.GetText()
+			if tok.GetTokenType() == antlr.TokenEOF {
+				synErr.Token = "<EOF>"
+			}
+		}
+		// a failed prediction only knows that none of the alternatives
+		// matched, the expected set at that state would be misleading
+		if _, ok := e.(*antlr.NoViableAltException); !ok {
+			synErr.Expected = tokenNames(r.GetExpectedTokens(), r.GetLiteralNames(), r.GetSymbolicNames())
+		}
+	case *antlr.BaseLexer:
+		start, stop := r.TokenStartCharIndex, r.GetInputStream().Index()
+		if stop > start {
+			// leave out the lookahead character the lexer choked on
+			stop--
+		}
+		synErr.Offset = start
+		synErr.Token = r.GetInputStream().GetText(start, stop)
+	}
+
+	l.errs = append(l.errs, synErr)
+}
+
+func tokenNames(set *antlr.IntervalSet, literalNames []string, symbolicNames []string) []string {
+	if set == nil {
+		return nil
+	}
+	var names []string
+	for _, interval := range set.GetIntervals() {
+		for t := interval.Start; t < interval.Stop; t++ {
+			switch {
+			case t == antlr.TokenEOF:
+				names = append(names, "<EOF>")
+			case t < len(literalNames) && literalNames[t] != "":
+				names = append(names, literalNames[t])
+			case t < len(symbolicNames) && symbolicNames[t] != "":
+				names = append(names, symbolicNames[t])
+			default:
+				names = append(names, fmt.Sprintf("<%d>", t))
+			}
+		}
+	}
+	return names
+}
@@ -1,9 +1,12 @@
 package parser
 
 import (
+	"errors"
 	"fmt"
 	"github.com/stretchr/testify/assert"
 	"net"
+	"net/netip"
+	"strings"
 	"testing"
 )
 
@@ -145,6 +148,14 @@ func TestIPMatchedRule(t *testing.T) {
 			obj{
 				"x": net.ParseIP("2001:0db8:85a3:0000:0000:8a2e:0370:7334"),
 			},
+			true,
+			false,
+		},
+		{
+			`x in 2001::8a2e:1/16`,
+			obj{
+				"x": net.ParseIP("2002:0db8:85a3:0000:0000:8a2e:0370:7334"),
+			},
 			false,
 			false,
 		},
@@ -163,3 +174,312 @@ func TestIPMatchedRule(t *testing.T) {
 		assert.Equal(t, tt.result, Evaluate(fmt.Sprintf("(%s)", tt.rule), tt.input), tt.rule)
 	}
 }
+
+func TestIPListMatchedRule(t *testing.T) {
+	tests := []testCase{
+		{`x in [10.0.0.0/8, 192.168.1.1, fd00::/8]`, obj{"x": "10.1.2.3"}, true, false},
+		{`x in [10.0.0.0/8, 192.168.1.1, fd00::/8]`, obj{"x": net.ParseIP("192.168.1.1")}, true, false},
+		{`x in [10.0.0.0/8, 192.168.1.1, fd00::/8]`, obj{"x": "192.168.1.2"}, false, false},
+		{`x in [10.0.0.0/8, 192.168.1.1, fd00::/8]`, obj{"x": "fd12::1"}, true, false},
+		{`x in [10.0.0.0/8, 192.168.1.1, fd00::/8]`, obj{"x": "fe80::1"}, false, false},
+		{`x in [::1]`, obj{"x": "::1"}, true, false},
+		{`x in [::, 2001:db8::/32]`, obj{"x": "2001:db8::1"}, true, false},
+		{`src.ip in [1.1.1.1,1.0.0.1]`, obj{"src": obj{"ip": "1.0.0.1"}}, true, false},
+	}
+
+	for _, tt := range tests {
+		result, err := eval(t, tt.rule, tt.input)
+		if tt.hasError {
+			assert.Error(t, err, tt.rule)
+			continue
+		} else {
+			assert.NoError(t, err)
+			assert.Equal(t, result, tt.result, fmt.Sprintf("rule :%s, input :%v", tt.rule, tt.input))
+		}
+		assert.Equal(t, false, Evaluate(tt.rule, obj{"x": 4.5}), tt.rule)
+		assert.Equal(t, tt.result, Evaluate(fmt.Sprintf("(%s)", tt.rule), tt.input), tt.rule)
+		assert.Equal(t, !tt.result, Evaluate(strings.Replace(tt.rule, " in ", " not in ", 1), tt.input), tt.rule)
+	}
+
+	for _, rule := range []string{
+		`x eq [10.0.0.0/8]`,
+		`x in [10.0.0.0/33]`,
+		`x in [10.0.0.0/8, 1]`,
+		`x in []`,
+	} {
+		_, err := NewEvaluator(rule)
+		assert.Error(t, err, rule)
+	}
+}
+
+func TestIPRangeMatchedRule(t *testing.T) {
+	tests := []testCase{
+		{`x in 192.168.1.10-192.168.1.20`, obj{"x": "192.168.1.10"}, true, false},
+		{`x in 192.168.1.10-192.168.1.20`, obj{"x": "192.168.1.20"}, true, false},
+		{`x in 192.168.1.10-192.168.1.20`, obj{"x": net.ParseIP("192.168.1.15")}, true, false},
+		{`x in 192.168.1.10-192.168.1.20`, obj{"x": "192.168.1.21"}, false, false},
+		{`x in 192.168.1.10-192.168.1.20`, obj{"x": "::ffff:192.168.1.11"}, true, false},
+		{`x in 192.168.1.10-192.168.1.20`, obj{"x": "fd00::1"}, false, false},
+		{`x in fd00::1-fd00::ff`, obj{"x": "fd00::80"}, true, false},
+		{`x in fd00::1-fd00::ff`, obj{"x": "fd00::100"}, false, false},
+		{`x in fd00::1-fd00::ff`, obj{"x": "0.0.0.1"}, false, false},
+		{`x in [10.0.0.0/8, 192.168.1.10-192.168.1.20]`, obj{"x": "192.168.1.12"}, true, false},
+		{`x in [10.0.0.0/8, 192.168.1.10-192.168.1.20]`, obj{"x": "192.168.1.9"}, false, false},
+	}
+
+	for _, tt := range tests {
+		result, err := eval(t, tt.rule, tt.input)
+		if tt.hasError {
+			assert.Error(t, err, tt.rule)
+			continue
+		} else {
+			assert.NoError(t, err, tt.rule)
+			assert.Equal(t, tt.result, result, fmt.Sprintf("rule :%s, input :%v", tt.rule, tt.input))
+		}
+		assert.Equal(t, false, Evaluate(tt.rule, obj{"x": 4.5}), tt.rule)
+		assert.Equal(t, !tt.result, Evaluate(strings.Replace(tt.rule, " in ", " not in ", 1), tt.input), tt.rule)
+	}
+
+	// a range is a right operand like a CIDR
+	r, err := ParseIPRange("10.0.0.5-10.0.0.99")
+	assert.NoError(t, err)
+	assert.True(t, Evaluate("x in r", obj{"x": "10.0.0.50", "r": r}))
+	assert.False(t, Evaluate("x in r", obj{"x": "10.0.0.100", "r": r}))
+	assert.True(t, Evaluate("x in r", obj{"x": "10.0.0.50", "r": "10.0.0.5-10.0.0.99"}))
+
+	for _, rule := range []string{
+		`x eq 10.0.0.5-10.0.0.99`,
+		`x gt 10.0.0.5-10.0.0.99`,
+		`x in 10.0.0.99-10.0.0.5`,
+		`x in 10.0.0.5-fd00::1`,
+		`x in [10.0.0.0/8, 10.0.0.99-10.0.0.5]`,
+	} {
+		_, err := NewEvaluator(rule)
+		assert.Error(t, err, rule)
+	}
+}
+
+func TestIPOrderMatchedRule(t *testing.T) {
+	tests := []testCase{
+		{`x ge 10.0.0.0 and x le 10.0.3.255`, obj{"x": "10.0.2.1"}, true, false},
+		{`x ge 10.0.0.0 and x le 10.0.3.255`, obj{"x": "10.0.4.0"}, false, false},
+		{`x gt 10.0.0.1`, obj{"x": "10.0.0.1"}, false, false},
+		{`x ge 10.0.0.1`, obj{"x": net.ParseIP("10.0.0.1")}, true, false},
+		{`x lt 10.0.0.10`, obj{"x": "10.0.0.9"}, true, false},
+		{`x lt 10.0.0.10`, obj{"x": "10.0.0.10"}, false, false},
+		{`x le 10.0.0.10`, obj{"x": netip.MustParseAddr("10.0.0.10")}, true, false},
+		// numerically, not as text
+		{`x gt 10.0.0.9`, obj{"x": "10.0.0.10"}, true, false},
+		// IPv4 addresses are before IPv6 ones, mapped ones being IPv4
+		{`x lt ::`, obj{"x": "255.255.255.255"}, true, false},
+		{`x gt 255.255.255.255`, obj{"x": "::"}, true, false},
+		{`x lt ::1`, obj{"x": "::ffff:1.2.3.4"}, true, false},
+		{`x gt fd00::1`, obj{"x": "fd00::2"}, true, false},
+		{`x ge y`, obj{"x": netip.MustParseAddr("10.0.0.2"), "y": netip.MustParseAddr("10.0.0.1")}, true, false},
+	}
+
+	for _, tt := range tests {
+		result, err := eval(t, tt.rule, tt.input)
+		if tt.hasError {
+			assert.Error(t, err, tt.rule)
+			continue
+		} else {
+			assert.NoError(t, err, tt.rule)
+			assert.Equal(t, tt.result, result, fmt.Sprintf("rule :%s, input :%v", tt.rule, tt.input))
+		}
+		assert.Equal(t, false, Evaluate(tt.rule, obj{"x": 4.5}), tt.rule)
+	}
+
+	for _, rule := range []string{
+		`x gt 10.0.0.0/8`,
+		`x le [10.0.0.1]`,
+	} {
+		_, err := NewEvaluator(rule)
+		assert.Error(t, err, rule)
+	}
+}
+
+func TestIPClassMatchedRule(t *testing.T) {
+	tests := []testCase{
+		{`x is private`, obj{"x": "10.1.2.3"}, true, false},
+		{`x is private`, obj{"x": "8.8.8.8"}, false, false},
+		{`x is not private`, obj{"x": "8.8.8.8"}, true, false},
+		{`x IS PRIVATE`, obj{"x": net.ParseIP("192.168.0.1")}, true, false},
+		{`x is loopback`, obj{"x": netip.MustParseAddr("::1")}, true, false},
+		{`x is link_local`, obj{"x": "169.254.169.254"}, true, false},
+		{`x is multicast`, obj{"x": "ff02::1"}, true, false},
+		{`x is global_unicast`, obj{"x": "8.8.8.8"}, true, false},
+		{`x is bogon`, obj{"x": "100.64.0.1"}, true, false},
+		{`x is not bogon`, obj{"x": "1.1.1.1"}, true, false},
+		{`x is ipv4`, obj{"x": "::ffff:1.2.3.4"}, true, false},
+		{`x is ipv6`, obj{"x": "::ffff:1.2.3.4"}, false, false},
+		{`x is ipv6`, obj{"x": "2001:db8::1"}, true, false},
+		{`x is documentation`, obj{"x": "203.0.113.7"}, true, false},
+		{`src.ip is private and dst.ip is not private`, obj{"src": obj{"ip": "10.0.0.1"}, "dst": obj{"ip": "1.1.1.1"}}, true, false},
+	}
+
+	for _, tt := range tests {
+		result, err := eval(t, tt.rule, tt.input)
+		if tt.hasError {
+			assert.Error(t, err, tt.rule)
+			continue
+		} else {
+			assert.NoError(t, err, tt.rule)
+			assert.Equal(t, tt.result, result, fmt.Sprintf("rule :%s, input :%v", tt.rule, tt.input))
+		}
+	}
+
+	// only addresses have classes
+	assert.False(t, Evaluate(`x is private`, obj{"x": 4.5}))
+	assert.False(t, Evaluate(`x is private`, obj{"x": "not an address"}))
+	assert.False(t, Evaluate(`x is private`, obj{}))
+
+	for _, rule := range []string{
+		`x is public`,
+		`x is 10.0.0.0/8`,
+		`x not is private`,
+		`x is "private"`,
+	} {
+		_, err := NewEvaluator(rule)
+		assert.Error(t, err, rule)
+	}
+}
+
+func TestNetipMatchedRule(t *testing.T) {
+	addr := netip.MustParseAddr("10.1.2.3")
+	tests := []testCase{
+		{`x eq 10.1.2.3`, obj{"x": addr}, true, false},
+		{`x eq 10.1.2.4`, obj{"x": addr}, false, false},
+		{`x in 10.0.0.0/8`, obj{"x": addr}, true, false},
+		{`x in 10.0.0.0/8`, obj{"x": netip.MustParseAddrPort("10.1.2.3:443")}, true, false},
+		{`x in [192.168.0.0/16, 10.1.2.3]`, obj{"x": addr}, true, false},
+		{`x eq y`, obj{"x": addr, "y": netip.MustParseAddr("10.1.2.3")}, true, false},
+		{`x eq y`, obj{"x": "10.1.2.3", "y": addr}, true, false},
+		{`x in y`, obj{"x": "10.1.2.3", "y": netip.MustParsePrefix("10.1.0.0/16")}, true, false},
+		{`x in y`, obj{"x": addr, "y": netip.MustParsePrefix("10.2.0.0/16")}, false, false},
+		{`x in y`, obj{"x": addr, "y": []netip.Prefix{netip.MustParsePrefix("fd00::/8"), netip.MustParsePrefix("10.0.0.0/8")}}, true, false},
+		{`x in y`, obj{"x": addr, "y": []netip.Addr{netip.MustParseAddr("::ffff:10.1.2.3")}}, true, false},
+
+		// IPv4-mapped addresses and networks are the IPv4 ones
+		{`x eq ::ffff:1.2.3.4`, obj{"x": "1.2.3.4"}, true, false},
+		{`x eq ::ffff:102:304`, obj{"x": netip.MustParseAddr("1.2.3.4")}, true, false},
+		{`x eq 1.2.3.4`, obj{"x": netip.MustParseAddr("::ffff:1.2.3.4")}, true, false},
+		{`x eq 1.2.3.4`, obj{"x": net.ParseIP("1.2.3.4").To16()}, true, false},
+		{`x in ::ffff:0:0/96`, obj{"x": "1.2.3.4"}, true, false},
+		{`x in ::ffff:1.2.3.0/120`, obj{"x": net.ParseIP("1.2.3.4")}, true, false},
+		{`x in 1.2.3.0/24`, obj{"x": "::ffff:1.2.3.4"}, true, false},
+		{`x in [::ffff:0:0/96]`, obj{"x": "1.2.3.4"}, true, false},
+		{`x in ::/0`, obj{"x": "1.2.3.4"}, false, false},
+		{`x in 0.0.0.0/0`, obj{"x": "::1"}, false, false},
+		{`x in y`, obj{"x": "1.2.3.4", "y": netip.MustParsePrefix("::ffff:1.2.0.0/112")}, true, false},
+
+		// networks on the left are compared as a whole
+		{`p eq 10.0.0.0/8`, obj{"p": netip.MustParsePrefix("10.0.0.0/8")}, true, false},
+		{`p eq 10.0.0.0/16`, obj{"p": netip.MustParsePrefix("10.0.0.0/8")}, false, false},
+		{`p ne 10.0.0.0/16`, obj{"p": netip.MustParsePrefix("10.0.0.0/8")}, true, false},
+		{`p eq 10.0.0.0`, obj{"p": netip.MustParsePrefix("10.0.0.0/8")}, false, false},
+		{`p eq 10.0.0.1`, obj{"p": netip.MustParsePrefix("10.0.0.1/32")}, true, false},
+		{`p eq ::ffff:10.0.0.0/104`, obj{"p": netip.MustParsePrefix("10.0.0.0/8")}, true, false},
+		{`p eq q`, obj{"p": netip.MustParsePrefix("10.1.0.0/16"), "q": netip.MustParsePrefix("10.1.0.0/16")}, true, false},
+		{`p eq q`, obj{"p": CIDRBuilder("10.1.0.0/16")[0], "q": netip.MustParsePrefix("10.1.2.0/16")}, true, false},
+		{`p eq q`, obj{"p": netip.MustParsePrefix("10.1.0.0/16"), "q": "10.1.0.0/16"}, true, false},
+		{`p in 10.0.0.0/8`, obj{"p": netip.MustParsePrefix("10.1.0.0/16")}, true, false},
+		{`p in 10.1.0.0/16`, obj{"p": netip.MustParsePrefix("10.0.0.0/8")}, false, false},
+		{`p in [192.168.0.0/16, 10.0.0.0/8]`, obj{"p": CIDRBuilder("10.1.0.0/16")[0]}, true, false},
+		{`p in [10.0.0.0/25, 10.0.0.128/25]`, obj{"p": netip.MustParsePrefix("10.0.0.0/24")}, true, false},
+		{`p in 10.0.0.0-10.0.0.200`, obj{"p": netip.MustParsePrefix("10.0.0.0/24")}, false, false},
+		{`p in 10.0.0.0-10.0.1.0`, obj{"p": netip.MustParsePrefix("10.0.0.0/24")}, true, false},
+		{`p in ::/0`, obj{"p": netip.MustParsePrefix("10.0.0.0/8")}, false, false},
+		{`p gt 10.0.0.0`, obj{"p": netip.MustParsePrefix("10.0.0.0/8")}, false, false},
+		{`p is private`, obj{"p": netip.MustParsePrefix("10.0.0.0/8")}, false, false},
+	}
+
+	for _, tt := range tests {
+		result, err := eval(t, tt.rule, tt.input)
+		if tt.hasError {
+			assert.Error(t, err, tt.rule)
+			continue
+		} else {
+			assert.NoError(t, err, tt.rule)
+			assert.Equal(t, tt.result, result, fmt.Sprintf("rule :%s, input :%v", tt.rule, tt.input))
+		}
+	}
+
+	// a network is present, and a value that isn't an address is of the
+	// wrong type rather than missing
+	prefix := netip.MustParsePrefix("10.0.0.0/8")
+	for _, tt := range []struct {
+		rule  string
+		input obj
+		match bool
+		cause error
+	}{
+		{`p eq 10.0.0.0/8`, obj{"p": prefix}, true, nil},
+		{`p eq q`, obj{"p": prefix, "q": prefix}, true, nil},
+		{`p in y`, obj{"p": prefix, "y": []netip.Prefix{netip.MustParsePrefix("0.0.0.0/0")}}, true, nil},
+		{`p gt 10.0.0.0`, obj{"p": prefix}, false, &ErrInvalidOperand{}},
+		{`f eq 10.0.0.1`, obj{"f": 1.5}, false, &ErrInvalidOperand{}},
+		{`f in 10.0.0.0/8`, obj{"f": true}, false, &ErrInvalidOperand{}},
+		{`f in 10.0.0.0/8`, obj{}, false, ErrEvalOperandMissing},
+	} {
+		ev, err := NewEvaluator(tt.rule, WithStrict())
+		assert.NoError(t, err)
+		match, err := ev.Process(tt.input)
+		assert.Equal(t, tt.match, match, tt.rule)
+		switch cause := tt.cause.(type) {
+		case nil:
+			assert.NoError(t, err, tt.rule)
+		case *ErrInvalidOperand:
+			assert.True(t, errors.As(err, &cause), "%s: %v", tt.rule, err)
+		default:
+			assert.True(t, errors.Is(err, cause), "%s: %v", tt.rule, err)
+		}
+	}
+
+	// networks are looked up in IP lists without allocating
+	op := &IPCompareOperation{}
+	var left Operand = netip.MustParsePrefix("10.0.0.0/24")
+	for _, entries := range [][]string{{"10.0.0.0/25", "10.0.0.128/25"}, {"192.168.0.0/16"}} {
+		list, err := ParseIPList(entries...)
+		assert.NoError(t, err)
+		var right Operand = list
+		allocs := testing.AllocsPerRun(100, func() {
+			op.IN(left, right)
+		})
+		assert.Zero(t, allocs, entries)
+	}
+
+	// functions get net types
+	env := NewEnv()
+	assert.NoError(t, env.Register("is_loopback", Function{
+		Params: []Type{TypeIP},
+		Result: TypeBool,
+		Call:   func(args []Operand) (Operand, error) { return args[0].(net.IP).IsLoopback(), nil },
+	}))
+	ev, err := NewEvaluator(`is_loopback(x) eq true`, WithEnv(env))
+	assert.NoError(t, err)
+	match, err := ev.Process(obj{"x": netip.MustParseAddr("::1")})
+	assert.NoError(t, err)
+	assert.True(t, match)
+}
+
+func TestIPList(t *testing.T) {
+	list, err := ParseIPList("10.0.0.0/8", "192.168.1.1", "fd00::/8")
+	assert.NoError(t, err)
+	assert.Equal(t, "[10.0.0.0/8, 192.168.1.1, fd00::/8]", list.String())
+	assert.True(t, list.Contains(net.ParseIP("10.255.0.1")))
+	assert.False(t, list.Contains(net.ParseIP("192.168.1.2")))
+
+	// a list is a right operand like []*net.IPNet
+	assert.True(t, Evaluate("x in arr", obj{"x": net.ParseIP("fd00::1"), "arr": list}))
+
+	_, err = ParseIPList("10.0.0.0/8", "10.0.0.256")
+	assert.Error(t, err)
+
+	// IPv4-mapped entries are the IPv4 ones
+	list, err = ParseIPList("::ffff:10.0.0.0/104", "::ffff:192.168.1.1")
+	assert.NoError(t, err)
+	assert.Equal(t, "[10.0.0.0/8, 192.168.1.1]", list.String())
+	assert.True(t, list.ContainsAddr(netip.MustParseAddr("10.1.2.3")))
+	assert.True(t, list.Contains(net.ParseIP("::ffff:192.168.1.1")))
+}
@@ -246,3 +246,149 @@ func TestLogicalExpWithAndWithExtraSpaces(t *testing.T) {
 		})
 	}
 }
+
+func TestLogicalPrecedence(t *testing.T) {
+	tests := []testCase{
+		{
+			// and binds tighter than or: a or (b and c)
+			`x eq 1 or y eq 2 and z eq 3`,
+			obj{
+				"x": 1,
+				"y": 0,
+				"z": 0,
+			},
+			true,
+			false,
+		},
+		{
+			`x eq 1 and y eq 2 or z eq 3`,
+			obj{
+				"x": 0,
+				"z": 3,
+			},
+			true,
+			false,
+		},
+		{
+			`(x eq 1 or y eq 2) and z eq 3`,
+			obj{
+				"x": 1,
+				"z": 0,
+			},
+			false,
+			false,
+		},
+		{
+			// xor sits between and and or: a or (b xor (c and d))
+			`x eq 1 or y eq 2 xor z eq 3 and w eq 4`,
+			obj{
+				"x": 0,
+				"y": 2,
+				"z": 3,
+				"w": 4,
+			},
+			false,
+			false,
+		},
+		{
+			`x eq 1 xor y eq 2`,
+			obj{
+				"x": 1,
+				"y": 0,
+			},
+			true,
+			false,
+		},
+		{
+			`x eq 1 xor y eq 2`,
+			obj{
+				"x": 1,
+				"y": 2,
+			},
+			false,
+			false,
+		},
+		{
+			`x eq 1 AND y eq 2 OR z eq 3 XOR w eq 4`,
+			obj{
+				"x": 1,
+				"y": 2,
+			},
+			true,
+			false,
+		},
+		{
+			`x eq 1 && y eq 2 || z eq 3`,
+			obj{
+				"x": 1,
+				"y": 0,
+				"z": 3,
+			},
+			true,
+			false,
+		},
+		{
+			`x eq 1 && y eq 2 || z eq 3`,
+			obj{
+				"x": 0,
+				"y": 2,
+			},
+			false,
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			assert.Equal(t, tt.result, Evaluate(tt.rule, tt.input), tt.rule)
+			assert.Equal(t, tt.result, Evaluate(fmt.Sprintf("(%s)", tt.rule), tt.input), tt.rule)
+		})
+	}
+}
+
+func TestNot(t *testing.T) {
+	tests := []testCase{
+		{`not x eq 1`, obj{"x": 1}, false, false},
+		{`not x eq 1`, obj{"x": 2}, true, false},
+		{`NOT x eq 1`, obj{"x": 2}, true, false},
+		{`! x eq 1`, obj{"x": 2}, true, false},
+		{`!x eq 1`, obj{"x": 2}, true, false},
+		{`!(x eq 1)`, obj{"x": 2}, true, false},
+		{`not not x eq 1`, obj{"x": 1}, true, false},
+		// not binds tighter than and/or
+		{`not x eq 1 and y eq 2`, obj{"x": 2, "y": 2}, true, false},
+		{`not x eq 1 and y eq 2`, obj{"x": 1, "y": 2}, false, false},
+		{`not x eq 1 or y eq 2`, obj{"x": 1, "y": 2}, true, false},
+		{`y eq 2 and not x eq 1`, obj{"x": 3, "y": 2}, true, false},
+		{`x not in [1, 2]`, obj{"x": 3}, true, false},
+		{`x not in [1, 2]`, obj{"x": 2}, false, false},
+		{`x NOT IN ["a", "b"]`, obj{"x": "c"}, true, false},
+		{`x not co "foo"`, obj{"x": "barbaz"}, true, false},
+		{`x not co "foo"`, obj{"x": "afoob"}, false, false},
+		{`x not sw "foo"`, obj{"x": "foobar"}, false, false},
+		{`x not ew "bar"`, obj{"x": "foobaz"}, true, false},
+		{`x not matches /foo/`, obj{"x": "bar"}, true, false},
+		{`x not mt /foo/`, obj{"x": "food"}, false, false},
+		{`x not in 10.0.0.0/8`, obj{"x": "192.168.1.1"}, true, false},
+		{`x not in 10.0.0.0/8`, obj{"x": "10.1.2.3"}, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			assert.Equal(t, tt.result, Evaluate(tt.rule, tt.input), tt.rule)
+			assert.Equal(t, tt.result, Evaluate(fmt.Sprintf("(%s)", tt.rule), tt.input), tt.rule)
+		})
+	}
+}
+
+func TestNotInvalid(t *testing.T) {
+	for _, rule := range []string{
+		`x not eq 1`,
+		`x not gt 1`,
+		`x not ne 1.1.1.1`,
+		`x not`,
+	} {
+		_, err := NewEvaluator(rule)
+		assert.Error(t, err, rule)
+	}
+}
@@ -0,0 +1,116 @@
+package parser
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArrayPaths(t *testing.T) {
+	input := obj{
+		"headers": []interface{}{"a", "b", "c"},
+		"hops": []interface{}{
+			obj{"ip": "10.0.0.1"},
+			obj{"ip": "10.0.0.2"},
+			obj{"ip": "192.168.1.1"},
+		},
+		"items": []obj{
+			{"sku": "A-1", "qty": 1},
+			{"sku": "B-2", "qty": 5},
+			{"qty": 3},
+		},
+		"matrix": []interface{}{
+			[]interface{}{1, 2},
+			[]interface{}{3, 4},
+		},
+		"labels": obj{
+			"app.kubernetes.io/name": "web",
+			"and":                    "keyword",
+		},
+		"ports": []int{22, 80, 443},
+	}
+
+	tests := []testCase{
+		{`headers[0] eq "a"`, input, true, false},
+		{`headers[2] eq "c"`, input, true, false},
+		{`headers[-1] eq "c"`, input, true, false},
+		{`headers[-3] eq "a"`, input, true, false},
+		{`headers[3] pr`, input, false, false},
+		{`headers[-4] pr`, input, false, false},
+		{`hops[0].ip eq 10.0.0.1`, input, true, false},
+		{`hops[-1].ip eq "192.168.1.1"`, input, true, false},
+		{`hops[-1].ip in 192.168.0.0/16`, input, true, false},
+		{`matrix[1][0] eq 3`, input, true, false},
+		{`ports[1] eq 80`, input, true, false},
+		{`labels["app.kubernetes.io/name"] eq "web"`, input, true, false},
+		{`labels["and"] eq "keyword"`, input, true, false},
+		{`labels["missing"] pr`, input, false, false},
+		{`items[*].sku co "B-2"`, input, true, false},
+		{`items[*].sku co "C-3"`, input, false, false},
+		{`items[*].qty co 3`, input, true, false},
+		{`items[*].sku pr`, input, true, false},
+		{`items[*].price pr`, input, false, false},
+		{`matrix[*][*] co 4`, input, true, false},
+		{`hops[*].ip co "10.0.0.2"`, input, true, false},
+		{`headers[0].x pr`, input, false, false},
+		{`ports co 443`, input, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			result, err := eval(t, tt.rule, tt.input)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.result, result, tt.rule)
+			assert.Equal(t, tt.result, Evaluate(fmt.Sprintf("(%s)", tt.rule), tt.input), tt.rule)
+		})
+	}
+}
+
+func TestVariableArrayPaths(t *testing.T) {
+	input := obj{
+		"x":     "b",
+		"list":  []interface{}{"a", "b"},
+		"other": obj{"keys": []interface{}{"b"}},
+	}
+	assert.True(t, Evaluate(`x eq list[1]`, input))
+	assert.True(t, Evaluate(`list[-1] eq other.keys[0]`, input))
+	assert.True(t, Evaluate(`list co other.keys[0]`, input))
+	assert.False(t, Evaluate(`mixed co x`, obj{"x": "b", "mixed": []interface{}{nil, obj{}, "a"}}))
+	assert.True(t, Evaluate(`mixed co x`, obj{"x": "a", "mixed": []interface{}{nil, obj{}, "a"}}))
+}
+
+func TestPathString(t *testing.T) {
+	tests := []string{
+		`x`,
+		`x.a.b`,
+		`hops[-1].ip`,
+		`items[*].sku`,
+		`labels["app.kubernetes.io/name"]`,
+		`labels["and"]`,
+		`x["a\"b"]`,
+	}
+	for _, rule := range tests {
+		ev, err := NewEvaluator(rule + " pr")
+		if !assert.NoError(t, err, rule) {
+			continue
+		}
+		assert.Equal(t, rule, ev.Expr().(*PresentExpr).Path.String())
+	}
+	assert.Equal(t, `x.a`, NewPath("x", "a").String())
+}
+
+func TestReservedWords(t *testing.T) {
+	for _, word := range []string{"and", "AND", "or", "OR", "xor", "XOR", "not", "in", "is", "IS", "eq", "mt", "matches", "co", "pr"} {
+		// a reserved word can't be an attribute name
+		for _, rule := range []string{word + ` eq 1`, `x.` + word + ` eq 1`} {
+			_, err := NewEvaluator(rule)
+			assert.Error(t, err, rule)
+		}
+
+		// but it can be a quoted key
+		rule := `x["` + word + `"] eq 1`
+		assert.True(t, Evaluate(rule, obj{"x": obj{word: 1}}), rule)
+		assert.Equal(t, `x["`+word+`"]`, NewPath("x", word).String())
+	}
+}
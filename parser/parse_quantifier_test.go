@@ -0,0 +1,100 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuantifiers(t *testing.T) {
+	input := obj{
+		"connections": []interface{}{
+			obj{"port": 443, "proto": "tcp"},
+			obj{"port": 22, "proto": "tcp"},
+			obj{"port": 53, "proto": "udp"},
+		},
+		"answers": []obj{
+			{"type": "A", "data": "10.0.0.1"},
+			{"type": "A", "data": "10.0.0.2"},
+			{"type": "CNAME", "data": "example.com"},
+		},
+		"hops":  []interface{}{obj{"ttl": 1, "trace": []interface{}{obj{"ok": true}}}},
+		"empty": []interface{}{},
+		"name":  "not a list",
+		"limit": 2,
+	}
+
+	tests := []testCase{
+		{`any(connections, port eq 22 and proto eq "tcp")`, input, true, false},
+		{`any(connections, port eq 53 and proto eq "tcp")`, input, false, false},
+		{`all(connections, port gt 0)`, input, true, false},
+		{`all(connections, proto eq "tcp")`, input, false, false},
+		{`none(connections, port eq 8080)`, input, true, false},
+		{`none(connections, port eq 22)`, input, false, false},
+		{`ANY(answers, data in 10.0.0.0/8)`, input, true, false},
+		{`any( answers , type eq "AAAA" )`, input, false, false},
+		{`any(hops, any(trace, ok eq true))`, input, true, false},
+		{`any(hops, ttl eq 1 and not (trace pr))`, input, false, false},
+		{`not any(connections, port eq 22)`, input, false, false},
+		{`any(connections, port eq 22) and name pr`, input, true, false},
+		{`any(empty, port eq 22)`, input, false, false},
+		{`all(empty, port eq 22)`, input, true, false},
+		{`none(empty, port eq 22)`, input, true, false},
+		{`any(missing, port eq 22)`, input, false, false},
+		{`all(missing, port eq 22)`, input, false, false},
+		{`none(missing, port eq 22)`, input, false, false},
+		{`all(name, port eq 22)`, input, false, false},
+		{`count(answers, type eq "A") eq 2`, input, true, false},
+		{`count(answers, type eq "A") gt 2`, input, false, false},
+		{`count(answers, type eq "A") ge limit`, input, true, false},
+		{`count(connections, proto eq "tcp") lt 2.5`, input, true, false},
+		{`count(empty, port pr) eq 0`, input, true, false},
+		{`count(missing, port pr) eq 0`, input, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			result, err := eval(t, tt.rule, tt.input)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.result, result, tt.rule)
+			assert.Equal(t, tt.result, Evaluate(fmt.Sprintf("(%s)", tt.rule), tt.input), tt.rule)
+		})
+	}
+
+	// the quantifier names are still usable as attribute names
+	assert.True(t, Evaluate(`any eq 1 and count.all eq 2`, obj{"any": 1, "count": obj{"all": 2}}))
+}
+
+func TestQuantifierDebugErr(t *testing.T) {
+	ev, err := NewEvaluator(`any(connections, port eq 22)`)
+	assert.NoError(t, err)
+
+	res, err := ev.Eval(obj{})
+	assert.NoError(t, err)
+	var nestedErr *NestedError
+	assert.True(t, errors.As(res.DebugErr, &nestedErr))
+	assert.Equal(t, ErrEvalOperandMissing, nestedErr.Original())
+
+	res, err = ev.Eval(obj{"connections": "x"})
+	assert.NoError(t, err)
+	assert.True(t, errors.As(res.DebugErr, &nestedErr))
+	_, ok := nestedErr.Original().(*ErrInvalidOperand)
+	assert.True(t, ok)
+}
+
+func TestQuantifierInvalid(t *testing.T) {
+	tests := []string{
+		`some(connections, port eq 22)`,
+		`any(connections, port eq 22) eq 1`,
+		`count(connections, port eq 22)`,
+		`count(connections, port eq 22) co 1`,
+		`any(connections)`,
+	}
+	for _, rule := range tests {
+		_, err := NewEvaluator(rule)
+		var parseErr *ParseError
+		assert.True(t, errors.As(err, &parseErr), rule)
+	}
+}
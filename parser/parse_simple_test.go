@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
@@ -22,14 +23,18 @@ var doPanic = false
 func eval(t *testing.T, rule string, input obj) (bool, error) {
 	ev, err := NewEvaluator(rule)
 
-	assert.NoError(t, err)
+	if !assert.NoError(t, err) {
+		return false, err
+	}
 	return ev.Process(input)
 }
 
 func evalPanic(t *testing.T, rule string, input obj) (bool, error) {
 	ev, err := NewEvaluatorWithPanicOnParseError(rule)
 
-	assert.NoError(t, err)
+	if !assert.NoError(t, err) {
+		return false, err
+	}
 	return ev.Process(input)
 }
 
@@ -41,10 +46,47 @@ func TestInvalidRule(t *testing.T) {
 	}
 
 	for _, rule := range invalidRules {
-		result, err := eval(t, rule, obj{"x": 1})
-		assert.Error(t, err, rule)
-		assert.False(t, result)
+		ev, err := NewEvaluator(rule)
+		assert.Nil(t, ev, rule)
+		var parseErr *ParseError
+		if !assert.True(t, errors.As(err, &parseErr), rule) {
+			continue
+		}
+		assert.Equal(t, rule, parseErr.Rule)
+		assert.NotEmpty(t, parseErr.Errors, rule)
+		assert.False(t, Evaluate(rule, obj{"x": 1}), rule)
+	}
+}
 
+func TestParseErrorPosition(t *testing.T) {
+	tests := []struct {
+		rule     string
+		line     int
+		column   int
+		token    string
+		expected []string
+	}{
+		{`x eq`, 1, 4, "<EOF>", nil},
+		{`x eq 1 and`, 1, 10, "<EOF>", []string{"SP"}},
+		{`x === 1`, 1, 4, "=", nil},
+		{`x eq 1 y`, 1, 6, " ", []string{"<EOF>"}},
+		{`y ~ 1`, 1, 2, "~", nil},
+		{"x eq 1 and \ny eq", 2, 4, "<EOF>", nil},
+	}
+
+	for _, tt := range tests {
+		_, err := NewEvaluator(tt.rule)
+		var parseErr *ParseError
+		if !assert.True(t, errors.As(err, &parseErr), tt.rule) {
+			continue
+		}
+		first := parseErr.Errors[0]
+		assert.Equal(t, tt.line, first.Line, tt.rule)
+		assert.Equal(t, tt.column, first.Column, tt.rule)
+		assert.Equal(t, tt.token, first.Token, tt.rule)
+		if tt.expected != nil {
+			assert.Equal(t, tt.expected, first.Expected, tt.rule)
+		}
 	}
 }
 
@@ -1286,6 +1328,13 @@ func TestString(t *testing.T) {
 			false,
 			false,
 		},
+		// escapes are resolved as in JSON
+		{`x eq "a\"b"`, obj{"x": `a"b`}, true, false},
+		{`x eq "C:\\dir"`, obj{"x": `C:\dir`}, true, false},
+		{`x eq "C:\\dir"`, obj{"x": `C:\\dir`}, false, false},
+		{`x eq "caf\u00e9"`, obj{"x": "café"}, true, false},
+		{`x co "\t"`, obj{"x": "a\tb"}, true, false},
+		{`x in ["a\"b", "c"]`, obj{"x": `a"b`}, true, false},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,95 @@
+package parser
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStrict(t *testing.T) {
+	input := obj{
+		"x":    1,
+		"s":    "abc",
+		"list": []interface{}{obj{"a": 1}, obj{"a": 2}},
+	}
+
+	tests := []struct {
+		rule     string
+		match    bool
+		path     string
+		fragment string
+		cause    error
+	}{
+		{`x eq 1`, true, "", "", nil},
+		{`x eq 1 or y eq 1`, true, "", "", nil},
+		{`y eq null`, true, "", "", nil},
+		{`y pr`, false, "", "", nil},
+		{`x eq 2`, false, "", "", nil},
+		{`any(list, a eq 2)`, true, "", "", nil},
+		{`y eq 1`, false, "y", "y eq 1", ErrEvalOperandMissing},
+		{`x eq 1 and (y.z in [1, 2])`, false, "y.z", "y.z in [1, 2]", ErrEvalOperandMissing},
+		{`not (y eq 1)`, false, "y", "y eq 1", ErrEvalOperandMissing},
+		{`x eq z`, false, "z", "x eq z", ErrEvalOperandMissing},
+		{`y eq z`, false, "y", "y eq z", ErrEvalOperandMissing},
+		{`len(y) gt 1`, false, "len(y)", "len(y) gt 1", ErrEvalOperandMissing},
+		{`any(missing, a eq 1)`, false, "missing", "any(missing, a eq 1)", ErrEvalOperandMissing},
+		{`count(list, b eq 1) eq 0`, false, "b", "b eq 1", ErrEvalOperandMissing},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			ev, err := NewEvaluator(tt.rule, WithStrict())
+			if !assert.NoError(t, err) {
+				return
+			}
+			match, err := ev.Process(input)
+			assert.Equal(t, tt.match, match)
+			if tt.cause == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var evalErr *EvalError
+			if !assert.True(t, errors.As(err, &evalErr), tt.rule) {
+				return
+			}
+			assert.Equal(t, tt.path, evalErr.Path)
+			assert.Equal(t, tt.fragment, evalErr.Fragment)
+			assert.True(t, errors.Is(err, tt.cause))
+
+			// without the option the rule is just false
+			ev, err = NewEvaluator(tt.rule)
+			assert.NoError(t, err)
+			_, err = ev.Process(input)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestStrictInvalidOperand(t *testing.T) {
+	ev, err := NewEvaluator(`x eq 1 and s gt 2`, WithStrict())
+	assert.NoError(t, err)
+	match, err := ev.Process(obj{"x": 1, "s": "abc"})
+	assert.False(t, match)
+	var evalErr *EvalError
+	if assert.True(t, errors.As(err, &evalErr)) {
+		assert.Equal(t, "s", evalErr.Path)
+		assert.Equal(t, "s gt 2", evalErr.Fragment)
+		assert.Equal(t, Span{Start: 11, End: 17}, evalErr.Span)
+	}
+	var operandErr *ErrInvalidOperand
+	assert.True(t, errors.As(err, &operandErr))
+
+	ev, err = NewEvaluator(`lower(s) eq "a" or lower(x) eq "b"`, WithStrict())
+	assert.NoError(t, err)
+	_, err = ev.Process(obj{"x": 1, "s": "abc"})
+	assert.True(t, errors.As(err, &evalErr))
+	assert.Equal(t, `lower(x) eq "b"`, evalErr.Fragment)
+
+	// the span counts characters, not bytes
+	ev, err = NewEvaluator(`s eq "é" and y eq 1`, WithStrict())
+	assert.NoError(t, err)
+	_, err = ev.Process(obj{"s": "é"})
+	assert.True(t, errors.As(err, &evalErr))
+	assert.Equal(t, "y eq 1", evalErr.Fragment)
+}
@@ -0,0 +1,83 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnknown(t *testing.T) {
+	input := obj{
+		"x":    1,
+		"s":    "abc",
+		"null": nil,
+		"list": []interface{}{obj{"a": 1}, obj{"b": 2}},
+		"all":  []interface{}{obj{"a": 1}, obj{"a": 2}},
+	}
+
+	tests := []struct {
+		rule  string
+		truth Truth
+	}{
+		{`x eq 1`, True},
+		{`x eq 2`, False},
+		{`y eq 1`, Unknown},
+		{`not (y eq 1)`, Unknown},
+		{`not y eq 1`, Unknown},
+		{`y not in [1, 2]`, Unknown},
+		{`y pr`, False},
+		{`not (y pr)`, True},
+		{`y eq null`, True},
+		{`null eq null`, True},
+		{`x eq y`, Unknown},
+		{`y + 1 gt 0`, Unknown},
+		{`len(y) eq 0`, Unknown},
+
+		{`y eq 1 and x eq 1`, Unknown},
+		{`y eq 1 and x eq 2`, False},
+		{`x eq 2 and y eq 1`, False},
+		{`y eq 1 or x eq 1`, True},
+		{`x eq 1 or y eq 1`, True},
+		{`y eq 1 or x eq 2`, Unknown},
+		{`y eq 1 xor x eq 1`, Unknown},
+		{`x eq 1 xor s eq "abc"`, False},
+		{`not (y eq 1 and x eq 2)`, True},
+
+		{`any(list, a eq 1)`, True},
+		{`any(list, a eq 2)`, Unknown},
+		{`all(list, a eq 1)`, Unknown},
+		{`all(list, a eq 2)`, False},
+		{`all(all, a gt 0)`, True},
+		{`none(list, a eq 2)`, Unknown},
+		{`none(all, a eq 3)`, True},
+		{`any(missing, a eq 1)`, Unknown},
+		{`any(s, a eq 1)`, False},
+		{`count(list, a eq 1) eq 1`, Unknown},
+		{`count(all, a eq 1) eq 1`, True},
+		{`count(missing, a eq 1) eq 0`, Unknown},
+		{`count(all, a eq 1) eq y`, Unknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			ev, err := NewEvaluator(tt.rule, WithUnknown())
+			if !assert.NoError(t, err) {
+				return
+			}
+			truth, err := ev.ProcessTruth(input)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.truth, truth, tt.rule)
+
+			match, err := ev.Process(input)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.truth == True, match, tt.rule)
+
+			// without the option missing attributes are simply false
+			ev, err = NewEvaluator(tt.rule)
+			assert.NoError(t, err)
+			truth, err = ev.ProcessTruth(input)
+			assert.NoError(t, err)
+			assert.NotEqual(t, Unknown, truth, tt.rule)
+		})
+	}
+}
@@ -0,0 +1,223 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Schema describes the input rules are evaluated with, for Check
+type Schema struct {
+	Type Type
+	// Elem is the schema of the elements of a TypeList, nil if they can be
+	// anything
+	Elem *Schema
+	// Fields are the attributes of a TypeObject
+	Fields map[string]*Schema
+	// Open objects can have attributes that are not in Fields
+	Open bool
+}
+
+// field returns the schema of an attribute of an object, and whether it can
+// be there at all
+func (s *Schema) field(name string) (*Schema, bool) {
+	if s.Type != TypeObject {
+		return anySchema, s.Type == TypeAny
+	}
+	if f, ok := s.Fields[name]; ok {
+		return f, true
+	}
+	return anySchema, s.Open
+}
+
+func (s *Schema) elem() *Schema {
+	if s.Elem == nil {
+		return anySchema
+	}
+	return s.Elem
+}
+
+var anySchema = &Schema{Type: TypeAny}
+
+// closeNames returns the attributes of the object close to name, closest
+// first, to suggest them for a misspelt one
+func (s *Schema) closeNames(name string) []string {
+	type candidate struct {
+		name string
+		dist int
+	}
+	var candidates []candidate
+	max := len(name)/3 + 1
+	for field := range s.Fields {
+		dist := editDistance(strings.ToLower(name), strings.ToLower(field))
+		if dist <= max {
+			candidates = append(candidates, candidate{field, dist})
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].dist != candidates[j].dist {
+			return candidates[i].dist < candidates[j].dist
+		}
+		return candidates[i].name < candidates[j].name
+	})
+	names := make([]string, len(candidates))
+	for i, c := range candidates {
+		names[i] = c.name
+	}
+	return names
+}
+
+// editDistance is the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
+
+// jsonSchema is the part of JSON Schema SchemaFromJSONSchema understands
+type jsonSchema struct {
+	Ref                  string                 `json:"$ref"`
+	Type                 json.RawMessage        `json:"type"`
+	Format               string                 `json:"format"`
+	Properties           map[string]*jsonSchema `json:"properties"`
+	AdditionalProperties json.RawMessage        `json:"additionalProperties"`
+	Items                *jsonSchema            `json:"items"`
+	Definitions          map[string]*jsonSchema `json:"definitions"`
+	Defs                 map[string]*jsonSchema `json:"$defs"`
+}
+
+// jsonSchemaFormats maps the string formats to the types of the rules. ipv4
+// and ipv6 are standard, cidr and semver are not but common.
+var jsonSchemaFormats = map[string]Type{
+	"ipv4":   TypeIP,
+	"ipv6":   TypeIP,
+	"ip":     TypeIP,
+	"cidr":   TypeCIDR,
+	"semver": TypeVersion,
+}
+
+// SchemaFromJSONSchema converts a JSON Schema describing the input. It
+// understands type, properties, additionalProperties, items, format and local
+// $refs; anything it doesn't, such as anyOf, is of any type.
+func SchemaFromJSONSchema(data []byte) (*Schema, error) {
+	var root jsonSchema
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+	conv := &jsonSchemaConverter{root: &root, seen: make(map[string]*Schema)}
+	return conv.convert(&root)
+}
+
+type jsonSchemaConverter struct {
+	root *jsonSchema
+	// seen holds the converted $refs, which also makes recursive schemas work
+	seen map[string]*Schema
+}
+
+func (c *jsonSchemaConverter) convert(js *jsonSchema) (*Schema, error) {
+	if js.Ref != "" {
+		return c.ref(js.Ref)
+	}
+
+	schema := &Schema{Type: TypeAny}
+	var types []string
+	if len(js.Type) > 0 {
+		var single string
+		if err := json.Unmarshal(js.Type, &single); err == nil {
+			types = []string{single}
+		} else if err := json.Unmarshal(js.Type, &types); err != nil {
+			return nil, fmt.Errorf("invalid type %s", js.Type)
+		}
+	}
+	// a nullable attribute is one that can be missing, which any can
+	var nonNull []string
+	for _, t := range types {
+		if t != "null" {
+			nonNull = append(nonNull, t)
+		}
+	}
+	if len(nonNull) != 1 {
+		return schema, nil
+	}
+
+	switch nonNull[0] {
+	case "string":
+		schema.Type = TypeString
+		if t, ok := jsonSchemaFormats[js.Format]; ok {
+			schema.Type = t
+		}
+	case "integer":
+		schema.Type = TypeInt
+	case "number":
+		schema.Type = TypeNumber
+	case "boolean":
+		schema.Type = TypeBool
+	case "array":
+		schema.Type = TypeList
+		if js.Items != nil {
+			elem, err := c.convert(js.Items)
+			if err != nil {
+				return nil, err
+			}
+			schema.Elem = elem
+		}
+	case "object":
+		schema.Type = TypeObject
+		schema.Fields = make(map[string]*Schema)
+		for name, prop := range js.Properties {
+			field, err := c.convert(prop)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			schema.Fields[name] = field
+		}
+		// additional properties are allowed unless they are false
+		schema.Open = string(js.AdditionalProperties) != "false"
+	}
+	return schema, nil
+}
+
+func (c *jsonSchemaConverter) ref(ref string) (*Schema, error) {
+	if schema, ok := c.seen[ref]; ok {
+		return schema, nil
+	}
+	var defs map[string]*jsonSchema
+	var name string
+	switch {
+	case strings.HasPrefix(ref, "#/definitions/"):
+		defs, name = c.root.Definitions, strings.TrimPrefix(ref, "#/definitions/")
+	case strings.HasPrefix(ref, "#/$defs/"):
+		defs, name = c.root.Defs, strings.TrimPrefix(ref, "#/$defs/")
+	default:
+		return nil, fmt.Errorf("unsupported $ref %s", ref)
+	}
+	def, ok := defs[name]
+	if !ok {
+		return nil, fmt.Errorf("undefined $ref %s", ref)
+	}
+
+	schema := &Schema{}
+	c.seen[ref] = schema
+	converted, err := c.convert(def)
+	if err != nil {
+		return nil, err
+	}
+	*schema = *converted
+	return schema, nil
+}
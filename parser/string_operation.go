@@ -136,6 +136,12 @@ func (o *StringOperation) IN(left Operand, right Operand) (bool, error) {
 				}
 			}
 		}
+		// and if val is an IP range, if leftVal is in the range
+		if strings.Contains(val, "-") {
+			if r, err := ParseIPRange(val); err == nil && r.Contains(net.ParseIP(leftVal)) {
+				return true, nil
+			}
+		}
 
 		if leftVal == val {
 			return true, nil
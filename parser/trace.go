@@ -0,0 +1,144 @@
+package parser
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Trace records how a rule was evaluated. It mirrors the compiled rule: every
+// node of the rule that was evaluated has a Trace, and the nodes skipped by
+// `and` and `or` short-circuiting have one with Skipped set. The query of a
+// quantifier has a Trace per element of the list.
+type Trace struct {
+	Expr Expr   `json:"-"`
+	Span Span   `json:"span"`
+	Text string `json:"text"`
+
+	Result  Truth `json:"result"`
+	Skipped bool  `json:"skipped,omitempty"`
+
+	// Left and Right are the operands of a comparison, and Operation the name
+	// of the Operation that compared them
+	Left      Operand `json:"left,omitempty"`
+	Right     Operand `json:"right,omitempty"`
+	Operation string  `json:"operation,omitempty"`
+
+	// Element is the list element a quantifier evaluated this node with
+	Element Operand `json:"element,omitempty"`
+
+	Children []*Trace `json:"children,omitempty"`
+}
+
+// String renders the trace as an indented tree, one node per line
+func (t *Trace) String() string {
+	var sb strings.Builder
+	t.write(&sb, 0)
+	return sb.String()
+}
+
+func (t *Trace) write(sb *strings.Builder, depth int) {
+	sb.WriteString(strings.Repeat("  ", depth))
+	if t.Skipped {
+		sb.WriteString("skipped")
+	} else {
+		sb.WriteString(t.Result.String())
+	}
+	sb.WriteString(": ")
+	sb.WriteString(t.Text)
+	if t.Element != nil {
+		fmt.Fprintf(sb, " [element %v]", t.Element)
+	}
+	if t.Operation != "" {
+		fmt.Fprintf(sb, " [%v %s %v]", t.Left, t.Operation, t.Right)
+	}
+	sb.WriteByte('\n')
+	for _, child := range t.Children {
+		child.write(sb, depth+1)
+	}
+}
+
+type tracer struct {
+	root  *Trace
+	stack []*Trace
+	// element is the list element of the next node entered
+	element Operand
+}
+
+func newTrace(e Expr, rule []rune) *Trace {
+	t := &Trace{Expr: e}
+	if n, ok := e.(interface{ span() Span }); ok {
+		t.Span = n.span()
+	}
+	if t.Span.End <= len(rule) && t.Span.Start <= t.Span.End {
+		t.Text = string(rule[t.Span.Start:t.Span.End])
+	}
+	return t
+}
+
+func (tr *tracer) enter(e Expr, rule []rune) *Trace {
+	t := newTrace(e, rule)
+	t.Element, tr.element = tr.element, nil
+	if len(tr.stack) == 0 {
+		tr.root = t
+	} else {
+		parent := tr.stack[len(tr.stack)-1]
+		parent.Children = append(parent.Children, t)
+	}
+	tr.stack = append(tr.stack, t)
+	return t
+}
+
+func (tr *tracer) leave(t *Trace, result Truth, rule []rune) {
+	t.Result = result
+	tr.stack = tr.stack[:len(tr.stack)-1]
+
+	// add what wasn't evaluated
+	switch e := t.Expr.(type) {
+	case *LogicalExpr:
+		if len(t.Children) == 1 {
+			t.Children = append(t.Children, skippedTrace(e.Right, rule))
+		}
+	case *QuantifierExpr:
+		if len(t.Children) == 0 {
+			t.Children = append(t.Children, skippedTrace(e.Expr, rule))
+		}
+	case *CountExpr:
+		if len(t.Children) == 0 {
+			t.Children = append(t.Children, skippedTrace(e.Expr, rule))
+		}
+	}
+}
+
+func (tr *tracer) operands(left, right Operand) {
+	t := tr.stack[len(tr.stack)-1]
+	t.Left = left
+	t.Right = right
+}
+
+func (tr *tracer) operation(op Operation) {
+	if op == nil {
+		return
+	}
+	typ := reflect.TypeOf(op)
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	tr.stack[len(tr.stack)-1].Operation = typ.Name()
+}
+
+func skippedTrace(e Expr, rule []rune) *Trace {
+	t := newTrace(e, rule)
+	t.Skipped = true
+	switch e := e.(type) {
+	case *LogicalExpr:
+		t.Children = []*Trace{skippedTrace(e.Left, rule), skippedTrace(e.Right, rule)}
+	case *NotExpr:
+		t.Children = []*Trace{skippedTrace(e.Expr, rule)}
+	case *QuantifierExpr:
+		t.Children = []*Trace{skippedTrace(e.Expr, rule)}
+	case *CountExpr:
+		t.Children = []*Trace{skippedTrace(e.Expr, rule)}
+	}
+	return t
+}
@@ -0,0 +1,98 @@
+package parser
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProcessWithTrace(t *testing.T) {
+	ev, err := NewEvaluator(`x eq 1 and (y sw "ab" or z in [1, 2]) and not (w pr)`)
+	assert.NoError(t, err)
+
+	match, trace, err := ev.ProcessWithTrace(obj{"x": 1, "y": "abc", "w": 1})
+	assert.NoError(t, err)
+	assert.False(t, match)
+	assert.Equal(t, `false: x eq 1 and (y sw "ab" or z in [1, 2]) and not (w pr)
+  true: x eq 1 and (y sw "ab" or z in [1, 2])
+    true: x eq 1 [1 IntOperation 1]
+    true: y sw "ab" or z in [1, 2]
+      true: y sw "ab" [abc StringOperation ab]
+      skipped: z in [1, 2]
+  false: not (w pr)
+    true: w pr
+`, trace.String())
+
+	cmp := trace.Children[0].Children[0]
+	assert.Equal(t, Span{Start: 0, End: 6}, cmp.Span)
+	assert.Equal(t, 1, cmp.Left)
+	assert.Equal(t, 1, cmp.Right)
+	assert.Equal(t, "IntOperation", cmp.Operation)
+	assert.Equal(t, True, cmp.Result)
+
+	match, trace, err = ev.ProcessWithTrace(obj{"x": 2})
+	assert.NoError(t, err)
+	assert.False(t, match)
+	assert.Equal(t, `false: x eq 1 and (y sw "ab" or z in [1, 2]) and not (w pr)
+  false: x eq 1 and (y sw "ab" or z in [1, 2])
+    false: x eq 1 [2 IntOperation 1]
+    skipped: y sw "ab" or z in [1, 2]
+      skipped: y sw "ab"
+      skipped: z in [1, 2]
+  skipped: not (w pr)
+    skipped: w pr
+`, trace.String())
+
+	data, err := json.Marshal(trace.Children[0].Children[0])
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"span":{"Start":0,"End":6},"text":"x eq 1","result":"false","left":2,"right":1,"operation":"IntOperation"}`, string(data))
+}
+
+func TestProcessWithTraceDebugErr(t *testing.T) {
+	ev, err := NewEvaluator(`x eq 1 and missing eq 1`)
+	assert.NoError(t, err)
+
+	// the debug error is recorded as by Process
+	match, _, err := ev.ProcessWithTrace(obj{"x": 1})
+	assert.NoError(t, err)
+	assert.False(t, match)
+	var nestedErr *NestedError
+	assert.True(t, errors.As(ev.LastDebugErr(), &nestedErr))
+	assert.Equal(t, ErrEvalOperandMissing, nestedErr.Original())
+
+	match, _, err = ev.ProcessWithTrace(obj{"x": 1, "missing": 1})
+	assert.NoError(t, err)
+	assert.True(t, match)
+	assert.NoError(t, ev.LastDebugErr())
+}
+
+func TestProcessWithTraceQuantifier(t *testing.T) {
+	ev, err := NewEvaluator(`any(conns, port eq 22) or count(empty, a pr) eq 0`)
+	assert.NoError(t, err)
+
+	match, trace, err := ev.ProcessWithTrace(obj{
+		"conns": []interface{}{obj{"port": 80}, obj{"port": 22}},
+		"empty": []interface{}{},
+	})
+	assert.NoError(t, err)
+	assert.True(t, match)
+	assert.Equal(t, `true: any(conns, port eq 22) or count(empty, a pr) eq 0
+  true: any(conns, port eq 22)
+    false: port eq 22 [element map[port:80]] [80 IntOperation 22]
+    true: port eq 22 [element map[port:22]] [22 IntOperation 22]
+  skipped: count(empty, a pr) eq 0
+    skipped: a pr
+`, trace.String())
+
+	match, trace, err = ev.ProcessWithTrace(obj{"empty": []interface{}{}})
+	assert.NoError(t, err)
+	assert.True(t, match)
+	assert.Equal(t, `true: any(conns, port eq 22) or count(empty, a pr) eq 0
+  false: any(conns, port eq 22)
+    skipped: port eq 22
+  true: count(empty, a pr) eq 0 [0 IntOperation 0]
+    skipped: a pr
+`, trace.String())
+}
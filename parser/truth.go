@@ -0,0 +1,77 @@
+package parser
+
+import "fmt"
+
+// Truth is the result of a rule in three-valued logic. Rules only evaluate
+// to Unknown when compiled WithUnknown, for attributes missing from the input.
+type Truth int
+
+const (
+	False Truth = iota
+	True
+	Unknown
+)
+
+func truth(b bool) Truth {
+	if b {
+		return True
+	}
+	return False
+}
+
+func (t Truth) String() string {
+	switch t {
+	case False:
+		return "false"
+	case True:
+		return "true"
+	case Unknown:
+		return "unknown"
+	}
+	return fmt.Sprintf("Truth(%d)", int(t))
+}
+
+func (t Truth) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// The logical operations follow Kleene's logic: Unknown is a value that is
+// either true or false, so `Unknown and False` is False but `Unknown and True`
+// is Unknown.
+
+func (t Truth) not() Truth {
+	switch t {
+	case True:
+		return False
+	case False:
+		return True
+	}
+	return Unknown
+}
+
+func (t Truth) and(o Truth) Truth {
+	if t == False || o == False {
+		return False
+	}
+	if t == Unknown || o == Unknown {
+		return Unknown
+	}
+	return True
+}
+
+func (t Truth) or(o Truth) Truth {
+	if t == True || o == True {
+		return True
+	}
+	if t == Unknown || o == Unknown {
+		return Unknown
+	}
+	return False
+}
+
+func (t Truth) xor(o Truth) Truth {
+	if t == Unknown || o == Unknown {
+		return Unknown
+	}
+	return truth(t != o)
+}
@@ -0,0 +1,179 @@
+package parser
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/netip"
+)
+
+// Type is the type of a function parameter or result
+type Type int
+
+const (
+	// TypeAny accepts any value and is the type of attribute paths since
+	// nothing is known about the input when a rule is compiled
+	TypeAny Type = iota
+	TypeBool
+	TypeInt
+	TypeFloat
+	// TypeNumber is an int or a float
+	TypeNumber
+	TypeString
+	TypeIP
+	TypeCIDR
+	TypeVersion
+	TypeList
+	TypeObject
+)
+
+var typeNames = [...]string{
+	TypeAny:     "any",
+	TypeBool:    "bool",
+	TypeInt:     "int",
+	TypeFloat:   "float",
+	TypeNumber:  "number",
+	TypeString:  "string",
+	TypeIP:      "ip",
+	TypeCIDR:    "cidr",
+	TypeVersion: "version",
+	TypeList:    "list",
+	TypeObject:  "object",
+}
+
+func (t Type) String() string {
+	if t >= 0 && int(t) < len(typeNames) {
+		return typeNames[t]
+	}
+	return fmt.Sprintf("Type(%d)", int(t))
+}
+
+func isNumeric(t Type) bool {
+	return t == TypeInt || t == TypeFloat || t == TypeNumber
+}
+
+// accepts reports whether a value of static type arg can be passed where t is
+// expected. An int is a valid float, and a number can be an int.
+func (t Type) accepts(arg Type) bool {
+	if t == TypeAny || arg == TypeAny || t == arg {
+		return true
+	}
+	if isNumeric(t) && isNumeric(arg) {
+		return t != TypeInt || arg != TypeFloat
+	}
+	// strings are parsed at runtime
+	return (t == TypeIP || t == TypeCIDR || t == TypeVersion) && arg == TypeString
+}
+
+// convert turns a value of the input into the Go type functions get for t:
+// bool, int, float64, string, net.IP, *net.IPNet, semver.Version or
+// []interface{}. netip values are turned into the net ones. A float with no
+// fractional part is a valid int since JSON decodes every number as a float.
+func (t Type) convert(v Operand) (Operand, error) {
+	switch t {
+	case TypeBool:
+		if _, ok := v.(bool); ok {
+			return v, nil
+		}
+		return nil, newErrInvalidOperand(v, false)
+	case TypeInt:
+		if i, ok := intValue(v); ok {
+			return i, nil
+		}
+		if f, ok := v.(float64); ok && f == math.Trunc(f) {
+			return int(f), nil
+		}
+		return nil, newErrInvalidOperand(v, 0)
+	case TypeFloat:
+		return toFloat(v)
+	case TypeNumber:
+		if isNumber(v) {
+			return v, nil
+		}
+		return nil, newErrInvalidOperand(v, 0.0)
+	case TypeString:
+		if _, ok := v.(string); ok {
+			return v, nil
+		}
+		return nil, newErrInvalidOperand(v, "")
+	case TypeIP:
+		if ip, ok := v.(net.IP); ok {
+			return ip, nil
+		}
+		switch v.(type) {
+		case netip.Addr, netip.AddrPort:
+			if addr, ok := toAddr(v); ok {
+				return net.IP(addr.AsSlice()), nil
+			}
+		}
+		if str, ok := v.(string); ok {
+			if ip := net.ParseIP(str); ip != nil {
+				return ip, nil
+			}
+		}
+		return nil, newErrInvalidOperand(v, net.IP{})
+	case TypeCIDR:
+		if ipNet, ok := v.(*net.IPNet); ok {
+			return ipNet, nil
+		}
+		if prefix, ok := v.(netip.Prefix); ok && prefix.IsValid() {
+			return prefixNet(prefix), nil
+		}
+		if str, ok := v.(string); ok {
+			if _, ipNet, err := net.ParseCIDR(str); err == nil {
+				return ipNet, nil
+			}
+		}
+		return nil, newErrInvalidOperand(v, &net.IPNet{})
+	case TypeVersion:
+		return (&VersionOperation{}).getVersion(v)
+	case TypeList:
+		if list, ok := listElems(v); ok {
+			return list, nil
+		}
+		return nil, newErrInvalidOperand(v, []interface{}{})
+	}
+	return v, nil
+}
+
+// literalTypes is the static type of each kind of literal
+var literalTypes = map[LiteralKind]Type{
+	LiteralBool:       TypeBool,
+	LiteralInt:        TypeInt,
+	LiteralFloat:      TypeFloat,
+	LiteralString:     TypeString,
+	LiteralIP:         TypeIP,
+	LiteralCIDR:       TypeCIDR,
+	LiteralVersion:    TypeVersion,
+	LiteralIntList:    TypeList,
+	LiteralFloatList:  TypeList,
+	LiteralStringList: TypeList,
+	LiteralIPList:     TypeList,
+	LiteralIPRange:    TypeList,
+	LiteralIPClass:    TypeIP,
+}
+
+// staticType is what is known of the type of a value when the rule is
+// compiled
+func staticType(v Value) Type {
+	switch val := v.(type) {
+	case *Literal:
+		if t, ok := literalTypes[val.Kind]; ok {
+			return t
+		}
+	case *ArithExpr:
+		left, right := staticType(val.Left), staticType(val.Right)
+		if left == TypeInt && right == TypeInt && val.Op != ArithDiv {
+			return TypeInt
+		}
+		if left == TypeFloat || right == TypeFloat || val.Op == ArithDiv {
+			return TypeFloat
+		}
+		return TypeNumber
+	case *CallExpr:
+		if val.fn != nil {
+			return val.fn.Result
+		}
+	}
+	return TypeAny
+}
@@ -8,22 +8,24 @@ type VersionOperation struct {
 	NullOperation
 }
 
+func (v *VersionOperation) getVersion(operand Operand) (semver.Version, error) {
+	switch val := operand.(type) {
+	case semver.Version:
+		return val, nil
+	case string:
+		return semver.Make(val)
+	}
+	var exp string
+	return semver.Version{}, newErrInvalidOperand(operand, exp)
+}
+
 func (v *VersionOperation) get(left Operand, right Operand) (semver.Version, semver.Version, error) {
 	var leftVer, rightVer semver.Version
-	leftVal, ok := left.(string)
-	if !ok {
-		return leftVer, rightVer, newErrInvalidOperand(left, leftVal)
-	}
-	rightVal, ok := right.(string)
-	if !ok {
-		return leftVer, rightVer, newErrInvalidOperand(right, rightVal)
-	}
-	var err error
-	leftVer, err = semver.Make(leftVal)
+	leftVer, err := v.getVersion(left)
 	if err != nil {
 		return leftVer, rightVer, err
 	}
-	rightVer, err = semver.Make(rightVal)
+	rightVer, err = v.getVersion(right)
 	return leftVer, rightVer, err
 }
 
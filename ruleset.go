@@ -0,0 +1,267 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/alex4386/rules/parser"
+)
+
+// Action is what a policy does with an item a rule matches. Any string can be
+// an action, these are the common ones.
+type Action string
+
+const (
+	ActionAllow Action = "allow"
+	ActionDeny  Action = "deny"
+	ActionLog   Action = "log"
+	ActionTag   Action = "tag"
+)
+
+// NamedRule is a rule of a RuleSet
+type NamedRule struct {
+	ID          string
+	Rule        string
+	Description string
+	Tags        []string
+	// Priority orders the rules, higher priorities are evaluated first and
+	// rules of the same priority in the order they were given
+	Priority int
+	Action   Action
+	// Continue makes a FirstMatch evaluation go on after the rule matched,
+	// e.g. for rules that only log or tag
+	Continue bool
+	// Disabled rules are compiled but never evaluated
+	Disabled bool
+	// ExpiresAt is when the rule stops being evaluated, never if zero
+	ExpiresAt time.Time
+}
+
+// active reports whether the rule is evaluated at now
+func (r *NamedRule) active(now time.Time) bool {
+	return !r.Disabled && (r.ExpiresAt.IsZero() || now.Before(r.ExpiresAt))
+}
+
+// Mode is how a RuleSet is evaluated
+type Mode int
+
+const (
+	// FirstMatch stops at the first rule that matches, not counting those
+	// with Continue
+	FirstMatch Mode = iota
+	// AllMatch evaluates every rule and reports all those that match
+	AllMatch
+)
+
+func (m Mode) String() string {
+	switch m {
+	case FirstMatch:
+		return "first-match"
+	case AllMatch:
+		return "all-match"
+	}
+	return fmt.Sprintf("Mode(%d)", int(m))
+}
+
+type ruleSetOptions struct {
+	mode          Mode
+	defaultAction Action
+	evalOpts      []parser.EvaluatorOption
+	now           func() time.Time
+}
+
+type RuleSetOption func(*ruleSetOptions)
+
+func WithMode(mode Mode) RuleSetOption {
+	return func(o *ruleSetOptions) {
+		o.mode = mode
+	}
+}
+
+// WithDefaultAction is the action of items no rule matches
+func WithDefaultAction(action Action) RuleSetOption {
+	return func(o *ruleSetOptions) {
+		o.defaultAction = action
+	}
+}
+
+// WithClock is the clock rule expiry is checked with, time.Now by default
+func WithClock(now func() time.Time) RuleSetOption {
+	return func(o *ruleSetOptions) {
+		o.now = now
+	}
+}
+
+// WithEvaluatorOptions compiles the rules with opts, e.g. parser.WithEnv
+func WithEvaluatorOptions(opts ...parser.EvaluatorOption) RuleSetOption {
+	return func(o *ruleSetOptions) {
+		o.evalOpts = append(o.evalOpts, opts...)
+	}
+}
+
+// RuleError is a rule of a RuleSet that can't be compiled or evaluated. File
+// is set for rules loaded from a file, and Index is the position of the rule
+// in the file or the slice given to NewRuleSet.
+type RuleError struct {
+	File  string
+	Index int
+	ID    string
+	Err   error
+}
+
+func (e *RuleError) Error() string {
+	where := fmt.Sprintf("rule %q", e.ID)
+	if e.ID == "" {
+		where = fmt.Sprintf("rules[%d]", e.Index)
+	}
+	if e.File != "" {
+		where = e.File + ": " + where
+	}
+	return fmt.Sprintf("%s: %v", where, e.Err)
+}
+
+func (e *RuleError) Unwrap() error {
+	return e.Err
+}
+
+type compiledRule struct {
+	NamedRule
+	ev *parser.Evaluator
+}
+
+// RuleSet is an ordered policy of named rules. It is immutable and safe for
+// concurrent use by multiple goroutines.
+type RuleSet struct {
+	rules         []compiledRule
+	mode          Mode
+	defaultAction Action
+	now           func() time.Time
+}
+
+// NewRuleSet compiles the rules. The error joins a *RuleError for every rule
+// that doesn't compile or has an empty or duplicate ID.
+func NewRuleSet(rules []NamedRule, opts ...RuleSetOption) (*RuleSet, error) {
+	rs, errs := newRuleSet(rules, nil, opts)
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return rs, nil
+}
+
+// ruleSource is where a rule comes from, for errors
+type ruleSource struct {
+	file  string
+	index int
+}
+
+// newRuleSet compiles the rules, returning the set of those that compiled along
+// with the errors of the others. sources are where the rules come from, their
+// position in rules if nil.
+func newRuleSet(rules []NamedRule, sources []ruleSource, opts []RuleSetOption) (*RuleSet, []error) {
+	options := ruleSetOptions{now: time.Now}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	rs := &RuleSet{
+		rules:         make([]compiledRule, 0, len(rules)),
+		mode:          options.mode,
+		defaultAction: options.defaultAction,
+		now:           options.now,
+	}
+	var errs []error
+	ids := map[string]bool{}
+	for i, rule := range rules {
+		src := ruleSource{index: i}
+		if sources != nil {
+			src = sources[i]
+		}
+		switch {
+		case rule.ID == "":
+			errs = append(errs, &RuleError{File: src.file, Index: src.index, Err: fmt.Errorf("empty ID")})
+			continue
+		case ids[rule.ID]:
+			errs = append(errs, &RuleError{File: src.file, Index: src.index, ID: rule.ID, Err: fmt.Errorf("duplicate ID")})
+			continue
+		}
+		ids[rule.ID] = true
+		ev, err := parser.NewEvaluator(rule.Rule, options.evalOpts...)
+		if err != nil {
+			errs = append(errs, &RuleError{File: src.file, Index: src.index, ID: rule.ID, Err: err})
+			continue
+		}
+		rs.rules = append(rs.rules, compiledRule{NamedRule: rule, ev: ev})
+	}
+	sort.SliceStable(rs.rules, func(i, j int) bool {
+		return rs.rules[i].Priority > rs.rules[j].Priority
+	})
+	return rs, errs
+}
+
+// Rules returns the rules in the order they are evaluated
+func (rs *RuleSet) Rules() []NamedRule {
+	rules := make([]NamedRule, len(rs.rules))
+	for i, rule := range rs.rules {
+		rules[i] = rule.NamedRule
+	}
+	return rules
+}
+
+// Match is a rule that matched an item
+type Match struct {
+	ID       string
+	Priority int
+	Action   Action
+	Tags     []string
+}
+
+// Decision is the outcome of evaluating a RuleSet against an item
+type Decision struct {
+	// Matches are the rules that matched, in the order they were evaluated
+	Matches []Match
+	// Action is the action of the first match without Continue, or the
+	// default action
+	Action Action
+	// Default is set when Action is the default action
+	Default bool
+}
+
+// IDs returns the IDs of the rules that matched
+func (d *Decision) IDs() []string {
+	ids := make([]string, len(d.Matches))
+	for i, m := range d.Matches {
+		ids[i] = m.ID
+	}
+	return ids
+}
+
+// Evaluate evaluates the rules against item, skipping those that are disabled
+// or expired. An error evaluating a rule, e.g. one compiled parser.WithStrict,
+// stops the evaluation and is a *RuleError.
+func (rs *RuleSet) Evaluate(item map[string]interface{}) (*Decision, error) {
+	decision := &Decision{Action: rs.defaultAction, Default: true}
+	now := rs.now()
+	for _, rule := range rs.rules {
+		if !rule.active(now) {
+			continue
+		}
+		match, err := rule.ev.Process(item)
+		if err != nil {
+			return nil, &RuleError{ID: rule.ID, Err: err}
+		}
+		if !match {
+			continue
+		}
+		decision.Matches = append(decision.Matches, Match{ID: rule.ID, Priority: rule.Priority, Action: rule.Action, Tags: rule.Tags})
+		if rule.Continue || !decision.Default {
+			continue
+		}
+		decision.Action, decision.Default = rule.Action, false
+		if rs.mode == FirstMatch {
+			break
+		}
+	}
+	return decision, nil
+}
@@ -0,0 +1,222 @@
+package rules
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/alex4386/rules/parser"
+	"gopkg.in/yaml.v3"
+)
+
+// ruleSetFile is the format of rule set files. JSON files are read with the
+// YAML decoder, JSON being a subset of YAML.
+type ruleSetFile struct {
+	name string
+
+	Mode          string      `yaml:"mode"`
+	DefaultAction Action      `yaml:"default_action"`
+	Rules         []ruleEntry `yaml:"rules"`
+}
+
+type ruleEntry struct {
+	ID          string     `yaml:"id"`
+	Description string     `yaml:"description"`
+	Priority    int        `yaml:"priority"`
+	Enabled     *bool      `yaml:"enabled"`
+	Tags        []string   `yaml:"tags"`
+	Action      Action     `yaml:"action"`
+	Continue    bool       `yaml:"continue"`
+	ExpiresAt   time.Time  `yaml:"expires_at"`
+	Rule        string     `yaml:"rule"`
+	Tests       []ruleTest `yaml:"tests"`
+}
+
+// ruleTest is an inline test case of a rule: whether it matches input
+type ruleTest struct {
+	Name  string                 `yaml:"name"`
+	Input map[string]interface{} `yaml:"input"`
+	Match bool                   `yaml:"match"`
+}
+
+// LoadRuleSet reads a rule set file, see ParseRuleSet
+func LoadRuleSet(path string, opts ...RuleSetOption) (*RuleSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRuleSet(path, data, opts...)
+}
+
+// ParseRuleSet compiles the rule set file data, in YAML or JSON, named name in
+// errors. The mode and default action of the file come before opts. Every rule
+// is compiled, disabled ones included, and its inline tests run: the error
+// joins a *RuleError for every rule that doesn't compile, fails a test or has
+// an empty or duplicate ID.
+func ParseRuleSet(name string, data []byte, opts ...RuleSetOption) (*RuleSet, error) {
+	file, err := decodeRuleSetFile(name, data)
+	if err != nil {
+		return nil, err
+	}
+	return compileRuleSetFiles([]*ruleSetFile{file}, opts)
+}
+
+// LoadRuleSetDir loads the rule set files of dir, those ending in .yaml, .yml
+// or .json, as a single rule set, see ParseRuleSet. IDs must be unique across
+// the files, and the files that set a mode or a default action must agree on
+// it. Hidden files and subdirectories are ignored.
+func LoadRuleSetDir(dir string, opts ...RuleSetOption) (*RuleSet, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []*ruleSetFile
+	var errs []error
+	for _, entry := range entries {
+		if !isRuleSetFile(entry.Name()) || entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		file, err := decodeRuleSetFile(path, data)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		files = append(files, file)
+	}
+	rs, err := compileRuleSetFiles(files, opts)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return rs, nil
+}
+
+func isRuleSetFile(name string) bool {
+	if strings.HasPrefix(name, ".") {
+		return false
+	}
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	}
+	return false
+}
+
+func decodeRuleSetFile(name string, data []byte) (*ruleSetFile, error) {
+	file := &ruleSetFile{name: name}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(file); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	if _, ok := parseMode(file.Mode); !ok {
+		return nil, fmt.Errorf("%s: unknown mode %q", name, file.Mode)
+	}
+	return file, nil
+}
+
+func parseMode(s string) (Mode, bool) {
+	switch s {
+	case "", FirstMatch.String():
+		return FirstMatch, true
+	case AllMatch.String():
+		return AllMatch, true
+	}
+	return FirstMatch, false
+}
+
+// compileRuleSetFiles compiles the rules of files as a single rule set and runs
+// their tests
+func compileRuleSetFiles(files []*ruleSetFile, opts []RuleSetOption) (*RuleSet, error) {
+	var errs []error
+	var fileOpts []RuleSetOption
+	var modeFile, actionFile *ruleSetFile
+	for _, file := range files {
+		if file.Mode != "" {
+			if modeFile != nil && file.Mode != modeFile.Mode {
+				errs = append(errs, fmt.Errorf("%s: mode %s conflicts with %s of %s", file.name, file.Mode, modeFile.Mode, modeFile.name))
+			} else if modeFile == nil {
+				modeFile = file
+				mode, _ := parseMode(file.Mode)
+				fileOpts = append(fileOpts, WithMode(mode))
+			}
+		}
+		if file.DefaultAction != "" {
+			if actionFile != nil && file.DefaultAction != actionFile.DefaultAction {
+				errs = append(errs, fmt.Errorf("%s: default action %s conflicts with %s of %s", file.name, file.DefaultAction, actionFile.DefaultAction, actionFile.name))
+			} else if actionFile == nil {
+				actionFile = file
+				fileOpts = append(fileOpts, WithDefaultAction(file.DefaultAction))
+			}
+		}
+	}
+
+	var rules []NamedRule
+	var sources []ruleSource
+	for _, file := range files {
+		for i, entry := range file.Rules {
+			rules = append(rules, NamedRule{
+				ID:          entry.ID,
+				Rule:        entry.Rule,
+				Description: entry.Description,
+				Tags:        entry.Tags,
+				Priority:    entry.Priority,
+				Action:      entry.Action,
+				Continue:    entry.Continue,
+				Disabled:    entry.Enabled != nil && !*entry.Enabled,
+				ExpiresAt:   entry.ExpiresAt,
+			})
+			sources = append(sources, ruleSource{file: file.name, index: i})
+		}
+	}
+	rs, compileErrs := newRuleSet(rules, sources, append(fileOpts, opts...))
+	errs = append(errs, compileErrs...)
+
+	compiled := map[string]*parser.Evaluator{}
+	for _, rule := range rs.rules {
+		compiled[rule.ID] = rule.ev
+	}
+	for _, file := range files {
+		for i, entry := range file.Rules {
+			// only the first rule of an ID is in the set
+			ev := compiled[entry.ID]
+			if ev == nil {
+				continue
+			}
+			delete(compiled, entry.ID)
+			for j, test := range entry.Tests {
+				testName := test.Name
+				if testName == "" {
+					testName = fmt.Sprintf("tests[%d]", j)
+				}
+				match, err := ev.Process(test.Input)
+				switch {
+				case err != nil:
+					err = fmt.Errorf("test %s: %w", testName, err)
+				case match != test.Match:
+					err = fmt.Errorf("test %s: got match %t, want %t", testName, match, test.Match)
+				}
+				if err != nil {
+					errs = append(errs, &RuleError{File: file.name, Index: i, ID: entry.ID, Err: err})
+				}
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return rs, nil
+}
@@ -0,0 +1,165 @@
+package rules
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const policyYAML = `
+mode: first-match
+default_action: deny
+rules:
+  - id: log-ssh
+    description: log every SSH connection
+    priority: 100
+    tags: [ssh, audit]
+    action: log
+    continue: true
+    rule: port eq 22
+  - id: allow-internal
+    priority: 50
+    action: allow
+    rule: src in 10.0.0.0/8 and user.name pr
+    tests:
+      - name: internal
+        input: {src: 10.1.2.3, user: {name: alice}}
+        match: true
+      - input: {src: 1.2.3.4, user: {name: alice}}
+        match: false
+  - id: legacy
+    enabled: false
+    action: allow
+    rule: port eq 23
+  - id: maintenance
+    action: allow
+    expires_at: 2026-01-01T00:00:00Z
+    rule: port eq 8080
+`
+
+func TestParseRuleSet(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	rs, err := ParseRuleSet("policy.yaml", []byte(policyYAML), WithClock(func() time.Time { return now }))
+	require.NoError(t, err)
+
+	rules := rs.Rules()
+	require.Len(t, rules, 4)
+	require.Equal(t, NamedRule{
+		ID:          "log-ssh",
+		Rule:        "port eq 22",
+		Description: "log every SSH connection",
+		Tags:        []string{"ssh", "audit"},
+		Priority:    100,
+		Action:      ActionLog,
+		Continue:    true,
+	}, rules[0])
+	require.True(t, rules[2].Disabled)
+	require.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), rules[3].ExpiresAt)
+
+	tests := []struct {
+		item   map[string]interface{}
+		ids    []string
+		action Action
+	}{
+		{map[string]interface{}{"src": "10.0.0.1", "port": 22, "user": map[string]interface{}{"name": "bob"}}, []string{"log-ssh", "allow-internal"}, ActionAllow},
+		{map[string]interface{}{"src": "1.2.3.4", "port": 22}, []string{"log-ssh"}, ActionDeny},
+		{map[string]interface{}{"src": "1.2.3.4", "port": 23}, []string{}, ActionDeny},
+		{map[string]interface{}{"src": "1.2.3.4", "port": 8080}, []string{"maintenance"}, ActionAllow},
+	}
+	for _, tt := range tests {
+		decision, err := rs.Evaluate(tt.item)
+		require.NoError(t, err)
+		require.Equal(t, tt.ids, decision.IDs(), tt.item)
+		require.Equal(t, tt.action, decision.Action, tt.item)
+	}
+	require.Equal(t, []string{"ssh", "audit"}, mustEvaluate(t, rs, map[string]interface{}{"port": 22}).Matches[0].Tags)
+
+	now = now.AddDate(1, 0, 0)
+	require.Empty(t, mustEvaluate(t, rs, map[string]interface{}{"port": 8080}).Matches)
+}
+
+func mustEvaluate(t *testing.T, rs *RuleSet, item map[string]interface{}) *Decision {
+	decision, err := rs.Evaluate(item)
+	require.NoError(t, err)
+	return decision
+}
+
+func TestLoadRuleSetJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{
+		"mode": "all-match",
+		"rules": [
+			{"id": "web", "action": "allow", "rule": "port in [80, 443]", "expires_at": "2100-01-01T00:00:00Z",
+			 "tests": [{"input": {"port": 443}, "match": true}]},
+			{"id": "tls", "action": "tag", "rule": "port eq 443"}
+		]
+	}`), 0o644))
+
+	rs, err := LoadRuleSet(path)
+	require.NoError(t, err)
+	require.Equal(t, []string{"web", "tls"}, mustEvaluate(t, rs, map[string]interface{}{"port": 443}).IDs())
+}
+
+func TestParseRuleSetErrors(t *testing.T) {
+	_, err := ParseRuleSet("policy.yaml", []byte(`
+rules:
+  - id: bad
+    rule: port eq
+  - id: ok
+    rule: port eq 1
+    tests:
+      - name: one
+        input: {port: 2}
+        match: true
+  - id: ok
+    rule: port eq 2
+  - rule: port eq 3
+`))
+	require.Error(t, err)
+	var ruleErr *RuleError
+	require.True(t, errors.As(err, &ruleErr))
+	require.Equal(t, "policy.yaml", ruleErr.File)
+	require.Equal(t, "bad", ruleErr.ID)
+	require.Contains(t, err.Error(), `policy.yaml: rule "bad": invalid rule "port eq"`)
+	require.Contains(t, err.Error(), `policy.yaml: rule "ok": test one: got match false, want true`)
+	require.Contains(t, err.Error(), `policy.yaml: rule "ok": duplicate ID`)
+	require.Contains(t, err.Error(), `policy.yaml: rules[3]: empty ID`)
+
+	_, err = ParseRuleSet("policy.yaml", []byte("rules:\n  - id: x\n    rule: x eq 1\n    priorty: 2\n"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "policy.yaml: yaml: unmarshal errors:\n  line 4: field priorty not found")
+
+	_, err = ParseRuleSet("policy.yaml", []byte("mode: any-match\n"))
+	require.EqualError(t, err, `policy.yaml: unknown mode "any-match"`)
+
+	_, err = LoadRuleSet(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.True(t, errors.Is(err, os.ErrNotExist))
+}
+
+func TestLoadRuleSetDir(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.yaml"), []byte("mode: all-match\nrules:\n  - {id: a, rule: x eq 1}\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.yml"), []byte("rules:\n  - {id: b, rule: x ge 1}\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".c.yaml.swp"), []byte("garbage"), 0o644))
+
+	rs, err := LoadRuleSetDir(dir)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b"}, mustEvaluate(t, rs, map[string]interface{}{"x": 1}).IDs())
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "c.json"), []byte(`{"mode": "first-match", "rules": [{"id": "a", "rule": "x eq 2"}]}`), 0o644))
+	_, err = LoadRuleSetDir(dir)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), filepath.Join(dir, "c.json")+": mode first-match conflicts with all-match of "+filepath.Join(dir, "a.yaml"))
+	require.Contains(t, err.Error(), filepath.Join(dir, "c.json")+`: rule "a": duplicate ID`)
+
+	// a file that can't be decoded is an error, not a crash
+	require.NoError(t, os.Remove(filepath.Join(dir, "c.json")))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "d.yaml"), []byte("rules:\n  - {id: d, rule: [x eq\n"), 0o644))
+	_, err = LoadRuleSetDir(dir)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), filepath.Join(dir, "d.yaml")+": yaml:")
+}
@@ -0,0 +1,93 @@
+package rules
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/alex4386/rules/parser"
+	"github.com/stretchr/testify/require"
+)
+
+var policy = []NamedRule{
+	{ID: "log-ssh", Rule: `port eq 22`, Priority: 100, Action: ActionLog, Continue: true},
+	{ID: "allow-internal", Rule: `src in 10.0.0.0/8`, Priority: 50, Action: ActionAllow},
+	{ID: "deny-ssh", Rule: `port eq 22`, Priority: 50, Action: ActionDeny},
+	{ID: "tag-web", Rule: `port in [80, 443]`, Priority: 10, Action: ActionTag, Continue: true},
+	{ID: "allow-web", Rule: `port in [80, 443]`, Action: ActionAllow},
+}
+
+func TestRuleSetFirstMatch(t *testing.T) {
+	rs, err := NewRuleSet(policy, WithDefaultAction(ActionDeny))
+	require.NoError(t, err)
+
+	tests := []struct {
+		item      map[string]interface{}
+		ids       []string
+		action    Action
+		isDefault bool
+	}{
+		{map[string]interface{}{"src": "10.0.0.1", "port": 22}, []string{"log-ssh", "allow-internal"}, ActionAllow, false},
+		{map[string]interface{}{"src": "1.2.3.4", "port": 22}, []string{"log-ssh", "deny-ssh"}, ActionDeny, false},
+		{map[string]interface{}{"src": "1.2.3.4", "port": 443}, []string{"tag-web", "allow-web"}, ActionAllow, false},
+		{map[string]interface{}{"src": "1.2.3.4", "port": 25}, []string{}, ActionDeny, true},
+	}
+	for _, tt := range tests {
+		decision, err := rs.Evaluate(tt.item)
+		require.NoError(t, err)
+		require.Equal(t, tt.ids, decision.IDs(), tt.item)
+		require.Equal(t, tt.action, decision.Action, tt.item)
+		require.Equal(t, tt.isDefault, decision.Default, tt.item)
+	}
+}
+
+func TestRuleSetAllMatch(t *testing.T) {
+	rs, err := NewRuleSet(policy, WithMode(AllMatch))
+	require.NoError(t, err)
+
+	decision, err := rs.Evaluate(map[string]interface{}{"src": "10.0.0.1", "port": 22})
+	require.NoError(t, err)
+	require.Equal(t, []string{"log-ssh", "allow-internal", "deny-ssh"}, decision.IDs())
+	require.Equal(t, ActionAllow, decision.Action)
+	require.Equal(t, Match{ID: "deny-ssh", Priority: 50, Action: ActionDeny}, decision.Matches[2])
+
+	decision, err = rs.Evaluate(map[string]interface{}{"port": 25})
+	require.NoError(t, err)
+	require.Empty(t, decision.Matches)
+	require.Equal(t, Action(""), decision.Action)
+	require.True(t, decision.Default)
+}
+
+func TestRuleSetOrder(t *testing.T) {
+	rs, err := NewRuleSet(policy)
+	require.NoError(t, err)
+	var ids []string
+	for _, rule := range rs.Rules() {
+		ids = append(ids, rule.ID)
+	}
+	require.Equal(t, []string{"log-ssh", "allow-internal", "deny-ssh", "tag-web", "allow-web"}, ids)
+}
+
+func TestRuleSetErrors(t *testing.T) {
+	_, err := NewRuleSet([]NamedRule{
+		{ID: "ok", Rule: `x eq 1`},
+		{ID: "bad", Rule: `x eq`},
+		{ID: "ok", Rule: `x eq 2`},
+		{Rule: `x eq 3`},
+	})
+	require.Error(t, err)
+	var ruleErr *RuleError
+	require.True(t, errors.As(err, &ruleErr))
+	require.Equal(t, "bad", ruleErr.ID)
+	var parseErr *parser.ParseError
+	require.True(t, errors.As(err, &parseErr))
+	require.Contains(t, err.Error(), `rule "ok": duplicate ID`)
+	require.Contains(t, err.Error(), `rules[3]: empty ID`)
+
+	rs, err := NewRuleSet([]NamedRule{{ID: "strict", Rule: `x eq 1`}}, WithEvaluatorOptions(parser.WithStrict()))
+	require.NoError(t, err)
+	_, err = rs.Evaluate(map[string]interface{}{})
+	require.True(t, errors.As(err, &ruleErr))
+	require.Equal(t, "strict", ruleErr.ID)
+	var evalErr *parser.EvalError
+	require.True(t, errors.As(err, &evalErr))
+}
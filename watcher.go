@@ -0,0 +1,170 @@
+package rules
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+type watcherOptions struct {
+	ruleSetOpts []RuleSetOption
+	debounce    time.Duration
+	onReload    func(*RuleSet)
+	onError     func(error)
+}
+
+type WatcherOption func(*watcherOptions)
+
+// WatchWithRuleSetOptions loads the rule sets with opts
+func WatchWithRuleSetOptions(opts ...RuleSetOption) WatcherOption {
+	return func(o *watcherOptions) {
+		o.ruleSetOpts = append(o.ruleSetOpts, opts...)
+	}
+}
+
+// WatchWithDebounce is how long the directory has to stay unchanged before it
+// is reloaded, so that a file being written or several files being changed
+// together are reloaded once, 100ms by default
+func WatchWithDebounce(d time.Duration) WatcherOption {
+	return func(o *watcherOptions) {
+		o.debounce = d
+	}
+}
+
+// OnReload is called with the new rule set after every successful load,
+// the first one included
+func OnReload(fn func(*RuleSet)) WatcherOption {
+	return func(o *watcherOptions) {
+		o.onReload = fn
+	}
+}
+
+// OnError is called when the directory changed but can't be loaded, the
+// previous rule set staying active, or watching it fails
+func OnError(fn func(error)) WatcherOption {
+	return func(o *watcherOptions) {
+		o.onError = fn
+	}
+}
+
+// Watcher keeps the rule set of a directory of rule set files up to date,
+// reloading it with LoadRuleSetDir when the directory changes. The active rule
+// set is only replaced by one where every rule compiles and passes its tests.
+// A Watcher is safe for concurrent use by multiple goroutines.
+type Watcher struct {
+	dir     string
+	options watcherOptions
+	current atomic.Pointer[RuleSet]
+	mu      sync.Mutex
+	fs      *fsnotify.Watcher
+	done    chan struct{}
+	// inCallback is set while the watching goroutine runs the callbacks,
+	// which then can't wait for it to stop
+	inCallback atomic.Bool
+}
+
+// NewWatcher loads the rule set of dir and watches it for changes until Close.
+// The error is the one of LoadRuleSetDir if dir can't be loaded.
+func NewWatcher(dir string, opts ...WatcherOption) (*Watcher, error) {
+	w := &Watcher{
+		dir:     dir,
+		options: watcherOptions{debounce: 100 * time.Millisecond},
+		done:    make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(&w.options)
+	}
+
+	fs, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	// watch before loading, not to miss a change in between
+	if err := fs.Add(dir); err != nil {
+		fs.Close()
+		return nil, err
+	}
+	if err := w.Reload(); err != nil {
+		fs.Close()
+		return nil, err
+	}
+	w.fs = fs
+	go w.watch()
+	return w, nil
+}
+
+// RuleSet returns the active rule set. It is a snapshot, evaluating it isn't
+// affected by later reloads.
+func (w *Watcher) RuleSet() *RuleSet {
+	return w.current.Load()
+}
+
+// Evaluate evaluates item against the active rule set
+func (w *Watcher) Evaluate(item map[string]interface{}) (*Decision, error) {
+	return w.RuleSet().Evaluate(item)
+}
+
+// Reload loads the directory and makes it the active rule set, e.g. on SIGHUP.
+// On error the active rule set is kept.
+func (w *Watcher) Reload() error {
+	w.mu.Lock()
+	rs, err := LoadRuleSetDir(w.dir, w.options.ruleSetOpts...)
+	if err == nil {
+		w.current.Store(rs)
+	}
+	w.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	// outside the lock, for the callback to be able to reload or close
+	if w.options.onReload != nil {
+		w.options.onReload(rs)
+	}
+	return nil
+}
+
+// Close stops watching the directory, the last rule set staying active. It
+// can be called from the callbacks.
+func (w *Watcher) Close() error {
+	err := w.fs.Close()
+	if !w.inCallback.Load() {
+		<-w.done
+	}
+	return err
+}
+
+func (w *Watcher) watch() {
+	defer close(w.done)
+	var reload <-chan time.Time
+	for {
+		select {
+		case _, ok := <-w.fs.Events:
+			if !ok {
+				return
+			}
+			reload = time.After(w.options.debounce)
+		case err, ok := <-w.fs.Errors:
+			if !ok {
+				return
+			}
+			w.inCallback.Store(true)
+			w.error(err)
+			w.inCallback.Store(false)
+		case <-reload:
+			reload = nil
+			w.inCallback.Store(true)
+			if err := w.Reload(); err != nil {
+				w.error(err)
+			}
+			w.inCallback.Store(false)
+		}
+	}
+}
+
+func (w *Watcher) error(err error) {
+	if w.options.onError != nil {
+		w.options.onError(err)
+	}
+}
@@ -0,0 +1,123 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeRules(t *testing.T, path, rules string) {
+	// write and rename, the way rule files are expected to be updated
+	tmp := filepath.Join(filepath.Dir(path), "."+filepath.Base(path)+".tmp")
+	require.NoError(t, os.WriteFile(tmp, []byte(rules), 0o644))
+	require.NoError(t, os.Rename(tmp, path))
+}
+
+func TestWatcher(t *testing.T) {
+	dir := t.TempDir()
+	writeRules(t, filepath.Join(dir, "ssh.yaml"), "default_action: allow\nrules:\n  - {id: deny-ssh, action: deny, rule: port eq 22}\n")
+	writeRules(t, filepath.Join(dir, "web.json"), `{"rules": [{"id": "deny-web", "action": "deny", "rule": "port eq 80"}]}`)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("not rules"), 0o644))
+
+	reloads := make(chan *RuleSet, 10)
+	errs := make(chan error, 10)
+	w, err := NewWatcher(dir,
+		WatchWithDebounce(10*time.Millisecond),
+		OnReload(func(rs *RuleSet) { reloads <- rs }),
+		OnError(func(err error) { errs <- err }),
+	)
+	require.NoError(t, err)
+	defer w.Close()
+	<-reloads
+
+	port := func(rs *RuleSet, port int) Action {
+		decision, err := rs.Evaluate(map[string]interface{}{"port": port})
+		require.NoError(t, err)
+		return decision.Action
+	}
+	first := w.RuleSet()
+	require.Equal(t, ActionDeny, port(first, 22))
+	require.Equal(t, ActionDeny, port(first, 80))
+
+	writeRules(t, filepath.Join(dir, "web.json"), `{"rules": [{"id": "deny-web", "action": "deny", "rule": "port eq 8080"}]}`)
+	select {
+	case rs := <-reloads:
+		require.Equal(t, rs, w.RuleSet())
+	case err := <-errs:
+		t.Fatal(err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("not reloaded")
+	}
+	require.Equal(t, ActionAllow, port(w.RuleSet(), 80))
+	require.Equal(t, ActionDeny, port(w.RuleSet(), 8080))
+	// an evaluation started before the reload keeps the rules it started with
+	require.Equal(t, ActionDeny, port(first, 80))
+
+	writeRules(t, filepath.Join(dir, "web.json"), `{"rules": [{"id": "deny-ssh", "action": "deny", "rule": "port eq"}]}`)
+	select {
+	case rs := <-reloads:
+		t.Fatalf("reloaded %v", rs.Rules())
+	case err := <-errs:
+		require.Contains(t, err.Error(), `web.json: rule "deny-ssh": duplicate ID`)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no error")
+	}
+	require.Equal(t, ActionDeny, port(w.RuleSet(), 8080))
+
+	// a malformed file keeps the rule set too
+	current := w.RuleSet()
+	writeRules(t, filepath.Join(dir, "web.json"), "rules:\n  - {id: deny-web, rule: [port eq\n")
+	select {
+	case rs := <-reloads:
+		t.Fatalf("reloaded %v", rs.Rules())
+	case err := <-errs:
+		require.Contains(t, err.Error(), "web.json: yaml:")
+	case <-time.After(5 * time.Second):
+		t.Fatal("no error")
+	}
+	require.Equal(t, current, w.RuleSet())
+	require.Equal(t, ActionDeny, port(w.RuleSet(), 8080))
+}
+
+func TestWatcherCallbacks(t *testing.T) {
+	dir := t.TempDir()
+	writeRules(t, filepath.Join(dir, "ssh.yaml"), "rules:\n  - {id: deny-ssh, action: deny, rule: port eq 22}\n")
+
+	// the callbacks can reload and close the watcher that calls them
+	var w atomic.Pointer[Watcher]
+	var reloaded atomic.Bool
+	reloads := make(chan error, 10)
+	closes := make(chan error, 10)
+	watcher, err := NewWatcher(dir,
+		WatchWithDebounce(10*time.Millisecond),
+		OnReload(func(rs *RuleSet) {
+			if cur := w.Load(); cur != nil && reloaded.CompareAndSwap(false, true) {
+				reloads <- cur.Reload()
+			}
+		}),
+		OnError(func(err error) { closes <- w.Load().Close() }),
+	)
+	require.NoError(t, err)
+	w.Store(watcher)
+
+	writeRules(t, filepath.Join(dir, "ssh.yaml"), "rules:\n  - {id: deny-ssh, action: deny, rule: port eq 2222}\n")
+	select {
+	case err := <-reloads:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("not reloaded")
+	}
+
+	writeRules(t, filepath.Join(dir, "ssh.yaml"), "rules:\n  - {id: deny-ssh, rule: [port eq\n")
+	select {
+	case err := <-closes:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("not closed")
+	}
+	require.NoError(t, watcher.Close())
+}